@@ -0,0 +1,29 @@
+package main
+
+import (
+	"go.uber.org/fx/fxevent"
+	"os"
+	"reflect"
+)
+
+// newFxLogger muestra todos los eventos de fx en nivel debug; en el resto
+// de niveles sólo los que traen un error.
+func newFxLogger(level string) fxevent.Logger {
+	console := &fxevent.ConsoleLogger{W: os.Stderr}
+	if level == "debug" {
+		return console
+	}
+
+	return &errorLogger{next: console}
+}
+
+type errorLogger struct {
+	next fxevent.Logger
+}
+
+func (l *errorLogger) LogEvent(event fxevent.Event) {
+	v := reflect.Indirect(reflect.ValueOf(event))
+	if field := v.FieldByName("Err"); field.IsValid() && !field.IsNil() {
+		l.next.LogEvent(event)
+	}
+}
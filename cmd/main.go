@@ -3,34 +3,133 @@ package main
 import (
 	"github.com/wicho90/anime-api/config"
 	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/artwork"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/calendar"
+	"github.com/wicho90/anime-api/internal/cast"
+	"github.com/wicho90/anime-api/internal/comment"
+	"github.com/wicho90/anime-api/internal/credit"
 	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/feed"
+	"github.com/wicho90/anime-api/internal/graph"
+	"github.com/wicho90/anime-api/internal/health"
+	"github.com/wicho90/anime-api/internal/links"
+	"github.com/wicho90/anime-api/internal/mal"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/rating"
+	"github.com/wicho90/anime-api/internal/rpc"
+	"github.com/wicho90/anime-api/internal/schedule"
 	"github.com/wicho90/anime-api/internal/season"
 	"github.com/wicho90/anime-api/internal/server"
+	"github.com/wicho90/anime-api/internal/source"
+	"github.com/wicho90/anime-api/internal/storage"
+	"github.com/wicho90/anime-api/internal/subtitle"
+	"github.com/wicho90/anime-api/internal/taxonomy"
+	"github.com/wicho90/anime-api/internal/telemetry"
 	"github.com/wicho90/anime-api/internal/validator"
+	"github.com/wicho90/anime-api/internal/watchlist"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 	"log"
+	"os"
+	"time"
 )
 
 func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	app := fx.New(
+		fx.Supply(cfg),
+		fx.WithLogger(func() fxevent.Logger {
+			return newFxLogger(cfg.Log.Level)
+		}),
+		// Margen para vaciar las peticiones gRPC y HTTP (cada una con
+		// ShutdownTimeout) y exportar las últimas trazas.
+		fx.StopTimeout(cfg.Server.DrainDelay+2*cfg.Server.ShutdownTimeout+5*time.Second),
 		fx.Provide(
-			config.New,
 			database.New,
+			database.NewReplica,
+			telemetry.New,
+			auth.NewRepository,
+			ratelimit.NewStore,
+			rating.NewRepository,
+			rating.NewService,
+			rating.NewHandler,
+			taxonomy.NewRepository,
+			taxonomy.NewService,
+			taxonomy.NewHandler,
+			cast.NewRepository,
+			cast.NewService,
+			cast.NewHandler,
+			credit.NewRepository,
+			credit.NewService,
+			credit.NewHandler,
+			schedule.NewRepository,
+			schedule.NewService,
+			schedule.NewHandler,
+			calendar.NewRepository,
+			calendar.NewService,
+			calendar.NewHandler,
+			feed.NewRepository,
+			feed.NewService,
+			feed.NewHandler,
 			season.NewRepository,
 			season.NewService,
 			season.NewHandler,
+			season.NewHandlerV2,
 			episode.NewRepository,
 			episode.NewService,
 			episode.NewHandler,
+			episode.NewHandlerV2,
+			source.NewRepository,
+			source.NewService,
+			source.NewHandler,
+			subtitle.NewRepository,
+			subtitle.NewService,
+			subtitle.NewHandler,
+			storage.New,
+			artwork.NewRepository,
+			artwork.NewService,
+			artwork.NewHandler,
+			links.NewPolicy,
+			links.NewRepository,
+			links.NewService,
+			links.NewHandler,
+			links.NewChecker,
+			comment.NewFilter,
+			comment.NewRepository,
+			comment.NewService,
+			comment.NewHandler,
+			watchlist.NewRepository,
+			watchlist.NewService,
+			watchlist.NewHandler,
+			mal.NewRepository,
+			mal.NewService,
+			mal.NewHandler,
+			health.NewHandler,
+			graph.NewHandler,
+			fx.Annotate(health.NewDatabaseChecker, fx.ResultTags(`group:"readiness"`)),
+			fx.Annotate(health.NewReplicaChecker, fx.ResultTags(`group:"readiness,flatten"`)),
+			fx.Annotate(health.NewMigrationsChecker, fx.ResultTags(`group:"readiness"`)),
+			fx.Annotate(ratelimit.NewChecker, fx.ResultTags(`group:"readiness"`)),
 			server.New,
+			rpc.New,
 			func() validator.Validator {
 				return validator.NewCustomValidator()
 			},
 		),
-		fx.Invoke(server.Start),
+		fx.Invoke(server.Start, rpc.Start, links.Start),
 	)
 
-	if err := app.Err(); err != nil {
-		log.Fatal(err)
-	}
+	app.Run()
 }
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/migrations"
+	"log"
+	"os"
+)
+
+func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := database.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := migrations.Up(context.Background(), db); err != nil {
+		log.Fatal(err)
+	}
+}
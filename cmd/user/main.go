@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"log"
+)
+
+// Crea un usuario y muestra su token de acceso, que no vuelve a poder
+// recuperarse. La conexión se configura igual que la API (archivo/entorno).
+func main() {
+	username := flag.String("username", "", "name of the new user")
+	admin := flag.Bool("admin", false, "grant the admin role")
+	flag.Parse()
+
+	if *username == "" {
+		log.Fatal("-username is required")
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := database.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	user := &entities.User{Username: *username, Role: entities.RoleUser}
+	if *admin {
+		user.Role = entities.RoleAdmin
+	}
+
+	err = auth.NewRepository(db).Create(context.Background(), user, auth.HashToken(token))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Created %s %q (id %d)\nToken: %s\n", user.Role, user.Username, user.ID, token)
+}
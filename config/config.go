@@ -1,62 +1,328 @@
 package config
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"time"
 )
 
 type Config struct {
-	Server struct {
-		Port string
-	}
+	// Environment puede ser "development", "staging" o "production".
+	Environment string `yaml:"environment" toml:"environment"`
+	Server      struct {
+		Port         string        `yaml:"port" toml:"port"`
+		ReadTimeout  time.Duration `yaml:"read_timeout" toml:"read_timeout"`
+		WriteTimeout time.Duration `yaml:"write_timeout" toml:"write_timeout"`
+		IdleTimeout  time.Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+		// ShutdownTimeout limita la espera de las peticiones en curso al parar.
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+		// DrainDelay es el tiempo que /readyz falla antes de cerrar el listener.
+		DrainDelay time.Duration `yaml:"drain_delay" toml:"drain_delay"`
+		BodyLimit  int           `yaml:"body_limit" toml:"body_limit"`
+		// ProxyHeader es la cabecera con la IP real del cliente (p. ej.
+		// X-Forwarded-For); sólo se acepta de los TrustedProxies.
+		ProxyHeader    string   `yaml:"proxy_header" toml:"proxy_header"`
+		TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+		StaticDir      string   `yaml:"static_dir" toml:"static_dir"`
+		CORS           struct {
+			// AllowOrigins acepta orígenes exactos, "*" o comodines de
+			// subdominio como "https://*.example.com".
+			AllowOrigins     []string      `yaml:"allow_origins" toml:"allow_origins"`
+			AllowMethods     []string      `yaml:"allow_methods" toml:"allow_methods"`
+			AllowHeaders     []string      `yaml:"allow_headers" toml:"allow_headers"`
+			ExposeHeaders    []string      `yaml:"expose_headers" toml:"expose_headers"`
+			AllowCredentials bool          `yaml:"allow_credentials" toml:"allow_credentials"`
+			MaxAge           time.Duration `yaml:"max_age" toml:"max_age"`
+		} `yaml:"cors" toml:"cors"`
+		// Security ajusta las cabeceras de seguridad. Los campos vacíos toman
+		// el valor del preset.
+		Security struct {
+			// Preset puede ser "development", "staging" o "production"; vacío
+			// usa Environment.
+			Preset                string        `yaml:"preset" toml:"preset"`
+			HSTSMaxAge            time.Duration `yaml:"hsts_max_age" toml:"hsts_max_age"`
+			ContentSecurityPolicy string        `yaml:"content_security_policy" toml:"content_security_policy"`
+			ReferrerPolicy        string        `yaml:"referrer_policy" toml:"referrer_policy"`
+		} `yaml:"security" toml:"security"`
+	} `yaml:"server" toml:"server"`
+	// API controla el ciclo de vida de las versiones de la API. Las fechas se
+	// anuncian en las cabeceras Deprecation y Sunset de las rutas de v1; sin
+	// V1Deprecation, v1 no se anuncia como obsoleta.
+	API struct {
+		V1Deprecation time.Time `yaml:"v1_deprecation,omitempty" toml:"v1_deprecation,omitempty"`
+		V1Sunset      time.Time `yaml:"v1_sunset,omitempty" toml:"v1_sunset,omitempty"`
+	} `yaml:"api" toml:"api"`
+	// GRPC sirve los mismos servicios por gRPC en un puerto propio.
+	GRPC struct {
+		Enabled bool   `yaml:"enabled" toml:"enabled"`
+		Port    string `yaml:"port" toml:"port"`
+	} `yaml:"grpc" toml:"grpc"`
 	Database struct {
-		Host     string
-		Port     string
-		User     string
-		Password string
-		Name     string
-	}
+		// URL es un DSN completo (postgres://...). Si se indica, sustituye a
+		// host, port, user, password y name.
+		URL             string        `yaml:"url" toml:"url"`
+		Host            string        `yaml:"host" toml:"host"`
+		Port            string        `yaml:"port" toml:"port"`
+		User            string        `yaml:"user" toml:"user"`
+		Password        string        `yaml:"password" toml:"password"`
+		Name            string        `yaml:"name" toml:"name"`
+		SSLMode         string        `yaml:"sslmode" toml:"sslmode"`
+		SSLRootCert     string        `yaml:"sslrootcert" toml:"sslrootcert"`
+		SSLCert         string        `yaml:"sslcert" toml:"sslcert"`
+		SSLKey          string        `yaml:"sslkey" toml:"sslkey"`
+		MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns"`
+		MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns"`
+		ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+		ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time"`
+		// ConnectRetries es el número de reintentos del ping inicial; la espera
+		// entre intentos empieza en ConnectBackoff y se duplica hasta
+		// ConnectMaxBackoff.
+		ConnectRetries    int           `yaml:"connect_retries" toml:"connect_retries"`
+		ConnectBackoff    time.Duration `yaml:"connect_backoff" toml:"connect_backoff"`
+		ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff" toml:"connect_max_backoff"`
+		// ReplicaURL es el DSN de la réplica de lectura. Vacío usa la primaria.
+		ReplicaURL string `yaml:"replica_url" toml:"replica_url"`
+	} `yaml:"database" toml:"database"`
+	RateLimit struct {
+		Enabled bool `yaml:"enabled" toml:"enabled"`
+		// Store puede ser "memory" o "redis".
+		Store    string `yaml:"store" toml:"store"`
+		RedisURL string `yaml:"redis_url" toml:"redis_url"`
+		// Read se aplica a GET/HEAD/OPTIONS y Write al resto de métodos.
+		Read  RateLimitPolicy `yaml:"read" toml:"read"`
+		Write RateLimitPolicy `yaml:"write" toml:"write"`
+	} `yaml:"rate_limit" toml:"rate_limit"`
+	// GraphQL limita el coste de las consultas de /graphql antes de ejecutarlas.
+	GraphQL struct {
+		MaxDepth int `yaml:"max_depth" toml:"max_depth"`
+		// MaxComplexity cuenta un punto por campo; los campos de tipo lista
+		// multiplican por ListFactor el coste de su selección.
+		MaxComplexity int `yaml:"max_complexity" toml:"max_complexity"`
+		ListFactor    int `yaml:"list_factor" toml:"list_factor"`
+	} `yaml:"graphql" toml:"graphql"`
+	// Storage guarda las imágenes subidas. PublicURL es la URL base con la
+	// que se publican: para el backend local, la ruta bajo la que app.Static
+	// sirve LocalDir; para S3, la del bucket o su CDN (vacía usa el endpoint).
+	Storage struct {
+		// Backend puede ser "local" o "s3".
+		Backend   string `yaml:"backend" toml:"backend"`
+		LocalDir  string `yaml:"local_dir" toml:"local_dir"`
+		PublicURL string `yaml:"public_url" toml:"public_url"`
+		// MaxImageSize es el tamaño máximo de una imagen en bytes.
+		MaxImageSize int `yaml:"max_image_size" toml:"max_image_size"`
+		S3           struct {
+			// Endpoint permite usar un servicio compatible, como MinIO.
+			Endpoint        string `yaml:"endpoint" toml:"endpoint"`
+			Region          string `yaml:"region" toml:"region"`
+			Bucket          string `yaml:"bucket" toml:"bucket"`
+			AccessKeyID     string `yaml:"access_key_id" toml:"access_key_id"`
+			SecretAccessKey string `yaml:"secret_access_key" toml:"secret_access_key"`
+			// UsePathStyle direcciona el bucket en la ruta (endpoint/bucket/key)
+			// en lugar de en el host, como necesitan MinIO y la mayoría de
+			// servicios compatibles.
+			UsePathStyle bool `yaml:"use_path_style" toml:"use_path_style"`
+		} `yaml:"s3" toml:"s3"`
+	} `yaml:"storage" toml:"storage"`
+	// Links valida las URL de los episodios y comprueba periódicamente que
+	// siguen respondiendo.
+	Links struct {
+		// AllowedHosts limita los hosts de las URL de episodios y fuentes.
+		// Admite comodines de subdominio como "*.example.com"; vacío acepta
+		// cualquier host.
+		AllowedHosts []string `yaml:"allowed_hosts" toml:"allowed_hosts"`
+		// CheckInterval es el tiempo entre dos comprobaciones; 0 las
+		// desactiva.
+		CheckInterval    time.Duration `yaml:"check_interval" toml:"check_interval"`
+		CheckTimeout     time.Duration `yaml:"check_timeout" toml:"check_timeout"`
+		CheckConcurrency int           `yaml:"check_concurrency" toml:"check_concurrency"`
+	} `yaml:"links" toml:"links"`
+	Comments struct {
+		// MaxLength es la longitud máxima de un comentario en caracteres.
+		MaxLength int `yaml:"max_length" toml:"max_length"`
+		// FilterLanguages elige las listas de palabras no permitidas ("es",
+		// "en"); vacío desactiva el filtro.
+		FilterLanguages []string `yaml:"filter_languages" toml:"filter_languages"`
+		// FilterAction puede ser "mask", que sustituye las palabras por
+		// asteriscos, "reject" o "review", que deja el comentario pendiente
+		// de moderación.
+		FilterAction string `yaml:"filter_action" toml:"filter_action"`
+		// ReportThreshold es el número de denuncias que deja un comentario
+		// oculto y pendiente de moderación.
+		ReportThreshold int `yaml:"report_threshold" toml:"report_threshold"`
+	} `yaml:"comments" toml:"comments"`
+	// Feeds configura los feeds RSS y Atom de episodios nuevos.
+	Feeds struct {
+		// Items es el número de episodios de cada feed.
+		Items int `yaml:"items" toml:"items"`
+	} `yaml:"feeds" toml:"feeds"`
+	Log struct {
+		// Level puede ser "debug", "info", "warn" o "error".
+		Level string `yaml:"level" toml:"level"`
+	} `yaml:"log" toml:"log"`
+	Health struct {
+		CheckTimeout time.Duration `yaml:"check_timeout" toml:"check_timeout"`
+	} `yaml:"health" toml:"health"`
+	Telemetry struct {
+		// Exporter puede ser "otlp", "stdout" o "none".
+		Exporter    string `yaml:"exporter" toml:"exporter"`
+		Endpoint    string `yaml:"endpoint" toml:"endpoint"`
+		Insecure    bool   `yaml:"insecure" toml:"insecure"`
+		ServiceName string `yaml:"service_name" toml:"service_name"`
+	} `yaml:"telemetry" toml:"telemetry"`
+
+	// PrintConfig indica que se pidió --print-config; no forma parte del archivo.
+	PrintConfig bool `yaml:"-" toml:"-"`
+}
+
+// RateLimitPolicy separa el presupuesto de los clientes anónimos, que se
+// identifican por IP, del de los usuarios autenticados.
+type RateLimitPolicy struct {
+	IP   RateLimitBudget `yaml:"ip" toml:"ip"`
+	User RateLimitBudget `yaml:"user" toml:"user"`
+}
+
+// RateLimitBudget describe un token bucket: Requests fichas cada Period, con
+// un máximo acumulado de Burst.
+type RateLimitBudget struct {
+	Requests int           `yaml:"requests" toml:"requests"`
+	Period   time.Duration `yaml:"period" toml:"period"`
+	Burst    int           `yaml:"burst" toml:"burst"`
 }
 
-func New() *Config {
-	cfg := &Config{}
+// Load construye la configuración por capas, de menor a mayor prioridad:
+// valores predeterminados, archivo YAML/TOML (--config o CONFIG_FILE),
+// variables de entorno (incluido .env) y flags. El resultado se valida antes
+// de devolverse.
+func Load(args []string) (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
 		log.Println("No se pudo cargar el archivo .env. Se utilizarán las configuraciones predeterminadas.")
 	}
 
-	cfg.Server.Port = os.Getenv("SERVER_PORT")
-	cfg.Database.Host = os.Getenv("DB_HOST")
-	cfg.Database.Port = os.Getenv("DB_PORT")
-	cfg.Database.User = os.Getenv("DB_USER")
-	cfg.Database.Password = os.Getenv("DB_PASSWORD")
-	cfg.Database.Name = os.Getenv("DB_NAME")
-
-	cfg.standard()
+	cfg := defaults()
+	bindings := cfg.bindings()
 
-	log.Printf("Config: %v", cfg)
-
-	return cfg
-}
-
-func (c *Config) standard() {
-	if c.Server.Port == "" {
-		c.Server.Port = "8080"
+	fs := flag.NewFlagSet("anime-api", flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML configuration file")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the effective configuration with secrets redacted and exit")
+	for _, b := range bindings {
+		fs.String(b.flag, "", fmt.Sprintf("%s (env %s)", b.usage, b.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
 	}
-	if c.Database.Host == "" {
-		c.Database.Host = "localhost"
+
+	if *file != "" {
+		if err := cfg.loadFile(*file); err != nil {
+			return nil, err
+		}
 	}
-	if c.Database.Port == "" {
-		c.Database.Port = "5432"
+
+	var errs []error
+	for _, b := range bindings {
+		if value, ok := os.LookupEnv(b.env); ok {
+			if err := b.set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", b.env, err))
+			}
+		}
 	}
-	if c.Database.User == "" {
-		c.Database.User = "postgres"
+	fs.Visit(func(f *flag.Flag) {
+		for _, b := range bindings {
+			if b.flag == f.Name {
+				if err := b.set(f.Value.String()); err != nil {
+					errs = append(errs, fmt.Errorf("--%s: %w", b.flag, err))
+				}
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
-	if c.Database.Password == "" {
-		c.Database.Password = "sasa"
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
-	if c.Database.Name == "" {
-		c.Database.Name = "animedb"
+
+	return cfg, nil
+}
+
+func defaults() *Config {
+	c := &Config{}
+
+	c.Environment = "development"
+
+	c.Server.Port = "8080"
+	c.Server.ReadTimeout = 10 * time.Second
+	c.Server.WriteTimeout = 10 * time.Second
+	c.Server.IdleTimeout = 60 * time.Second
+	c.Server.ShutdownTimeout = 10 * time.Second
+	c.Server.BodyLimit = 4 * 1024 * 1024
+	c.Server.StaticDir = "./public"
+	c.Server.CORS.AllowOrigins = []string{"http://127.0.0.1:5173"}
+	c.Server.CORS.AllowMethods = []string{"GET", "POST", "PUT", "DELETE"}
+	c.Server.CORS.AllowHeaders = []string{"Content-Type", "Authorization"}
+	c.Server.CORS.ExposeHeaders = []string{
+		"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+		"Deprecation", "Sunset", "Link",
 	}
+	c.Server.CORS.MaxAge = 10 * time.Minute
+
+	c.GRPC.Enabled = true
+	c.GRPC.Port = "9090"
+
+	c.Database.Host = "localhost"
+	c.Database.Port = "5432"
+	c.Database.User = "postgres"
+	c.Database.Name = "animedb"
+	c.Database.SSLMode = "disable"
+	c.Database.MaxOpenConns = 25
+	c.Database.MaxIdleConns = 25
+	c.Database.ConnMaxLifetime = 30 * time.Minute
+	c.Database.ConnMaxIdleTime = 5 * time.Minute
+	c.Database.ConnectRetries = 5
+	c.Database.ConnectBackoff = 500 * time.Millisecond
+	c.Database.ConnectMaxBackoff = 10 * time.Second
+
+	c.RateLimit.Enabled = true
+	c.RateLimit.Store = "memory"
+	c.RateLimit.Read.IP = RateLimitBudget{Requests: 300, Period: time.Minute, Burst: 60}
+	c.RateLimit.Read.User = RateLimitBudget{Requests: 600, Period: time.Minute, Burst: 120}
+	c.RateLimit.Write.IP = RateLimitBudget{Requests: 30, Period: time.Minute, Burst: 10}
+	c.RateLimit.Write.User = RateLimitBudget{Requests: 120, Period: time.Minute, Burst: 30}
+
+	c.GraphQL.MaxDepth = 8
+	c.GraphQL.MaxComplexity = 1000
+	c.GraphQL.ListFactor = 10
+
+	c.Storage.Backend = "local"
+	c.Storage.LocalDir = "./public/uploads"
+	c.Storage.PublicURL = "/uploads"
+	c.Storage.MaxImageSize = 3 * 1024 * 1024
+	c.Storage.S3.Endpoint = "https://s3.amazonaws.com"
+	c.Storage.S3.Region = "us-east-1"
+
+	c.Links.CheckInterval = 6 * time.Hour
+	c.Links.CheckTimeout = 10 * time.Second
+	c.Links.CheckConcurrency = 8
+
+	c.Comments.MaxLength = 2000
+	c.Comments.FilterLanguages = []string{"es", "en"}
+	c.Comments.FilterAction = "mask"
+	c.Comments.ReportThreshold = 5
+
+	c.Feeds.Items = 20
+
+	c.Log.Level = "info"
+
+	c.Health.CheckTimeout = 2 * time.Second
+
+	c.Telemetry.Exporter = "none"
+	c.Telemetry.Endpoint = "localhost:4318"
+	c.Telemetry.ServiceName = "anime-api"
+
+	return c
 }
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/url"
+)
+
+const redacted = "REDACTED"
+
+// Redacted devuelve una copia de la configuración sin secretos, apta para
+// imprimirse o registrarse.
+func (c *Config) Redacted() *Config {
+	copied := *c
+	copied.Server.CORS.AllowOrigins = append([]string(nil), c.Server.CORS.AllowOrigins...)
+	copied.Server.CORS.AllowMethods = append([]string(nil), c.Server.CORS.AllowMethods...)
+	copied.Server.CORS.AllowHeaders = append([]string(nil), c.Server.CORS.AllowHeaders...)
+	copied.Server.CORS.ExposeHeaders = append([]string(nil), c.Server.CORS.ExposeHeaders...)
+	copied.Server.TrustedProxies = append([]string(nil), c.Server.TrustedProxies...)
+	copied.Links.AllowedHosts = append([]string(nil), c.Links.AllowedHosts...)
+	copied.Comments.FilterLanguages = append([]string(nil), c.Comments.FilterLanguages...)
+
+	if copied.Database.Password != "" {
+		copied.Database.Password = redacted
+	}
+	copied.Database.URL = redactURL(copied.Database.URL)
+	copied.Database.ReplicaURL = redactURL(copied.Database.ReplicaURL)
+	copied.RateLimit.RedisURL = redactURL(copied.RateLimit.RedisURL)
+	if copied.Storage.S3.SecretAccessKey != "" {
+		copied.Storage.S3.SecretAccessKey = redacted
+	}
+
+	return &copied
+}
+
+// Print escribe la configuración efectiva en YAML con los secretos ocultos.
+func (c *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// redactURL oculta la contraseña de un DSN, incluida la que se pase como
+// parámetro password=.
+func redactURL(dsn string) string {
+	if dsn == "" {
+		return dsn
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return redacted
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	query := u.Query()
+	if query.Has("password") {
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binding enlaza un campo de Config con su variable de entorno y su flag.
+type binding struct {
+	env   string
+	flag  string
+	usage string
+	set   func(value string) error
+}
+
+func (c *Config) bindings() []binding {
+	bindings := []binding{
+		stringBinding("APP_ENV", "env", "development, staging or production", &c.Environment),
+		stringBinding("SERVER_PORT", "port", "HTTP listen port", &c.Server.Port),
+		durationBinding("SERVER_READ_TIMEOUT", "read-timeout", "maximum duration for reading a request", &c.Server.ReadTimeout),
+		durationBinding("SERVER_WRITE_TIMEOUT", "write-timeout", "maximum duration for writing a response", &c.Server.WriteTimeout),
+		durationBinding("SERVER_IDLE_TIMEOUT", "idle-timeout", "keep-alive idle timeout", &c.Server.IdleTimeout),
+		durationBinding("SERVER_SHUTDOWN_TIMEOUT", "shutdown-timeout", "maximum wait for in-flight requests on shutdown", &c.Server.ShutdownTimeout),
+		durationBinding("SERVER_DRAIN_DELAY", "drain-delay", "time /readyz fails before the listener is closed", &c.Server.DrainDelay),
+		intBinding("SERVER_BODY_LIMIT", "body-limit", "maximum request body size in bytes", &c.Server.BodyLimit),
+		stringBinding("SERVER_PROXY_HEADER", "proxy-header", "header carrying the client IP behind a proxy", &c.Server.ProxyHeader),
+		listBinding("SERVER_TRUSTED_PROXIES", "trusted-proxies", "comma-separated proxy IPs/CIDRs allowed to set the proxy header", &c.Server.TrustedProxies),
+		stringBinding("SERVER_STATIC_DIR", "static-dir", "directory served at /", &c.Server.StaticDir),
+		listBinding("CORS_ALLOW_ORIGINS", "cors-allow-origins", "comma-separated CORS origins", &c.Server.CORS.AllowOrigins),
+		listBinding("CORS_ALLOW_METHODS", "cors-allow-methods", "comma-separated CORS methods", &c.Server.CORS.AllowMethods),
+		listBinding("CORS_ALLOW_HEADERS", "cors-allow-headers", "comma-separated CORS request headers", &c.Server.CORS.AllowHeaders),
+		listBinding("CORS_EXPOSE_HEADERS", "cors-expose-headers", "comma-separated response headers readable by browsers", &c.Server.CORS.ExposeHeaders),
+		boolBinding("CORS_ALLOW_CREDENTIALS", "cors-allow-credentials", "allow credentialed CORS requests", &c.Server.CORS.AllowCredentials),
+		durationBinding("CORS_MAX_AGE", "cors-max-age", "how long browsers may cache preflight responses", &c.Server.CORS.MaxAge),
+		stringBinding("SECURITY_PRESET", "security-preset", "security headers preset (defaults to the environment)", &c.Server.Security.Preset),
+		durationBinding("SECURITY_HSTS_MAX_AGE", "hsts-max-age", "Strict-Transport-Security max-age", &c.Server.Security.HSTSMaxAge),
+		stringBinding("SECURITY_CSP", "csp", "Content-Security-Policy for the static files", &c.Server.Security.ContentSecurityPolicy),
+		stringBinding("SECURITY_REFERRER_POLICY", "referrer-policy", "Referrer-Policy header", &c.Server.Security.ReferrerPolicy),
+
+		timeBinding("API_V1_DEPRECATION", "api-v1-deprecation", "date v1 was deprecated (YYYY-MM-DD or RFC 3339)", &c.API.V1Deprecation),
+		timeBinding("API_V1_SUNSET", "api-v1-sunset", "date v1 stops being served (YYYY-MM-DD or RFC 3339)", &c.API.V1Sunset),
+
+		boolBinding("GRPC_ENABLED", "grpc-enabled", "serve the gRPC API", &c.GRPC.Enabled),
+		stringBinding("GRPC_PORT", "grpc-port", "gRPC listen port", &c.GRPC.Port),
+
+		stringBinding("DATABASE_URL", "database-url", "full PostgreSQL DSN (overrides the DB_* connection fields)", &c.Database.URL),
+		stringBinding("DB_HOST", "db-host", "database host", &c.Database.Host),
+		stringBinding("DB_PORT", "db-port", "database port", &c.Database.Port),
+		stringBinding("DB_USER", "db-user", "database user", &c.Database.User),
+		stringBinding("DB_PASSWORD", "db-password", "database password", &c.Database.Password),
+		stringBinding("DB_NAME", "db-name", "database name", &c.Database.Name),
+		stringBinding("DB_SSLMODE", "db-sslmode", "database sslmode", &c.Database.SSLMode),
+		stringBinding("DB_SSLROOTCERT", "db-sslrootcert", "CA certificate used to verify the server", &c.Database.SSLRootCert),
+		stringBinding("DB_SSLCERT", "db-sslcert", "client certificate", &c.Database.SSLCert),
+		stringBinding("DB_SSLKEY", "db-sslkey", "client certificate key", &c.Database.SSLKey),
+		intBinding("DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum open connections (0 = unlimited)", &c.Database.MaxOpenConns),
+		intBinding("DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum idle connections", &c.Database.MaxIdleConns),
+		durationBinding("DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "maximum lifetime of a connection", &c.Database.ConnMaxLifetime),
+		durationBinding("DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "maximum idle time of a connection", &c.Database.ConnMaxIdleTime),
+
+		intBinding("DB_CONNECT_RETRIES", "db-connect-retries", "startup connection retries", &c.Database.ConnectRetries),
+		durationBinding("DB_CONNECT_BACKOFF", "db-connect-backoff", "initial wait between startup retries", &c.Database.ConnectBackoff),
+		durationBinding("DB_CONNECT_MAX_BACKOFF", "db-connect-max-backoff", "maximum wait between startup retries", &c.Database.ConnectMaxBackoff),
+		stringBinding("DATABASE_REPLICA_URL", "database-replica-url", "read replica DSN", &c.Database.ReplicaURL),
+
+		boolBinding("RATE_LIMIT_ENABLED", "rate-limit-enabled", "enable rate limiting", &c.RateLimit.Enabled),
+		stringBinding("RATE_LIMIT_STORE", "rate-limit-store", "memory or redis", &c.RateLimit.Store),
+		stringBinding("RATE_LIMIT_REDIS_URL", "rate-limit-redis-url", "redis:// URL for the redis store", &c.RateLimit.RedisURL),
+	}
+	bindings = append(bindings, budgetBindings("RATE_LIMIT_READ_IP", "rate-limit-read-ip", &c.RateLimit.Read.IP)...)
+	bindings = append(bindings, budgetBindings("RATE_LIMIT_READ_USER", "rate-limit-read-user", &c.RateLimit.Read.User)...)
+	bindings = append(bindings, budgetBindings("RATE_LIMIT_WRITE_IP", "rate-limit-write-ip", &c.RateLimit.Write.IP)...)
+	bindings = append(bindings, budgetBindings("RATE_LIMIT_WRITE_USER", "rate-limit-write-user", &c.RateLimit.Write.User)...)
+
+	return append(bindings, []binding{
+		intBinding("GRAPHQL_MAX_DEPTH", "graphql-max-depth", "maximum selection depth of a GraphQL query", &c.GraphQL.MaxDepth),
+		intBinding("GRAPHQL_MAX_COMPLEXITY", "graphql-max-complexity", "maximum estimated cost of a GraphQL query", &c.GraphQL.MaxComplexity),
+		intBinding("GRAPHQL_LIST_FACTOR", "graphql-list-factor", "cost multiplier applied below list fields", &c.GraphQL.ListFactor),
+
+		stringBinding("STORAGE_BACKEND", "storage-backend", "local or s3", &c.Storage.Backend),
+		stringBinding("STORAGE_LOCAL_DIR", "storage-local-dir", "directory of the local backend, inside the static dir", &c.Storage.LocalDir),
+		stringBinding("STORAGE_PUBLIC_URL", "storage-public-url", "base URL uploaded files are published under", &c.Storage.PublicURL),
+		intBinding("STORAGE_MAX_IMAGE_SIZE", "storage-max-image-size", "maximum image upload size in bytes", &c.Storage.MaxImageSize),
+		stringBinding("S3_ENDPOINT", "s3-endpoint", "S3-compatible endpoint URL", &c.Storage.S3.Endpoint),
+		stringBinding("S3_REGION", "s3-region", "S3 region", &c.Storage.S3.Region),
+		stringBinding("S3_BUCKET", "s3-bucket", "S3 bucket", &c.Storage.S3.Bucket),
+		stringBinding("S3_ACCESS_KEY_ID", "s3-access-key-id", "S3 access key id", &c.Storage.S3.AccessKeyID),
+		stringBinding("S3_SECRET_ACCESS_KEY", "s3-secret-access-key", "S3 secret access key", &c.Storage.S3.SecretAccessKey),
+		boolBinding("S3_USE_PATH_STYLE", "s3-use-path-style", "address the bucket in the path instead of the host", &c.Storage.S3.UsePathStyle),
+
+		listBinding("LINKS_ALLOWED_HOSTS", "links-allowed-hosts", "comma-separated hosts allowed in episode URLs (empty allows any)", &c.Links.AllowedHosts),
+		durationBinding("LINKS_CHECK_INTERVAL", "links-check-interval", "time between broken link checks (0 disables them)", &c.Links.CheckInterval),
+		durationBinding("LINKS_CHECK_TIMEOUT", "links-check-timeout", "timeout for each link check request", &c.Links.CheckTimeout),
+		intBinding("LINKS_CHECK_CONCURRENCY", "links-check-concurrency", "links checked in parallel", &c.Links.CheckConcurrency),
+
+		intBinding("COMMENTS_MAX_LENGTH", "comments-max-length", "maximum comment length in characters", &c.Comments.MaxLength),
+		listBinding("COMMENTS_FILTER_LANGUAGES", "comments-filter-languages", "comma-separated word filter languages (es, en; empty disables it)", &c.Comments.FilterLanguages),
+		stringBinding("COMMENTS_FILTER_ACTION", "comments-filter-action", "mask, reject or review comments with filtered words", &c.Comments.FilterAction),
+		intBinding("COMMENTS_REPORT_THRESHOLD", "comments-report-threshold", "reports that hold a comment for moderation", &c.Comments.ReportThreshold),
+
+		intBinding("FEEDS_ITEMS", "feeds-items", "episodes in each RSS and Atom feed", &c.Feeds.Items),
+
+		stringBinding("LOG_LEVEL", "log-level", "debug, info, warn or error", &c.Log.Level),
+
+		durationBinding("HEALTH_CHECK_TIMEOUT", "health-check-timeout", "timeout for each readiness check", &c.Health.CheckTimeout),
+
+		stringBinding("TELEMETRY_EXPORTER", "telemetry-exporter", "otlp, stdout or none", &c.Telemetry.Exporter),
+		stringBinding("OTEL_EXPORTER_OTLP_ENDPOINT", "otlp-endpoint", "OTLP/HTTP collector host:port", &c.Telemetry.Endpoint),
+		boolBinding("OTEL_EXPORTER_OTLP_INSECURE", "otlp-insecure", "disable TLS for the OTLP exporter", &c.Telemetry.Insecure),
+		stringBinding("OTEL_SERVICE_NAME", "service-name", "service.name resource attribute", &c.Telemetry.ServiceName),
+	}...)
+}
+
+func budgetBindings(env, flag string, budget *RateLimitBudget) []binding {
+	return []binding{
+		intBinding(env+"_REQUESTS", flag+"-requests", "tokens added every period", &budget.Requests),
+		durationBinding(env+"_PERIOD", flag+"-period", "token refill period", &budget.Period),
+		intBinding(env+"_BURST", flag+"-burst", "bucket capacity", &budget.Burst),
+	}
+}
+
+func stringBinding(env, flag, usage string, target *string) binding {
+	return binding{env: env, flag: flag, usage: usage, set: func(value string) error {
+		*target = value
+		return nil
+	}}
+}
+
+func intBinding(env, flag, usage string, target *int) binding {
+	return binding{env: env, flag: flag, usage: usage, set: func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		*target = n
+		return nil
+	}}
+}
+
+func boolBinding(env, flag, usage string, target *bool) binding {
+	return binding{env: env, flag: flag, usage: usage, set: func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		*target = b
+		return nil
+	}}
+}
+
+func durationBinding(env, flag, usage string, target *time.Duration) binding {
+	return binding{env: env, flag: flag, usage: usage, set: func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		*target = d
+		return nil
+	}}
+}
+
+// timeBinding acepta una fecha (2006-01-02, en UTC) o un instante RFC 3339.
+func timeBinding(env, flag, usage string, target *time.Time) binding {
+	return binding{env: env, flag: flag, usage: usage, set: func(value string) error {
+		if value == "" {
+			*target = time.Time{}
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t, err = time.Parse(time.DateOnly, value)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid date %q", value)
+		}
+		*target = t
+		return nil
+	}}
+}
+
+func listBinding(env, flag, usage string, target *[]string) binding {
+	return binding{env: env, flag: flag, usage: usage, set: func(value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*target = items
+		return nil
+	}}
+}
+
+// loadFile sobrescribe c con los valores presentes en el archivo. El formato
+// se deduce de la extensión.
+func (c *Config) loadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(c); err == io.EOF {
+			err = nil
+		}
+	case ".toml":
+		var meta toml.MetaData
+		meta, err = toml.Decode(string(content), c)
+		if err == nil && len(meta.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown keys %v", meta.Undecoded())
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
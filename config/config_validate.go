@@ -0,0 +1,219 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	sslModes  = map[string]bool{
+		"disable": true, "allow": true, "prefer": true,
+		"require": true, "verify-ca": true, "verify-full": true,
+	}
+	exporters       = map[string]bool{"otlp": true, "stdout": true, "none": true}
+	environments    = map[string]bool{"development": true, "staging": true, "production": true}
+	filterLanguages = map[string]bool{"es": true, "en": true}
+	filterActions   = map[string]bool{"mask": true, "reject": true, "review": true}
+)
+
+// Validate comprueba que la configuración sea utilizable, devolviendo todos
+// los problemas encontrados a la vez.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(environments[c.Environment], "environment %q must be one of development, staging, production", c.Environment)
+	check(c.Server.Security.Preset == "" || environments[c.Server.Security.Preset],
+		"server.security.preset %q must be one of development, staging, production", c.Server.Security.Preset)
+	check(c.Server.Security.HSTSMaxAge >= 0, "server.security.hsts_max_age must not be negative")
+	check(c.Server.CORS.MaxAge >= 0, "server.cors.max_age must not be negative")
+	check(validPort(c.Server.Port), "server.port %q is not a valid port", c.Server.Port)
+	check(c.Server.ReadTimeout >= 0, "server.read_timeout must not be negative")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.DrainDelay >= 0, "server.drain_delay must not be negative")
+	check(c.Server.BodyLimit > 0, "server.body_limit must be positive")
+	check(len(c.Server.CORS.AllowOrigins) > 0, "server.cors.allow_origins must not be empty")
+	for _, origin := range c.Server.CORS.AllowOrigins {
+		check(validOrigin(origin), "server.cors.allow_origins: %q is not a valid origin", origin)
+	}
+	check(!(c.Server.CORS.AllowCredentials && contains(c.Server.CORS.AllowOrigins, "*")),
+		"server.cors.allow_credentials cannot be combined with the \"*\" origin")
+
+	check(c.API.V1Sunset.IsZero() || !c.API.V1Deprecation.IsZero(), "api.v1_sunset requires api.v1_deprecation")
+	check(c.API.V1Sunset.IsZero() || c.API.V1Deprecation.IsZero() || c.API.V1Sunset.After(c.API.V1Deprecation),
+		"api.v1_sunset must be later than api.v1_deprecation")
+
+	if c.GRPC.Enabled {
+		check(validPort(c.GRPC.Port), "grpc.port %q is not a valid port", c.GRPC.Port)
+		check(c.GRPC.Port != c.Server.Port, "grpc.port must differ from server.port")
+	}
+
+	if c.Database.URL != "" {
+		check(validDSN(c.Database.URL), "database.url must be a postgres:// or postgresql:// URL")
+	} else {
+		check(c.Database.Host != "", "database.host is required")
+		check(validPort(c.Database.Port), "database.port %q is not a valid port", c.Database.Port)
+		check(c.Database.User != "", "database.user is required")
+		check(c.Database.Password != "", "database.password is required")
+		check(c.Database.Name != "", "database.name is required")
+	}
+	check(c.Database.ReplicaURL == "" || validDSN(c.Database.ReplicaURL),
+		"database.replica_url must be a postgres:// or postgresql:// URL")
+	check(sslModes[c.Database.SSLMode], "database.sslmode %q is not supported", c.Database.SSLMode)
+	check((c.Database.SSLCert == "") == (c.Database.SSLKey == ""),
+		"database.sslcert and database.sslkey must be set together")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must not exceed database.max_open_conns")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time must not be negative")
+	check(c.Database.ConnectRetries >= 0, "database.connect_retries must not be negative")
+	check(c.Database.ConnectBackoff > 0, "database.connect_backoff must be positive")
+	check(c.Database.ConnectMaxBackoff >= c.Database.ConnectBackoff,
+		"database.connect_max_backoff must not be lower than database.connect_backoff")
+
+	check(c.RateLimit.Store == "memory" || c.RateLimit.Store == "redis",
+		"rate_limit.store %q must be memory or redis", c.RateLimit.Store)
+	check(c.RateLimit.Store != "redis" || c.RateLimit.RedisURL != "",
+		"rate_limit.redis_url is required for the redis store")
+	for _, budget := range []struct {
+		name string
+		RateLimitBudget
+	}{
+		{"read.ip", c.RateLimit.Read.IP},
+		{"read.user", c.RateLimit.Read.User},
+		{"write.ip", c.RateLimit.Write.IP},
+		{"write.user", c.RateLimit.Write.User},
+	} {
+		check(budget.Requests > 0 && budget.Period > 0 && budget.Burst > 0,
+			"rate_limit.%s needs positive requests, period and burst", budget.name)
+	}
+
+	check(c.GraphQL.MaxDepth > 0, "graphql.max_depth must be positive")
+	check(c.GraphQL.MaxComplexity > 0, "graphql.max_complexity must be positive")
+	check(c.GraphQL.ListFactor > 0, "graphql.list_factor must be positive")
+
+	check(c.Storage.MaxImageSize > 0, "storage.max_image_size must be positive")
+	check(c.Storage.MaxImageSize < c.Server.BodyLimit, "storage.max_image_size must be lower than server.body_limit")
+	switch c.Storage.Backend {
+	case "local":
+		check(c.Storage.PublicURL != "", "storage.public_url is required for the local backend")
+		check(within(c.Server.StaticDir, c.Storage.LocalDir),
+			"storage.local_dir must be inside server.static_dir to be served")
+		check(!strings.HasPrefix(c.Storage.PublicURL, "/") || servedAt(c.Server.StaticDir, c.Storage.LocalDir, c.Storage.PublicURL),
+			"storage.public_url %q does not match where server.static_dir serves storage.local_dir", c.Storage.PublicURL)
+	case "s3":
+		check(validHTTPURL(c.Storage.S3.Endpoint), "storage.s3.endpoint must be an http:// or https:// URL")
+		check(c.Storage.PublicURL == "" || validHTTPURL(c.Storage.PublicURL),
+			"storage.public_url must be an http:// or https:// URL for the s3 backend")
+		check(c.Storage.S3.Region != "", "storage.s3.region is required")
+		check(c.Storage.S3.Bucket != "", "storage.s3.bucket is required")
+		check(c.Storage.S3.AccessKeyID != "" && c.Storage.S3.SecretAccessKey != "",
+			"storage.s3.access_key_id and storage.s3.secret_access_key are required")
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend %q must be local or s3", c.Storage.Backend))
+	}
+
+	for _, host := range c.Links.AllowedHosts {
+		check(validHostPattern(host), "links.allowed_hosts entry %q must be a host name or *.domain", host)
+	}
+	check(c.Links.CheckInterval >= 0, "links.check_interval must not be negative")
+	check(c.Links.CheckTimeout > 0, "links.check_timeout must be positive")
+	check(c.Links.CheckConcurrency > 0, "links.check_concurrency must be positive")
+
+	check(c.Comments.MaxLength > 0, "comments.max_length must be positive")
+	for _, language := range c.Comments.FilterLanguages {
+		check(filterLanguages[language], "comments.filter_languages entry %q must be es or en", language)
+	}
+	check(filterActions[c.Comments.FilterAction], "comments.filter_action %q must be mask, reject or review", c.Comments.FilterAction)
+	check(c.Comments.ReportThreshold > 0, "comments.report_threshold must be positive")
+
+	check(c.Feeds.Items > 0 && c.Feeds.Items <= 100, "feeds.items must be between 1 and 100")
+
+	check(logLevels[c.Log.Level], "log.level %q must be one of debug, info, warn, error", c.Log.Level)
+
+	check(c.Health.CheckTimeout > 0, "health.check_timeout must be positive")
+
+	check(exporters[c.Telemetry.Exporter], "telemetry.exporter %q must be one of otlp, stdout, none", c.Telemetry.Exporter)
+	check(c.Telemetry.Exporter != "otlp" || c.Telemetry.Endpoint != "", "telemetry.endpoint is required for the otlp exporter")
+	check(c.Telemetry.ServiceName != "", "telemetry.service_name is required")
+
+	return errors.Join(errs...)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+func validDSN(dsn string) bool {
+	u, err := url.Parse(dsn)
+	return err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") && u.Host != ""
+}
+
+func validHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// within indica si dir está dentro de root.
+func within(root, dir string) bool {
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// servedAt indica si app.Static, que sirve root en "/", sirve dir en la ruta
+// publicURL.
+func servedAt(root, dir, publicURL string) bool {
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && "/"+filepath.ToSlash(rel) == strings.TrimSuffix(publicURL, "/")
+}
+
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+		return false
+	}
+
+	// El comodín sólo puede ocupar la primera etiqueta: https://*.example.com
+	host := strings.TrimPrefix(u.Host, "*.")
+	return !strings.Contains(host, "*")
+}
+
+// validHostPattern acepta un nombre de host, con puerto opcional, o un
+// comodín de subdominio como "*.example.com".
+func validHostPattern(pattern string) bool {
+	host := strings.TrimPrefix(pattern, "*.")
+	if host == "" || strings.ContainsAny(host, "*/@?#") {
+		return false
+	}
+
+	u, err := url.Parse("http://" + host)
+	return err == nil && u.Host == host && u.Hostname() != ""
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,31 +1,151 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/lib/pq"
 	"github.com/wicho90/anime-api/config"
+	"log"
+	"net/url"
+	"strings"
+	"time"
 )
 
+// Replica es la conexión usada por las rutas de sólo lectura. Cuando no se
+// configura una réplica apunta a la misma *sql.DB que la primaria.
+type Replica struct {
+	*sql.DB
+}
+
 func New(config *config.Config) (*sql.DB, error) {
-	host := config.Database.Host
-	port := config.Database.Port
-	user := config.Database.User
-	password := config.Database.Password
-	dbName := config.Database.Name
+	dsn := config.Database.URL
+	if dsn == "" {
+		dsn = keywordDSN(config)
+	} else {
+		dsn = withTLS(dsn, config)
+	}
+
+	return open("primary", dsn, config)
+}
+
+// NewReplica abre la réplica de lectura configurada en Database.ReplicaURL o,
+// si no hay ninguna, reutiliza primary.
+func NewReplica(config *config.Config, primary *sql.DB) (*Replica, error) {
+	if config.Database.ReplicaURL == "" {
+		return &Replica{DB: primary}, nil
+	}
+
+	db, err := open("replica", withTLS(config.Database.ReplicaURL, config), config)
+	if err != nil {
+		return nil, err
+	}
 
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbName)
+	return &Replica{DB: db}, nil
+}
 
-	db, err := sql.Open("postgres", connStr)
+func open(name, dsn string, config *config.Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.Ping()
+	db.SetMaxOpenConns(config.Database.MaxOpenConns)
+	db.SetMaxIdleConns(config.Database.MaxIdleConns)
+	db.SetConnMaxLifetime(config.Database.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.Database.ConnMaxIdleTime)
+
+	err = ping(db, name, config)
 	if err != nil {
+		_ = db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// ping reintenta la conexión con espera exponencial para tolerar que
+// Postgres todavía no esté disponible al arrancar.
+func ping(db *sql.DB, name string, config *config.Config) error {
+	backoff := config.Database.ConnectBackoff
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = db.PingContext(context.Background())
+		if err == nil || attempt >= config.Database.ConnectRetries {
+			break
+		}
+
+		log.Printf("%s database not ready (%s), retrying in %s", name, err, backoff)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > config.Database.ConnectMaxBackoff {
+			backoff = config.Database.ConnectMaxBackoff
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s database: %w", name, err)
+	}
+
+	return nil
+}
+
+func keywordDSN(config *config.Config) string {
+	params := []string{
+		"host=" + quote(config.Database.Host),
+		"port=" + quote(config.Database.Port),
+		"user=" + quote(config.Database.User),
+		"password=" + quote(config.Database.Password),
+		"dbname=" + quote(config.Database.Name),
+		"sslmode=" + quote(config.Database.SSLMode),
+	}
+	for key, value := range tlsParams(config) {
+		params = append(params, key+"="+quote(value))
+	}
+
+	return strings.Join(params, " ")
+}
+
+// withTLS completa un DSN en formato URL con los parámetros TLS de la
+// configuración que el propio DSN no indique.
+func withTLS(dsn string, config *config.Config) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+
+	query := u.Query()
+	if !query.Has("sslmode") {
+		query.Set("sslmode", config.Database.SSLMode)
+	}
+	for key, value := range tlsParams(config) {
+		if !query.Has(key) {
+			query.Set(key, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+func tlsParams(config *config.Config) map[string]string {
+	params := map[string]string{}
+	if config.Database.SSLRootCert != "" {
+		params["sslrootcert"] = config.Database.SSLRootCert
+	}
+	if config.Database.SSLCert != "" {
+		params["sslcert"] = config.Database.SSLCert
+		params["sslkey"] = config.Database.SSLKey
+	}
+
+	return params
+}
+
+// quote escapa un valor para el formato clave=valor de lib/pq.
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+
+	return "'" + value + "'"
+}
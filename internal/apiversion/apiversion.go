@@ -0,0 +1,130 @@
+package apiversion
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/response"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Versions son las versiones que sirve la API; la última es la recomendada.
+var Versions = []string{"v1", "v2"}
+
+// MediaType es el tipo de contenido con el que un cliente pide una versión
+// concreta en la cabecera Accept: application/vnd.anime-api.v2+json.
+const MediaType = "application/vnd.anime-api.%s+json"
+
+var (
+	vendorPattern = regexp.MustCompile(`application/vnd\.anime-api\.(v[0-9]+)\+json`)
+	pathPattern   = regexp.MustCompile(`^/api/(v[0-9]+)/([^/]+)`)
+)
+
+// Negotiate permite elegir la versión con la cabecera Accept en lugar de la
+// ruta: GET /api/v1/seasons con Accept: application/vnd.anime-api.v2+json se
+// atiende como GET /api/v2/seasons. Sólo se reescriben los recursos
+// indicados, que deben existir en todas las versiones; una versión
+// desconocida responde 406. Debe registrarse antes que el resto de
+// middlewares, porque el enrutado vuelve a empezar con la nueva ruta.
+func Negotiate(resources ...string) fiber.Handler {
+	versioned := map[string]bool{}
+	for _, resource := range resources {
+		versioned[resource] = true
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		match := pathPattern.FindStringSubmatch(ctx.Path())
+		if match == nil {
+			return ctx.Next()
+		}
+		ctx.Vary(fiber.HeaderAccept)
+
+		requested := vendorPattern.FindStringSubmatch(ctx.Get(fiber.HeaderAccept))
+		if requested == nil || !versioned[match[2]] {
+			return ctx.Next()
+		}
+
+		version := requested[1]
+		if !supported(version) {
+			return response.NewNotAcceptableResponse(fmt.Sprintf(
+				"API version %s is not available, use one of %s", version, strings.Join(Versions, ", ")))
+		}
+
+		if version == match[1] {
+			return next(ctx, version)
+		}
+
+		ctx.Path("/api/" + version + strings.TrimPrefix(ctx.Path(), "/api/"+match[1]))
+		if err := ctx.RestartRouting(); err != nil {
+			return err
+		}
+		setContentType(ctx, version)
+
+		return nil
+	}
+}
+
+func next(ctx *fiber.Ctx, version string) error {
+	if err := ctx.Next(); err != nil {
+		return err
+	}
+	setContentType(ctx, version)
+
+	return nil
+}
+
+// setContentType responde con el mismo tipo que pidió el cliente cuando el
+// cuerpo es JSON.
+func setContentType(ctx *fiber.Ctx, version string) {
+	if strings.HasPrefix(string(ctx.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+		ctx.Set(fiber.HeaderContentType, fmt.Sprintf(MediaType, version))
+	}
+}
+
+func supported(version string) bool {
+	for _, v := range Versions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deprecate marca las respuestas de una versión obsoleta con las cabeceras
+// Deprecation (RFC 9745) y Sunset (RFC 8594), y enlaza el mismo recurso en
+// la versión que la sustituye con Link rel="successor-version". Mientras no
+// se configure la fecha de obsolescencia no añade ninguna cabecera.
+func Deprecate(version, successor string, config *config.Config) fiber.Handler {
+	deprecation, sunset := dates(version, config)
+	prefix := "/api/" + version + "/"
+
+	return func(ctx *fiber.Ctx) error {
+		if deprecation.IsZero() {
+			return ctx.Next()
+		}
+
+		ctx.Set("Deprecation", fmt.Sprintf("@%d", deprecation.Unix()))
+		if !sunset.IsZero() {
+			ctx.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		if path := ctx.Path(); strings.HasPrefix(path, prefix) {
+			ctx.Append(fiber.HeaderLink,
+				fmt.Sprintf(`</api/%s/%s>; rel="successor-version"`, successor, strings.TrimPrefix(path, prefix)))
+		}
+
+		return ctx.Next()
+	}
+}
+
+func dates(version string, config *config.Config) (time.Time, time.Time) {
+	switch version {
+	case "v1":
+		return config.API.V1Deprecation, config.API.V1Sunset
+	}
+
+	return time.Time{}, time.Time{}
+}
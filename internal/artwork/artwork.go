@@ -0,0 +1,29 @@
+package artwork
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type Repository interface {
+	Get(ctx context.Context, ownerType string, ownerID uint64) (*entities.Image, error)
+	// Save crea o reemplaza la imagen del propietario y, si es una
+	// temporada, apunta seasons.image_url a publicUrl. Devuelve la clave de
+	// la imagen reemplazada, vacía si no había ninguna.
+	Save(ctx context.Context, image *entities.Image, publicUrl string) (string, error)
+}
+
+type Service interface {
+	Get(ctx context.Context, ownerType string, ownerID uint64) (*entities.Image, error)
+	// Upload valida la imagen, genera sus variantes y las guarda junto con
+	// el original, reemplazando la imagen anterior del propietario.
+	Upload(ctx context.Context, ownerType string, ownerID uint64, content []byte) (*entities.Image, error)
+}
+
+type Handler interface {
+	GetSeasonImage(ctx *fiber.Ctx) error
+	UploadSeasonImage(ctx *fiber.Ctx) error
+	GetEpisodeThumbnail(ctx *fiber.Ctx) error
+	UploadEpisodeThumbnail(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,38 @@
+package artwork
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+type imageVariants struct {
+	Original  string `json:"original"`
+	Thumbnail string `json:"thumbnail"`
+	Card      string `json:"card"`
+	Banner    string `json:"banner"`
+}
+
+type imageResponse struct {
+	ContentType string        `json:"content_type"`
+	Width       int           `json:"width"`
+	Height      int           `json:"height"`
+	Size        int           `json:"size"`
+	Variants    imageVariants `json:"variants"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+func newImageResponse(image *entities.Image) *imageResponse {
+	return &imageResponse{
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		Size:        image.Size,
+		Variants: imageVariants{
+			Original:  image.Variants[original],
+			Thumbnail: image.Variants["thumbnail"],
+			Card:      image.Variants["card"],
+			Banner:    image.Variants["banner"],
+		},
+		UpdatedAt: image.UpdatedAt,
+	}
+}
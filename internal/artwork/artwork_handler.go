@@ -0,0 +1,99 @@
+package artwork
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service      Service
+	maxImageSize int
+}
+
+func NewHandler(service Service, config *config.Config) Handler {
+	return &handler{service: service, maxImageSize: config.Storage.MaxImageSize}
+}
+
+func (h *handler) GetSeasonImage(ctx *fiber.Ctx) error {
+	return h.get(ctx, entities.ImageOwnerSeason)
+}
+
+func (h *handler) UploadSeasonImage(ctx *fiber.Ctx) error {
+	return h.upload(ctx, entities.ImageOwnerSeason)
+}
+
+func (h *handler) GetEpisodeThumbnail(ctx *fiber.Ctx) error {
+	return h.get(ctx, entities.ImageOwnerEpisode)
+}
+
+func (h *handler) UploadEpisodeThumbnail(ctx *fiber.Ctx) error {
+	return h.upload(ctx, entities.ImageOwnerEpisode)
+}
+
+func (h *handler) get(ctx *fiber.Ctx, ownerType string) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	image, err := h.service.Get(ctx.UserContext(), ownerType, id)
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to get image")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newImageResponse(image))
+}
+
+// upload recibe la imagen en el campo "image" de un formulario multipart.
+func (h *handler) upload(ctx *fiber.Ctx, ownerType string) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		return response.NewBadRequestResponse("The image field is required")
+	}
+	if header.Size > int64(h.maxImageSize) {
+		return response.NewBadRequestResponse(fmt.Sprintf("The image must not be larger than %d bytes", h.maxImageSize))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to read image")
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to read image")
+	}
+
+	image, err := h.service.Upload(ctx.UserContext(), ownerType, id, content)
+	if err != nil {
+		var validation *ex.ErrValidation
+		switch {
+		case errors.Is(err, ex.ErrNotFound):
+			return response.NewNotFoundResponse(err.Error())
+		case errors.As(err, &validation):
+			return response.NewBadRequestResponse(validation.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to upload image")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newImageResponse(image))
+}
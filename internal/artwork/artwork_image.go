@@ -0,0 +1,125 @@
+package artwork
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/wicho90/anime-api/internal/ex"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"math"
+	"net/http"
+)
+
+const (
+	// maxPixels evita decodificar imágenes enormes con poco peso en disco.
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+// extensions son los tipos de imagen aceptados, detectados por su contenido,
+// con la extensión con la que se guarda el original.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// variant es una versión redimensionada de la imagen. Las variantes con
+// Crop recortan la imagen para llenar exactamente Width x Height; el resto
+// la encajan conservando su proporción. Nunca se amplía la imagen.
+type variant struct {
+	Name   string
+	Width  int
+	Height int
+	Crop   bool
+}
+
+var variants = []variant{
+	{Name: "thumbnail", Width: 200, Height: 200},
+	{Name: "card", Width: 600, Height: 600},
+	{Name: "banner", Width: 1600, Height: 500, Crop: true},
+}
+
+// sniff devuelve el tipo de la imagen según su contenido, sin fiarse de la
+// extensión ni del Content-Type que envía el cliente.
+func sniff(content []byte) (string, error) {
+	contentType := http.DetectContentType(content)
+	if _, ok := extensions[contentType]; !ok {
+		return "", &ex.ErrValidation{Field: "image", Reason: "must be a JPEG, PNG, WebP or GIF image"}
+	}
+
+	return contentType, nil
+}
+
+func decode(content []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || config.Width == 0 || config.Height == 0 {
+		return nil, &ex.ErrValidation{Field: "image", Reason: "could not be decoded"}
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, &ex.ErrValidation{Field: "image", Reason: fmt.Sprintf("must not exceed %d pixels", maxPixels)}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, &ex.ErrValidation{Field: "image", Reason: "could not be decoded"}
+	}
+
+	return img, nil
+}
+
+// resize genera la variante como JPEG. La transparencia se aplana sobre
+// blanco.
+func resize(src image.Image, v variant) ([]byte, error) {
+	bounds := src.Bounds()
+	srcRect := bounds
+	width, height := fit(bounds.Dx(), bounds.Dy(), v.Width, v.Height)
+
+	if v.Crop {
+		srcRect = crop(bounds, v.Width, v.Height)
+		width, height = v.Width, v.Height
+		if srcRect.Dx() < width {
+			width, height = srcRect.Dx(), srcRect.Dy()
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fit devuelve el mayor tamaño que cabe en maxWidth x maxHeight con la
+// proporción de width x height, sin superar el tamaño original.
+func fit(width, height, maxWidth, maxHeight int) (int, int) {
+	scale := math.Min(1, math.Min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height)))
+
+	return int(math.Max(1, math.Round(float64(width)*scale))), int(math.Max(1, math.Round(float64(height)*scale)))
+}
+
+// crop devuelve el mayor rectángulo centrado de bounds con la proporción
+// width x height.
+func crop(bounds image.Rectangle, width, height int) image.Rectangle {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w*height > h*width {
+		cropped := h * width / height
+		x := bounds.Min.X + (w-cropped)/2
+		return image.Rect(x, bounds.Min.Y, x+cropped, bounds.Max.Y)
+	}
+
+	cropped := w * height / width
+	y := bounds.Min.Y + (h-cropped)/2
+	return image.Rect(bounds.Min.X, y, bounds.Max.X, y+cropped)
+}
@@ -0,0 +1,82 @@
+package artwork
+
+import (
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+var example = imageResponse{
+	ContentType: "image/png",
+	Width:       1920,
+	Height:      1080,
+	Size:        1048576,
+	Variants: imageVariants{
+		Original:  "/uploads/seasons/1/3f2a9c0d1b7e4a66/original.png",
+		Thumbnail: "/uploads/seasons/1/3f2a9c0d1b7e4a66/thumbnail.jpg",
+		Card:      "/uploads/seasons/1/3f2a9c0d1b7e4a66/card.jpg",
+		Banner:    "/uploads/seasons/1/3f2a9c0d1b7e4a66/banner.jpg",
+	},
+	UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+}
+
+// OpenAPI documenta la subida de imágenes de temporadas y miniaturas de
+// episodios.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("ImageV2", imageResponse{})
+	body := &openapi.RequestBody{
+		Required: true,
+		Content: map[string]*openapi.MediaType{
+			"multipart/form-data": {Schema: &openapi.Schema{
+				Type:       "object",
+				Required:   []string{"image"},
+				Properties: map[string]*openapi.Schema{"image": {Type: "string", Format: "binary"}},
+			}},
+		},
+	}
+	description := "Accepts JPEG, PNG, WebP and GIF images, detected from their content, up to storage.max_image_size bytes. " +
+		"Generates a 200x200 thumbnail and a 600x600 card that keep the proportions, and a cropped 1600x500 banner, all as JPEG. " +
+		"Each upload is published under new URLs, replacing the previous image. " +
+		"Uploading the artwork of a season also sets its image_url to the original. Requires an administrator token."
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	for _, owner := range []struct {
+		path, id, name, summary string
+	}{
+		{"/api/v2/seasons/:id/image", "SeasonImageV2", "season", "the artwork of a season"},
+		{"/api/v2/episodes/:id/thumbnail", "EpisodeThumbnailV2", "episode", "the thumbnail of an episode"},
+	} {
+		notFound := openapi.Error(http.StatusNotFound, "image of "+owner.name+" 1 not found")
+
+		doc.Add(http.MethodGet, owner.path, &openapi.Operation{
+			OperationID: "get" + owner.id,
+			Summary:     "Get " + owner.summary,
+			Tags:        []string{"images"},
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON("Image", schema, example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusNotFound:            notFound,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get image"),
+			}),
+		})
+		doc.Add(http.MethodPut, owner.path, &openapi.Operation{
+			OperationID: "upload" + owner.id,
+			Summary:     "Upload " + owner.summary,
+			Description: description,
+			Tags:        []string{"images"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			RequestBody: body,
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON("Uploaded image", schema, example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'image' must be a JPEG, PNG, WebP or GIF image"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusNotFound:            openapi.Error(http.StatusNotFound, owner.name+" with id 1 not found"),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to upload image"),
+			}),
+		})
+	}
+}
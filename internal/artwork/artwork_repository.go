@@ -0,0 +1,110 @@
+package artwork
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+)
+
+const (
+	queryGetBySeason = `SELECT id, season_id, content_type, width, height, size_bytes, storage_key, updated_at
+		FROM images WHERE season_id = $1`
+	queryGetByEpisode = `SELECT id, episode_id, content_type, width, height, size_bytes, storage_key, updated_at
+		FROM images WHERE episode_id = $1`
+	// La subconsulta de RETURNING ve la fila anterior al upsert, así que
+	// devuelve la clave que se reemplaza.
+	querySaveSeason = `INSERT INTO images (season_id, content_type, width, height, size_bytes, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (season_id) DO UPDATE
+		SET content_type = EXCLUDED.content_type, width = EXCLUDED.width, height = EXCLUDED.height,
+			size_bytes = EXCLUDED.size_bytes, storage_key = EXCLUDED.storage_key, updated_at = NOW()
+		RETURNING id, updated_at, COALESCE((SELECT storage_key FROM images WHERE season_id = $1), '')`
+	querySaveEpisode = `INSERT INTO images (episode_id, content_type, width, height, size_bytes, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (episode_id) DO UPDATE
+		SET content_type = EXCLUDED.content_type, width = EXCLUDED.width, height = EXCLUDED.height,
+			size_bytes = EXCLUDED.size_bytes, storage_key = EXCLUDED.storage_key, updated_at = NOW()
+		RETURNING id, updated_at, COALESCE((SELECT storage_key FROM images WHERE episode_id = $1), '')`
+	queryUpdateSeasonImageUrl = "UPDATE seasons SET image_url = $1 WHERE id = $2"
+)
+
+var (
+	getQueries  = map[string]string{entities.ImageOwnerSeason: queryGetBySeason, entities.ImageOwnerEpisode: queryGetByEpisode}
+	saveQueries = map[string]string{entities.ImageOwnerSeason: querySaveSeason, entities.ImageOwnerEpisode: querySaveEpisode}
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) Get(ctx context.Context, ownerType string, ownerID uint64) (*entities.Image, error) {
+	query := getQueries[ownerType]
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBy"+ownerType, query)
+	defer span.End()
+
+	image := &entities.Image{OwnerType: ownerType}
+	err := r.replica.QueryRowContext(ctx, query, ownerID).Scan(&image.ID, &image.OwnerID, &image.ContentType,
+		&image.Width, &image.Height, &image.Size, &image.Key, &image.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("image of %s %d %w", ownerType, ownerID, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (r *repository) Save(ctx context.Context, image *entities.Image, publicUrl string) (string, error) {
+	query := saveQueries[image.OwnerType]
+	ctx, span := telemetry.StartQuery(ctx, "querySave"+image.OwnerType, query)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return "", err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var previousKey string
+	err = tx.QueryRowContext(ctx, query, image.OwnerID, image.ContentType, image.Width, image.Height,
+		image.Size, image.Key).Scan(&image.ID, &image.UpdatedAt, &previousKey)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23503" { // el propietario no existe
+			return "", fmt.Errorf("%s with id %d %w", image.OwnerType, image.OwnerID, ex.ErrNotFound)
+		}
+		log.Printf("Image save failed: %s", err)
+		return "", fmt.Errorf("image save failed: %w", err)
+	}
+
+	if image.OwnerType == entities.ImageOwnerSeason {
+		if _, err := tx.ExecContext(ctx, queryUpdateSeasonImageUrl, publicUrl, image.OwnerID); err != nil {
+			telemetry.RecordError(span, err)
+			return "", fmt.Errorf("failed to update season image_url: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		telemetry.RecordError(span, err)
+		return "", err
+	}
+
+	return previousKey, nil
+}
@@ -0,0 +1,162 @@
+package artwork
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/storage"
+	"go.opentelemetry.io/otel"
+	"log"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/artwork")
+
+// original es el nombre con el que se guarda y publica la imagen subida.
+const original = "original"
+
+type service struct {
+	repository   Repository
+	storage      storage.Storage
+	maxImageSize int
+}
+
+func NewService(repository Repository, storage storage.Storage, config *config.Config) Service {
+	return &service{
+		repository:   repository,
+		storage:      storage,
+		maxImageSize: config.Storage.MaxImageSize,
+	}
+}
+
+func (s *service) Get(ctx context.Context, ownerType string, ownerID uint64) (*entities.Image, error) {
+	ctx, span := tracer.Start(ctx, "artwork.Service.Get")
+	defer span.End()
+
+	image, err := s.repository.Get(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	image.Variants = s.urls(image)
+
+	return image, nil
+}
+
+func (s *service) Upload(ctx context.Context, ownerType string, ownerID uint64, content []byte) (*entities.Image, error) {
+	ctx, span := tracer.Start(ctx, "artwork.Service.Upload")
+	defer span.End()
+
+	if len(content) > s.maxImageSize {
+		return nil, &ex.ErrValidation{Field: "image", Reason: fmt.Sprintf("must not be larger than %d bytes", s.maxImageSize)}
+	}
+
+	contentType, err := sniff(content)
+	if err != nil {
+		return nil, err
+	}
+	src, err := decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &entities.Image{
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		ContentType: contentType,
+		Width:       src.Bounds().Dx(),
+		Height:      src.Bounds().Dy(),
+		Size:        len(content),
+		Key:         newKey(ownerType, ownerID),
+	}
+
+	objects := map[string][]byte{objectKey(image, original): content}
+	for _, v := range variants {
+		resized, err := resize(src, v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resize %s: %w", v.Name, err)
+		}
+		objects[objectKey(image, v.Name)] = resized
+	}
+
+	var stored []string
+	for key, body := range objects {
+		contentType := "image/jpeg"
+		if key == objectKey(image, original) {
+			contentType = image.ContentType
+		}
+		if err := s.storage.Put(ctx, key, body, contentType); err != nil {
+			s.delete(ctx, stored)
+			return nil, fmt.Errorf("failed to store image: %w", err)
+		}
+		stored = append(stored, key)
+	}
+
+	image.Variants = s.urls(image)
+	previousKey, err := s.repository.Save(ctx, image, image.Variants[original])
+	if err != nil {
+		s.delete(ctx, stored)
+		return nil, err
+	}
+
+	if previousKey != "" && previousKey != image.Key {
+		previous := *image
+		previous.Key = previousKey
+		s.delete(ctx, s.keys(&previous))
+	}
+
+	return image, nil
+}
+
+// delete borra los objetos sin devolver error: un objeto huérfano no debe
+// hacer fallar la subida.
+func (s *service) delete(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete %s: %s", key, err)
+		}
+	}
+}
+
+func (s *service) urls(image *entities.Image) map[string]string {
+	urls := map[string]string{original: s.storage.URL(objectKey(image, original))}
+	for _, v := range variants {
+		urls[v.Name] = s.storage.URL(objectKey(image, v.Name))
+	}
+
+	return urls
+}
+
+// keys devuelve las claves de todos los objetos de la imagen. El original
+// de la imagen reemplazada puede tener otra extensión, así que se prueban
+// todas.
+func (s *service) keys(image *entities.Image) []string {
+	var keys []string
+	for _, extension := range extensions {
+		keys = append(keys, image.Key+"/"+original+extension)
+	}
+	for _, v := range variants {
+		keys = append(keys, objectKey(image, v.Name))
+	}
+
+	return keys
+}
+
+func objectKey(image *entities.Image, name string) string {
+	if name == original {
+		return image.Key + "/" + original + extensions[image.ContentType]
+	}
+
+	return image.Key + "/" + name + ".jpg"
+}
+
+// newKey genera un prefijo nuevo en cada subida para que las URL publicadas
+// nunca cambien de contenido y puedan cachearse indefinidamente.
+func newKey(ownerType string, ownerID uint64) string {
+	random := make([]byte, 8)
+	_, _ = rand.Read(random)
+
+	return fmt.Sprintf("%ss/%d/%s", ownerType, ownerID, hex.EncodeToString(random))
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+const userKey = "auth.user"
+
+type Repository interface {
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.User, error)
+	Create(ctx context.Context, user *entities.User, tokenHash string) error
+}
+
+// CurrentUser devuelve el usuario autenticado por el middleware, si lo hay.
+func CurrentUser(ctx *fiber.Ctx) (*entities.User, bool) {
+	user, ok := ctx.Locals(userKey).(*entities.User)
+	return user, ok
+}
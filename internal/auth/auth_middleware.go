@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"strings"
+)
+
+// Middleware identifica al usuario a partir de la cabecera
+// "Authorization: Bearer <token>". Las peticiones sin cabecera continúan
+// como anónimas; un token inválido se rechaza con 401.
+func Middleware(repository Repository) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		header := ctx.Get(fiber.HeaderAuthorization)
+		if header == "" {
+			return ctx.Next()
+		}
+
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			return response.NewUnauthorizedResponse("Invalid authorization header")
+		}
+
+		user, err := repository.GetByTokenHash(ctx.UserContext(), HashToken(token))
+		if err != nil {
+			if errors.Is(err, ex.ErrNotFound) {
+				return response.NewUnauthorizedResponse("Invalid token")
+			}
+
+			return response.NewInternalServerErrorResponse("Failed to authenticate")
+		}
+
+		ctx.Locals(userKey, user)
+
+		return ctx.Next()
+	}
+}
+
+// RequireUser restringe la ruta a usuarios autenticados. Debe ir detrás
+// de Middleware.
+func RequireUser() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if _, ok := CurrentUser(ctx); !ok {
+			return response.NewUnauthorizedResponse("Authentication required")
+		}
+
+		return ctx.Next()
+	}
+}
+
+// RequireAdmin restringe la ruta a administradores. Debe ir detrás de
+// Middleware: sin usuario responde 401 y con un usuario sin rol de
+// administrador, 403.
+func RequireAdmin() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user, ok := CurrentUser(ctx)
+		if !ok {
+			return response.NewUnauthorizedResponse("Authentication required")
+		}
+		if !user.IsAdmin() {
+			return response.NewForbiddenResponse("Administrator role required")
+		}
+
+		return ctx.Next()
+	}
+}
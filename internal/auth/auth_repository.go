@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+)
+
+const (
+	queryGetByTokenHash = "SELECT id, username, role FROM users WHERE token_hash = $1"
+	queryCreate         = "INSERT INTO users (username, token_hash, role) VALUES ($1, $2, $3) RETURNING id"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.User, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByTokenHash", queryGetByTokenHash)
+	defer span.End()
+
+	user := &entities.User{}
+
+	err := r.db.QueryRowContext(ctx, queryGetByTokenHash, tokenHash).
+		Scan(&user.ID, &user.Username, &user.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user %w", ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *repository) Create(ctx context.Context, user *entities.User, tokenHash string) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", queryCreate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreate, user.Username, tokenHash, user.Role).Scan(&user.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return &ex.ErrAlreadyExists{
+				Field:      "username",
+				Constraint: pgErr.Constraint,
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package calendar publica las emisiones programadas como calendarios
+// iCalendar (RFC 5545) a los que suscribirse desde una aplicación de
+// calendario.
+package calendar
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+const mimeCalendar = "text/calendar"
+
+// Calendar es un calendario con su nombre y sus emisiones, en orden.
+type Calendar struct {
+	Name    string
+	Airings []*entities.Airing
+}
+
+type Repository interface {
+	// GetUserIDByTokenHash devuelve el usuario dueño del token de
+	// calendario.
+	GetUserIDByTokenHash(ctx context.Context, tokenHash string) (uint64, error)
+	// SaveToken crea o reemplaza el token del usuario.
+	SaveToken(ctx context.Context, userID uint64, tokenHash string) error
+	DeleteToken(ctx context.Context, userID uint64) error
+}
+
+type Service interface {
+	GetAll(ctx context.Context) (*Calendar, error)
+	GetBySeasonID(ctx context.Context, seasonID uint64) (*Calendar, error)
+	// GetByToken devuelve el calendario de la lista del dueño del token.
+	GetByToken(ctx context.Context, token string) (*Calendar, error)
+	// CreateToken genera un token nuevo para el usuario, que invalida el
+	// anterior.
+	CreateToken(ctx context.Context, userID uint64) (string, error)
+	DeleteToken(ctx context.Context, userID uint64) error
+}
+
+type Handler interface {
+	GetAll(ctx *fiber.Ctx) error
+	GetBySeason(ctx *fiber.Ctx) error
+	GetPersonal(ctx *fiber.Ctx) error
+	CreateToken(ctx *fiber.Ctx) error
+	DeleteToken(ctx *fiber.Ctx) error
+}
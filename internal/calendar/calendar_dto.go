@@ -0,0 +1,7 @@
+package calendar
+
+type tokenResponse struct {
+	Token string `json:"token"`
+	// Url es la dirección del calendario personal para suscribirse a él.
+	Url string `json:"url"`
+}
@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) Handler {
+	return &handler{service: service}
+}
+
+func (h *handler) GetAll(ctx *fiber.Ctx) error {
+	calendar, err := h.service.GetAll(ctx.UserContext())
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get calendar")
+	}
+
+	return send(ctx, calendar, "public, max-age=300")
+}
+
+func (h *handler) GetBySeason(ctx *fiber.Ctx) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	calendar, err := h.service.GetBySeasonID(ctx.UserContext(), seasonID)
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to get calendar")
+	}
+
+	return send(ctx, calendar, "public, max-age=300")
+}
+
+// GetPersonal sirve el calendario de la lista del usuario. Las aplicaciones
+// de calendario no envían la cabecera Authorization, así que el token va en
+// la ruta.
+func (h *handler) GetPersonal(ctx *fiber.Ctx) error {
+	calendar, err := h.service.GetByToken(ctx.UserContext(), ctx.Params("token"))
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to get calendar")
+	}
+
+	return send(ctx, calendar, "private, max-age=300")
+}
+
+func (h *handler) CreateToken(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	token, err := h.service.CreateToken(ctx.UserContext(), user.ID)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to create calendar token")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(&tokenResponse{
+		Token: token,
+		Url:   ctx.BaseURL() + "/api/v2/calendar/" + token + ".ics",
+	})
+}
+
+func (h *handler) DeleteToken(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	if err := h.service.DeleteToken(ctx.UserContext(), user.ID); err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to delete calendar token")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func send(ctx *fiber.Ctx, calendar *Calendar, cacheControl string) error {
+	ctx.Set(fiber.HeaderContentType, mimeCalendar+"; charset=utf-8")
+	ctx.Set(fiber.HeaderCacheControl, cacheControl)
+
+	return ctx.Status(http.StatusOK).SendString(encode(calendar))
+}
@@ -0,0 +1,86 @@
+package calendar
+
+import (
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	prodID = "-//wicho90//anime-api//EN"
+	// uidDomain completa el UID de cada evento. El UID sólo depende del
+	// episodio, así que al cambiar la emisión el cliente reemplaza el evento
+	// en lugar de duplicarlo.
+	uidDomain = "anime-api"
+	// refreshInterval sugiere a los clientes cada cuánto volver a descargar
+	// el calendario (RFC 7986 y su equivalente X-PUBLISHED-TTL).
+	refreshInterval = "PT1H"
+	utcLayout       = "20060102T150405Z"
+	// maxLineLength es la longitud máxima en octetos de una línea; las más
+	// largas se pliegan.
+	maxLineLength = 75
+)
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// encode genera el calendario en formato iCalendar. Las horas van en UTC
+// para no tener que incluir las definiciones VTIMEZONE de cada zona; la hora
+// local de la emisión se indica en la descripción.
+func encode(calendar *Calendar) string {
+	var b strings.Builder
+	writeLine(&b, "BEGIN", "VCALENDAR")
+	writeLine(&b, "VERSION", "2.0")
+	writeLine(&b, "PRODID", prodID)
+	writeLine(&b, "CALSCALE", "GREGORIAN")
+	writeLine(&b, "NAME", escapeText(calendar.Name))
+	writeLine(&b, "X-WR-CALNAME", escapeText(calendar.Name))
+	writeLine(&b, "REFRESH-INTERVAL;VALUE=DURATION", refreshInterval)
+	writeLine(&b, "X-PUBLISHED-TTL", refreshInterval)
+	for _, airing := range calendar.Airings {
+		writeEvent(&b, airing)
+	}
+	writeLine(&b, "END", "VCALENDAR")
+
+	return b.String()
+}
+
+func writeEvent(b *strings.Builder, airing *entities.Airing) {
+	writeLine(b, "BEGIN", "VEVENT")
+	writeLine(b, "UID", fmt.Sprintf("episode-%d@%s", airing.EpisodeID, uidDomain))
+	// Sin METHOD, DTSTAMP es la fecha de la última revisión del evento.
+	writeLine(b, "DTSTAMP", airing.UpdatedAt.UTC().Format(utcLayout))
+	writeLine(b, "LAST-MODIFIED", airing.UpdatedAt.UTC().Format(utcLayout))
+	writeLine(b, "SEQUENCE", fmt.Sprint(airing.Sequence))
+	writeLine(b, "DTSTART", airing.AirsAt.UTC().Format(utcLayout))
+	if airing.EndsAt.After(airing.AirsAt) {
+		writeLine(b, "DTEND", airing.EndsAt.UTC().Format(utcLayout))
+	}
+	writeLine(b, "SUMMARY", escapeText(fmt.Sprintf("%s - Episode %d: %s", airing.SeasonName, airing.EpisodeNumber, airing.EpisodeName)))
+	writeLine(b, "DESCRIPTION", escapeText(fmt.Sprintf("Airs on %s (%s).", airing.AirsAt.Format("Mon, 02 Jan 2006 15:04"), airing.TimeZone)))
+	writeLine(b, "END", "VEVENT")
+}
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// writeLine escribe la línea name:value terminada en CRLF y la pliega cada
+// maxLineLength octetos sin partir un carácter UTF-8. Las líneas de
+// continuación empiezan por un espacio, que cuenta en su longitud.
+func writeLine(b *strings.Builder, name, value string) {
+	line := name + ":" + value
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,164 @@
+package calendar
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func newAiring(sequence int, airsAt time.Time) *entities.Airing {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	return &entities.Airing{
+		EpisodeID:     13,
+		EpisodeName:   "the last day",
+		EpisodeNumber: 13,
+		SeasonName:    "shingeki no kyojin",
+		AirsAt:        airsAt.In(tokyo),
+		TimeZone:      "Asia/Tokyo",
+		EndsAt:        airsAt.Add(24 * time.Minute).In(tokyo),
+		Sequence:      sequence,
+		UpdatedAt:     time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestEncode(t *testing.T) {
+	airing := newAiring(0, time.Date(2026, time.October, 24, 14, 0, 0, 0, time.UTC))
+
+	got := encode(&Calendar{Name: "Anime API", Airings: []*entities.Airing{airing}})
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//wicho90//anime-api//EN",
+		"CALSCALE:GREGORIAN",
+		"NAME:Anime API",
+		"X-WR-CALNAME:Anime API",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+		"X-PUBLISHED-TTL:PT1H",
+		"BEGIN:VEVENT",
+		"UID:episode-13@anime-api",
+		"DTSTAMP:20261001T120000Z",
+		"LAST-MODIFIED:20261001T120000Z",
+		"SEQUENCE:0",
+		"DTSTART:20261024T140000Z",
+		"DTEND:20261024T142400Z",
+		"SUMMARY:shingeki no kyojin - Episode 13: the last day",
+		"DESCRIPTION:Airs on Sat\\, 24 Oct 2026 23:00 (Asia/Tokyo).",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEncodeRevisions(t *testing.T) {
+	first := newAiring(0, time.Date(2026, time.October, 24, 14, 0, 0, 0, time.UTC))
+	moved := newAiring(2, time.Date(2026, time.October, 31, 14, 0, 0, 0, time.UTC))
+	moved.UpdatedAt = time.Date(2026, time.October, 20, 9, 30, 0, 0, time.UTC)
+	unknownEnd := newAiring(1, time.Date(2026, time.October, 24, 14, 0, 0, 0, time.UTC))
+	unknownEnd.EndsAt = unknownEnd.AirsAt
+
+	tests := []struct {
+		name    string
+		airing  *entities.Airing
+		want    []string
+		missing []string
+	}{
+		{"first version", first, []string{"UID:episode-13@anime-api", "SEQUENCE:0", "DTSTART:20261024T140000Z"}, nil},
+		{"moved keeps the uid", moved, []string{
+			"UID:episode-13@anime-api", "SEQUENCE:2", "DTSTAMP:20261020T093000Z", "DTSTART:20261031T140000Z",
+		}, nil},
+		{"no duration", unknownEnd, []string{"SEQUENCE:1"}, []string{"DTEND"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(encode(&Calendar{Airings: []*entities.Airing{tt.airing}}), "\r\n")
+			for _, want := range tt.want {
+				if !contains(lines, want) {
+					t.Errorf("missing line %q", want)
+				}
+			}
+			for _, name := range tt.missing {
+				for _, line := range lines {
+					if strings.HasPrefix(line, name+":") {
+						t.Errorf("unexpected line %q", line)
+					}
+				}
+			}
+		})
+	}
+}
+
+func contains(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"plain", "plain"},
+		{"a, b; c", `a\, b\; c`},
+		{`back\slash`, `back\\slash`},
+		{"one\ntwo\r\nthree\rfour", `one\ntwo\nthree\nfour`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.value); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWriteLineFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		lines int
+	}{
+		{"short", "short", 1},
+		{"exactly 75 octets", strings.Repeat("a", 75-len("SUMMARY:")), 1},
+		{"76 octets", strings.Repeat("a", 76-len("SUMMARY:")), 2},
+		{"long ascii", strings.Repeat("abcdefghij", 20), 3},
+		{"multibyte", strings.Repeat("進撃の巨人", 12), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeLine(&b, "SUMMARY", tt.value)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line does not end in CRLF: %q", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("got %d lines, want %d", len(lines), tt.lines)
+			}
+			for i, line := range lines {
+				if len(line) > maxLineLength {
+					t.Errorf("line %d has %d octets", i, len(line))
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a character: %q", i, line)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+			}
+
+			unfolded := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", "")
+			if unfolded != "SUMMARY:"+tt.value {
+				t.Errorf("unfolded to %q", unfolded)
+			}
+		})
+	}
+}
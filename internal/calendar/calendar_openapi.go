@@ -0,0 +1,99 @@
+package calendar
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+// OpenAPI documenta los calendarios iCalendar y el token del calendario
+// personal.
+func OpenAPI(doc *openapi.Document) {
+	token := doc.Register("CalendarTokenV2", tokenResponse{})
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	example := encode(&Calendar{Name: "Anime API", Airings: []*entities.Airing{{
+		EpisodeID: 13, EpisodeName: "the last day", EpisodeNumber: 13, EpisodeSlug: "shingeki-no-kyojin-13",
+		SeasonID: 1, SeasonName: "shingeki no kyojin", SeasonSlug: "shingeki-no-kyojin",
+		AirsAt:   time.Date(2026, time.October, 24, 23, 0, 0, 0, tokyo),
+		EndsAt:   time.Date(2026, time.October, 24, 23, 24, 0, 0, tokyo),
+		TimeZone: "Asia/Tokyo", Sequence: 1,
+		UpdatedAt: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC),
+	}}})
+	calendar := func(description string) *openapi.Response {
+		return &openapi.Response{
+			Description: description,
+			Content:     map[string]*openapi.MediaType{mimeCalendar: {Schema: &openapi.Schema{Type: "string"}, Example: example}},
+		}
+	}
+	events := "Covers the airings of the last week and the next three months. Each episode is one event whose UID " +
+		"does not change, so calendar apps update the event when the airing moves; times are in UTC."
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+
+	doc.Add(http.MethodGet, "/api/v2/calendar.ics", &openapi.Operation{
+		OperationID: "getCalendarV2",
+		Summary:     "Get the calendar of every scheduled episode",
+		Description: events,
+		Tags:        []string{"calendar"},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  calendar("iCalendar feed"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get calendar"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/seasons/:id/calendar.ics", &openapi.Operation{
+		OperationID: "getSeasonCalendarV2",
+		Summary:     "Get the calendar of a season",
+		Description: events,
+		Tags:        []string{"calendar"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  calendar("iCalendar feed"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get calendar"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/calendar/:token.ics", &openapi.Operation{
+		OperationID: "getPersonalCalendarV2",
+		Summary:     "Get the calendar of a watchlist",
+		Description: "Includes the seasons of the watchlist that are being watched or planned to watch. " +
+			"The token in the path replaces the Authorization header, which calendar apps do not send. " + events,
+		Tags: []string{"calendar"},
+		Parameters: []*openapi.Parameter{
+			openapi.PathParam("token", "Calendar token", &openapi.Schema{Type: "string"}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  calendar("iCalendar feed"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "calendar not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get calendar"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/calendar/token", &openapi.Operation{
+		OperationID: "createCalendarTokenV2",
+		Summary:     "Create the token of the watchlist calendar",
+		Description: "Returns the token and the URL to subscribe to. The token is only shown once; creating a new one " +
+			"revokes the previous URL.",
+		Tags:     []string{"calendar"},
+		Security: openapi.Authenticated(),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated: openapi.JSON("Calendar token", token, tokenResponse{
+				Token: "3f7a9c", Url: "https://anime.example.com/api/v2/calendar/3f7a9c.ics",
+			}),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create calendar token"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/calendar/token", &openapi.Operation{
+		OperationID: "deleteCalendarTokenV2",
+		Summary:     "Revoke the token of the watchlist calendar",
+		Tags:        []string{"calendar"},
+		Security:    openapi.Authenticated(),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Revoked"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "calendar token not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete calendar token"),
+		}),
+	})
+}
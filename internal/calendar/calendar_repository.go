@@ -0,0 +1,73 @@
+package calendar
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+)
+
+const (
+	queryGetUserIDByTokenHash = "SELECT user_id FROM calendar_tokens WHERE token_hash = $1"
+	querySaveToken            = `INSERT INTO calendar_tokens (user_id, token_hash) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = now()`
+	queryDeleteToken = "DELETE FROM calendar_tokens WHERE user_id = $1"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetUserIDByTokenHash(ctx context.Context, tokenHash string) (uint64, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetUserIDByTokenHash", queryGetUserIDByTokenHash)
+	defer span.End()
+
+	var userID uint64
+	if err := r.db.QueryRowContext(ctx, queryGetUserIDByTokenHash, tokenHash).Scan(&userID); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("calendar %w", ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (r *repository) SaveToken(ctx context.Context, userID uint64, tokenHash string) error {
+	ctx, span := telemetry.StartQuery(ctx, "querySaveToken", querySaveToken)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, querySaveToken, userID, tokenHash); err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteToken(ctx context.Context, userID uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteToken", queryDeleteToken)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteToken, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("calendar token %w", ex.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package calendar
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/schedule"
+	"github.com/wicho90/anime-api/internal/season"
+	"go.opentelemetry.io/otel"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/calendar")
+
+// Los calendarios incluyen la última semana, para que un episodio no
+// desaparezca en cuanto se emite, y los próximos tres meses.
+const (
+	pastWindow   = 7 * 24 * time.Hour
+	futureWindow = 90 * 24 * time.Hour
+)
+
+// watchStatuses son los estados de la lista que entran en el calendario
+// personal.
+var watchStatuses = []string{entities.WatchWatching, entities.WatchPlanToWatch}
+
+type service struct {
+	repository         Repository
+	scheduleRepository schedule.Repository
+	seasonRepository   season.Repository
+}
+
+func NewService(repository Repository, scheduleRepository schedule.Repository, seasonRepository season.Repository) Service {
+	return &service{repository: repository, scheduleRepository: scheduleRepository, seasonRepository: seasonRepository}
+}
+
+func window() (time.Time, time.Time) {
+	now := time.Now()
+	return now.Add(-pastWindow), now.Add(futureWindow)
+}
+
+func (s *service) GetAll(ctx context.Context) (*Calendar, error) {
+	ctx, span := tracer.Start(ctx, "calendar.Service.GetAll")
+	defer span.End()
+
+	from, to := window()
+	airings, err := s.scheduleRepository.GetBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Calendar{Name: "Anime API", Airings: airings}, nil
+}
+
+func (s *service) GetBySeasonID(ctx context.Context, seasonID uint64) (*Calendar, error) {
+	ctx, span := tracer.Start(ctx, "calendar.Service.GetBySeasonID")
+	defer span.End()
+
+	found, err := s.seasonRepository.GetById(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := window()
+	airings, err := s.scheduleRepository.GetBetweenBySeasonID(ctx, seasonID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Calendar{Name: "Anime API: " + found.Name, Airings: airings}, nil
+}
+
+func (s *service) GetByToken(ctx context.Context, token string) (*Calendar, error) {
+	ctx, span := tracer.Start(ctx, "calendar.Service.GetByToken")
+	defer span.End()
+
+	userID, err := s.repository.GetUserIDByTokenHash(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := window()
+	airings, err := s.scheduleRepository.GetBetweenByUserID(ctx, userID, watchStatuses, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Calendar{Name: "Anime API: watchlist", Airings: airings}, nil
+}
+
+func (s *service) CreateToken(ctx context.Context, userID uint64) (string, error) {
+	ctx, span := tracer.Start(ctx, "calendar.Service.CreateToken")
+	defer span.End()
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repository.SaveToken(ctx, userID, auth.HashToken(token)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *service) DeleteToken(ctx context.Context, userID uint64) error {
+	ctx, span := tracer.Start(ctx, "calendar.Service.DeleteToken")
+	defer span.End()
+
+	return s.repository.DeleteToken(ctx, userID)
+}
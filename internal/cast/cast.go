@@ -0,0 +1,65 @@
+// Package cast gestiona los personajes, las personas del reparto y los
+// papeles de voz que las enlazan en cada temporada.
+package cast
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type Repository interface {
+	GetCharacterByID(ctx context.Context, id uint64) (*entities.Character, error)
+	CreateCharacter(ctx context.Context, character *entities.Character) error
+	UpdateCharacter(ctx context.Context, character *entities.Character) error
+	DeleteCharacter(ctx context.Context, id uint64) error
+	GetPersonByID(ctx context.Context, id uint64) (*entities.Person, error)
+	CreatePerson(ctx context.Context, person *entities.Person) error
+	UpdatePerson(ctx context.Context, person *entities.Person) error
+	DeletePerson(ctx context.Context, id uint64) error
+	// GetBySeasonID devuelve los papeles de la temporada ordenados por
+	// personaje e idioma. Con language sólo los de ese idioma.
+	GetBySeasonID(ctx context.Context, seasonID uint64, language string) ([]*entities.Role, error)
+	// GetByPersonID devuelve los papeles de la persona ordenados por
+	// temporada y personaje.
+	GetByPersonID(ctx context.Context, personID uint64) ([]*entities.Role, error)
+	CreateRole(ctx context.Context, role *entities.Role) error
+	DeleteRole(ctx context.Context, id uint64) error
+}
+
+type Service interface {
+	GetCharacterByID(ctx context.Context, id uint64) (*entities.Character, error)
+	CreateCharacter(ctx context.Context, character *entities.Character) error
+	UpdateCharacter(ctx context.Context, id uint64, character *entities.Character) error
+	DeleteCharacter(ctx context.Context, id uint64) error
+	GetPersonByID(ctx context.Context, id uint64) (*entities.Person, error)
+	CreatePerson(ctx context.Context, person *entities.Person) error
+	UpdatePerson(ctx context.Context, id uint64, person *entities.Person) error
+	DeletePerson(ctx context.Context, id uint64) error
+	// GetBySeasonID comprueba que la temporada existe antes de devolver sus
+	// papeles.
+	GetBySeasonID(ctx context.Context, seasonID uint64, language string) ([]*entities.Role, error)
+	// GetByPersonID devuelve la persona junto con sus papeles.
+	GetByPersonID(ctx context.Context, personID uint64) (*entities.Person, []*entities.Role, error)
+	// GetCreditsByPersonID devuelve la persona junto con sus créditos en
+	// los episodios.
+	GetCreditsByPersonID(ctx context.Context, personID uint64) (*entities.Person, []*entities.Credit, error)
+	CreateRole(ctx context.Context, role *entities.Role) error
+	DeleteRole(ctx context.Context, id uint64) error
+}
+
+type Handler interface {
+	GetCharacter(ctx *fiber.Ctx) error
+	CreateCharacter(ctx *fiber.Ctx) error
+	UpdateCharacter(ctx *fiber.Ctx) error
+	DeleteCharacter(ctx *fiber.Ctx) error
+	GetPerson(ctx *fiber.Ctx) error
+	CreatePerson(ctx *fiber.Ctx) error
+	UpdatePerson(ctx *fiber.Ctx) error
+	DeletePerson(ctx *fiber.Ctx) error
+	GetPersonRoles(ctx *fiber.Ctx) error
+	GetPersonCredits(ctx *fiber.Ctx) error
+	GetSeasonCharacters(ctx *fiber.Ctx) error
+	CreateRole(ctx *fiber.Ctx) error
+	DeleteRole(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,223 @@
+package cast
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type characterRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description" validate:"max=2000"`
+	ImageUrl    string `json:"image_url" validate:"omitempty,http_url,max=255"`
+}
+
+func (r *characterRequest) toEntity() *entities.Character {
+	return &entities.Character{Name: r.Name, Description: r.Description, ImageUrl: r.ImageUrl}
+}
+
+type personRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description" validate:"max=2000"`
+	ImageUrl    string `json:"image_url" validate:"omitempty,http_url,max=255"`
+}
+
+func (r *personRequest) toEntity() *entities.Person {
+	return &entities.Person{Name: r.Name, Description: r.Description, ImageUrl: r.ImageUrl}
+}
+
+type roleRequest struct {
+	SeasonID    uint64 `json:"season_id" validate:"required"`
+	CharacterID uint64 `json:"character_id" validate:"required"`
+	PersonID    uint64 `json:"person_id" validate:"required"`
+	// Language es el código del idioma del doblaje, como "ja" o "es-mx".
+	Language string `json:"language" validate:"required,min=2,max=10"`
+}
+
+func (r *roleRequest) toEntity() *entities.Role {
+	return &entities.Role{
+		SeasonID:  r.SeasonID,
+		Character: entities.Character{ID: r.CharacterID},
+		Person:    entities.Person{ID: r.PersonID},
+		Language:  r.Language,
+	}
+}
+
+type castImage struct {
+	Url string `json:"url"`
+}
+
+// newCastImage devuelve nil si no hay imagen.
+func newCastImage(url string) *castImage {
+	if url == "" {
+		return nil
+	}
+
+	return &castImage{Url: url}
+}
+
+type characterResponse struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Image       *castImage `json:"image"`
+}
+
+func newCharacterResponse(character *entities.Character) *characterResponse {
+	return &characterResponse{
+		ID:          character.ID,
+		Name:        character.Name,
+		Description: character.Description,
+		Image:       newCastImage(character.ImageUrl),
+	}
+}
+
+type personResponse struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Image       *castImage `json:"image"`
+}
+
+func newPersonResponse(person *entities.Person) *personResponse {
+	return &personResponse{
+		ID:          person.ID,
+		Name:        person.Name,
+		Description: person.Description,
+		Image:       newCastImage(person.ImageUrl),
+	}
+}
+
+// castMember resume un personaje o una persona dentro de un papel.
+type castMember struct {
+	ID    uint64     `json:"id"`
+	Name  string     `json:"name"`
+	Image *castImage `json:"image"`
+}
+
+type roleResponse struct {
+	ID          uint64 `json:"id"`
+	SeasonID    uint64 `json:"season_id"`
+	CharacterID uint64 `json:"character_id"`
+	PersonID    uint64 `json:"person_id"`
+	Language    string `json:"language"`
+}
+
+func newRoleResponse(role *entities.Role) *roleResponse {
+	return &roleResponse{
+		ID:          role.ID,
+		SeasonID:    role.SeasonID,
+		CharacterID: role.Character.ID,
+		PersonID:    role.Person.ID,
+		Language:    role.Language,
+	}
+}
+
+type voiceResponse struct {
+	RoleID   uint64      `json:"role_id"`
+	Language string      `json:"language"`
+	Person   *castMember `json:"person"`
+}
+
+type seasonCharacterResponse struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Image       *castImage `json:"image"`
+	// Voices lista quién da voz al personaje en cada idioma.
+	Voices []*voiceResponse `json:"voices"`
+}
+
+type seasonCharactersResponse struct {
+	SeasonID uint64                     `json:"season_id"`
+	Data     []*seasonCharacterResponse `json:"data"`
+}
+
+// newSeasonCharactersResponse agrupa los papeles por personaje. Los papeles
+// llegan ordenados por personaje, así que basta con mirar el anterior.
+func newSeasonCharactersResponse(seasonID uint64, roles []*entities.Role) *seasonCharactersResponse {
+	list := &seasonCharactersResponse{SeasonID: seasonID, Data: []*seasonCharacterResponse{}}
+	var current *seasonCharacterResponse
+	for _, role := range roles {
+		if current == nil || current.ID != role.Character.ID {
+			current = &seasonCharacterResponse{
+				ID:          role.Character.ID,
+				Name:        role.Character.Name,
+				Description: role.Character.Description,
+				Image:       newCastImage(role.Character.ImageUrl),
+				Voices:      []*voiceResponse{},
+			}
+			list.Data = append(list.Data, current)
+		}
+		current.Voices = append(current.Voices, &voiceResponse{
+			RoleID:   role.ID,
+			Language: role.Language,
+			Person:   &castMember{ID: role.Person.ID, Name: role.Person.Name, Image: newCastImage(role.Person.ImageUrl)},
+		})
+	}
+
+	return list
+}
+
+type roleSeason struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+type personRoleResponse struct {
+	RoleID    uint64      `json:"role_id"`
+	Language  string      `json:"language"`
+	Season    *roleSeason `json:"season"`
+	Character *castMember `json:"character"`
+}
+
+type personRolesResponse struct {
+	Person *personResponse       `json:"person"`
+	Data   []*personRoleResponse `json:"data"`
+}
+
+func newPersonRolesResponse(person *entities.Person, roles []*entities.Role) *personRolesResponse {
+	list := &personRolesResponse{Person: newPersonResponse(person), Data: make([]*personRoleResponse, 0, len(roles))}
+	for _, role := range roles {
+		list.Data = append(list.Data, &personRoleResponse{
+			RoleID:    role.ID,
+			Language:  role.Language,
+			Season:    &roleSeason{ID: role.SeasonID, Title: role.SeasonName, Slug: role.SeasonSlug},
+			Character: &castMember{ID: role.Character.ID, Name: role.Character.Name, Image: newCastImage(role.Character.ImageUrl)},
+		})
+	}
+
+	return list
+}
+
+type creditEpisode struct {
+	ID     uint64 `json:"id"`
+	Title  string `json:"title"`
+	Number uint8  `json:"number"`
+	Slug   string `json:"slug"`
+}
+
+type personCreditResponse struct {
+	CreditID uint64         `json:"credit_id"`
+	Role     string         `json:"role"`
+	Season   *roleSeason    `json:"season"`
+	Episode  *creditEpisode `json:"episode"`
+}
+
+type personCreditsResponse struct {
+	Person *personResponse         `json:"person"`
+	Data   []*personCreditResponse `json:"data"`
+}
+
+func newPersonCreditsResponse(person *entities.Person, credits []*entities.Credit) *personCreditsResponse {
+	list := &personCreditsResponse{Person: newPersonResponse(person), Data: make([]*personCreditResponse, 0, len(credits))}
+	for _, credit := range credits {
+		list.Data = append(list.Data, &personCreditResponse{
+			CreditID: credit.ID,
+			Role:     credit.Role,
+			Season:   &roleSeason{ID: credit.SeasonID, Title: credit.SeasonName, Slug: credit.SeasonSlug},
+			Episode:  &creditEpisode{ID: credit.EpisodeID, Title: credit.EpisodeName, Number: credit.EpisodeNumber, Slug: credit.EpisodeSlug},
+		})
+	}
+
+	return list
+}
@@ -0,0 +1,245 @@
+package cast
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetCharacter(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	character, err := h.service.GetCharacterByID(ctx.UserContext(), id)
+	if err != nil {
+		return writeError(err, "Failed to get character")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newCharacterResponse(character))
+}
+
+func (h *handler) CreateCharacter(ctx *fiber.Ctx) error {
+	var request characterRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	character := request.toEntity()
+	if err := h.service.CreateCharacter(ctx.UserContext(), character); err != nil {
+		return writeError(err, "Failed to create character")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newCharacterResponse(character))
+}
+
+func (h *handler) UpdateCharacter(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request characterRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	character := request.toEntity()
+	if err := h.service.UpdateCharacter(ctx.UserContext(), id, character); err != nil {
+		return writeError(err, "Failed to update character")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newCharacterResponse(character))
+}
+
+func (h *handler) DeleteCharacter(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.DeleteCharacter(ctx.UserContext(), id); err != nil {
+		return writeError(err, "Failed to delete character")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) GetPerson(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	person, err := h.service.GetPersonByID(ctx.UserContext(), id)
+	if err != nil {
+		return writeError(err, "Failed to get person")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newPersonResponse(person))
+}
+
+func (h *handler) CreatePerson(ctx *fiber.Ctx) error {
+	var request personRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	person := request.toEntity()
+	if err := h.service.CreatePerson(ctx.UserContext(), person); err != nil {
+		return writeError(err, "Failed to create person")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newPersonResponse(person))
+}
+
+func (h *handler) UpdatePerson(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request personRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	person := request.toEntity()
+	if err := h.service.UpdatePerson(ctx.UserContext(), id, person); err != nil {
+		return writeError(err, "Failed to update person")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newPersonResponse(person))
+}
+
+func (h *handler) DeletePerson(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.DeletePerson(ctx.UserContext(), id); err != nil {
+		return writeError(err, "Failed to delete person")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) GetPersonRoles(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	person, roles, err := h.service.GetByPersonID(ctx.UserContext(), id)
+	if err != nil {
+		return writeError(err, "Failed to get roles of person")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newPersonRolesResponse(person, roles))
+}
+
+func (h *handler) GetPersonCredits(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	person, credits, err := h.service.GetCreditsByPersonID(ctx.UserContext(), id)
+	if err != nil {
+		return writeError(err, "Failed to get credits of person")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newPersonCreditsResponse(person, credits))
+}
+
+func (h *handler) GetSeasonCharacters(ctx *fiber.Ctx) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	roles, err := h.service.GetBySeasonID(ctx.UserContext(), seasonID, ctx.Query("language"))
+	if err != nil {
+		return writeError(err, "Failed to get characters of season")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSeasonCharactersResponse(seasonID, roles))
+}
+
+func (h *handler) CreateRole(ctx *fiber.Ctx) error {
+	var request roleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	role := request.toEntity()
+	if err := h.service.CreateRole(ctx.UserContext(), role); err != nil {
+		return writeError(err, "Failed to create role")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newRoleResponse(role))
+}
+
+func (h *handler) DeleteRole(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.DeleteRole(ctx.UserContext(), id); err != nil {
+		return writeError(err, "Failed to delete role")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func writeError(err error, message string) error {
+	var alreadyExists *ex.ErrAlreadyExists
+	var validation *ex.ErrValidation
+
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &alreadyExists):
+		return response.NewConflictResponse("The person already voices this character in this language and season")
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
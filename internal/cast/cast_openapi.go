@@ -0,0 +1,214 @@
+package cast
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+var (
+	exampleCharacter = &characterResponse{
+		ID:          1,
+		Name:        "Eren Yeager",
+		Description: "A boy who swears to wipe out the Titans.",
+		Image:       &castImage{Url: "https://cdn.example.com/characters/eren.jpg"},
+	}
+	examplePerson = &personResponse{
+		ID:          1,
+		Name:        "Yuki Kaji",
+		Description: "Japanese voice actor.",
+		Image:       &castImage{Url: "https://cdn.example.com/people/yuki-kaji.jpg"},
+	}
+)
+
+// OpenAPI documenta los personajes, las personas del reparto y sus papeles
+// de voz en cada temporada.
+func OpenAPI(doc *openapi.Document) {
+	character := doc.Register("CharacterV2", characterResponse{})
+	characterInput := doc.Register("CharacterRequestV2", characterRequest{})
+	person := doc.Register("PersonV2", personResponse{})
+	personInput := doc.Register("PersonRequestV2", personRequest{})
+	role := doc.Register("VoiceRoleV2", roleResponse{})
+	roleInput := doc.Register("VoiceRoleRequestV2", roleRequest{})
+	seasonCharacters := doc.Register("SeasonCharactersV2", seasonCharactersResponse{})
+	personRoles := doc.Register("PersonRolesV2", personRolesResponse{})
+	personCredits := doc.Register("PersonCreditsV2", personCreditsResponse{})
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	for _, kind := range []struct {
+		name, plural, id string
+		schema, input    *openapi.Schema
+		example, request any
+	}{
+		{"character", "characters", "Character", character, characterInput, exampleCharacter, characterRequest{
+			Name: exampleCharacter.Name, Description: exampleCharacter.Description, ImageUrl: exampleCharacter.Image.Url,
+		}},
+		{"person", "people", "Person", person, personInput, examplePerson, personRequest{
+			Name: examplePerson.Name, Description: examplePerson.Description, ImageUrl: examplePerson.Image.Url,
+		}},
+	} {
+		notFound := openapi.Error(http.StatusNotFound, kind.name+" with id 1 not found")
+
+		doc.Add(http.MethodGet, "/api/v2/"+kind.plural+"/:id", &openapi.Operation{
+			OperationID: "get" + kind.id + "V2",
+			Summary:     "Get a " + kind.name,
+			Tags:        []string{"cast"},
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON(kind.id, kind.schema, kind.example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusNotFound:            notFound,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get "+kind.name),
+			}),
+		})
+		doc.Add(http.MethodPost, "/api/v2/admin/"+kind.plural, &openapi.Operation{
+			OperationID: "create" + kind.id + "V2",
+			Summary:     "Create a " + kind.name,
+			Description: "The image is optional. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			RequestBody: openapi.JSONBody(kind.input, kind.request),
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusCreated:             openapi.JSON("Created "+kind.name, kind.schema, kind.example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Name is required"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create "+kind.name),
+			}),
+		})
+		doc.Add(http.MethodPut, "/api/v2/admin/"+kind.plural+"/:id", &openapi.Operation{
+			OperationID: "update" + kind.id + "V2",
+			Summary:     "Update a " + kind.name,
+			Description: "Replaces every field. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			RequestBody: openapi.JSONBody(kind.input, kind.request),
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON("Updated "+kind.name, kind.schema, kind.example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusNotFound:            notFound,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update "+kind.name),
+			}),
+		})
+		doc.Add(http.MethodDelete, "/api/v2/admin/"+kind.plural+"/:id", &openapi.Operation{
+			OperationID: "delete" + kind.id + "V2",
+			Summary:     "Delete a " + kind.name,
+			Description: "Its voice roles are deleted too. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusNoContent:           openapi.NoContent("Deleted"),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusNotFound:            notFound,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete "+kind.name),
+			}),
+		})
+	}
+
+	doc.Add(http.MethodGet, "/api/v2/seasons/:id/characters", &openapi.Operation{
+		OperationID: "listSeasonCharactersV2",
+		Summary:     "List the characters of a season",
+		Description: "Each character comes with the people who voice it in each language. " +
+			"Only characters with at least one voice role in the season are listed.",
+		Tags: []string{"cast"},
+		Parameters: []*openapi.Parameter{
+			openapi.IDParam(),
+			openapi.QueryParam("language", "Only voice roles in this language, such as ja or es-mx", &openapi.Schema{Type: "string"}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Characters of the season", seasonCharacters, seasonCharactersResponse{
+				SeasonID: 1,
+				Data: []*seasonCharacterResponse{{
+					ID: exampleCharacter.ID, Name: exampleCharacter.Name, Description: exampleCharacter.Description, Image: exampleCharacter.Image,
+					Voices: []*voiceResponse{{
+						RoleID: 1, Language: "ja", Person: &castMember{ID: examplePerson.ID, Name: examplePerson.Name, Image: examplePerson.Image},
+					}},
+				}},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get characters of season"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/people/:id/roles", &openapi.Operation{
+		OperationID: "listPersonRolesV2",
+		Summary:     "List the voice roles of a person",
+		Description: "Sorted by season and character.",
+		Tags:        []string{"cast"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Roles of the person", personRoles, personRolesResponse{
+				Person: examplePerson,
+				Data: []*personRoleResponse{{
+					RoleID: 1, Language: "ja",
+					Season:    &roleSeason{ID: 1, Title: "shingeki no kyojin", Slug: "shingeki-no-kyojin"},
+					Character: &castMember{ID: exampleCharacter.ID, Name: exampleCharacter.Name, Image: exampleCharacter.Image},
+				}},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "person with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get roles of person"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/people/:id/credits", &openapi.Operation{
+		OperationID: "listPersonCreditsV2",
+		Summary:     "List the staff credits of a person",
+		Description: "Sorted by season and episode number.",
+		Tags:        []string{"cast"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Credits of the person", personCredits, personCreditsResponse{
+				Person: &personResponse{ID: 2, Name: "Tetsurō Araki", Description: "Japanese director."},
+				Data: []*personCreditResponse{{
+					CreditID: 1, Role: entities.CreditDirector,
+					Season:  &roleSeason{ID: 1, Title: "shingeki no kyojin", Slug: "shingeki-no-kyojin"},
+					Episode: &creditEpisode{ID: 1, Title: "to you, in 2000 years", Number: 1, Slug: "shingeki-no-kyojin-1"},
+				}},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "person with id 2 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get credits of person"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/admin/roles", &openapi.Operation{
+		OperationID: "createVoiceRoleV2",
+		Summary:     "Add a voice role",
+		Description: "The person voices the character in the season and language. The language is stored in lowercase. " +
+			"Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		RequestBody: openapi.JSONBody(roleInput, roleRequest{SeasonID: 1, CharacterID: 1, PersonID: 1, Language: "ja"}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created role", role, roleResponse{ID: 1, SeasonID: 1, CharacterID: 1, PersonID: 1, Language: "ja"}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'character_id' does not exist"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusConflict:            openapi.Error(http.StatusConflict, "The person already voices this character in this language and season"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create role"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/admin/roles/:id", &openapi.Operation{
+		OperationID: "deleteVoiceRoleV2",
+		Summary:     "Delete a voice role",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "role with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete role"),
+		}),
+	})
+}
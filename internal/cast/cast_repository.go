@@ -0,0 +1,296 @@
+package cast
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"strings"
+)
+
+const (
+	queryGetCharacterById = "SELECT id, name, description, image_url FROM characters WHERE id = $1"
+	queryCreateCharacter  = "INSERT INTO characters (name, description, image_url) VALUES ($1, $2, $3) RETURNING id"
+	queryUpdateCharacter  = "UPDATE characters SET name = $1, description = $2, image_url = $3 WHERE id = $4"
+	queryDeleteCharacter  = "DELETE FROM characters WHERE id = $1"
+
+	queryGetPersonById = "SELECT id, name, description, image_url FROM people WHERE id = $1"
+	queryCreatePerson  = "INSERT INTO people (name, description, image_url) VALUES ($1, $2, $3) RETURNING id"
+	queryUpdatePerson  = "UPDATE people SET name = $1, description = $2, image_url = $3 WHERE id = $4"
+	queryDeletePerson  = "DELETE FROM people WHERE id = $1"
+
+	querySelectRoles = `SELECT r.id, r.season_id, s.name, s.slug,
+			c.id, c.name, c.description, c.image_url,
+			p.id, p.name, p.description, p.image_url, r.language
+		FROM voice_roles r
+		JOIN seasons s ON s.id = r.season_id
+		JOIN characters c ON c.id = r.character_id
+		JOIN people p ON p.id = r.person_id`
+	queryGetBySeasonId = querySelectRoles + `
+		WHERE r.season_id = $1 AND ($2 = '' OR r.language = $2)
+		ORDER BY c.name, c.id, r.language, p.name`
+	queryGetByPersonId = querySelectRoles + `
+		WHERE r.person_id = $1
+		ORDER BY s.name, s.id, c.name, r.language`
+	queryCreateRole = `INSERT INTO voice_roles (season_id, character_id, person_id, language)
+		VALUES ($1, $2, $3, $4) RETURNING id`
+	queryDeleteRole = "DELETE FROM voice_roles WHERE id = $1"
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetCharacterByID(ctx context.Context, id uint64) (*entities.Character, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetCharacterById", queryGetCharacterById)
+	defer span.End()
+
+	character := &entities.Character{}
+	err := r.db.QueryRowContext(ctx, queryGetCharacterById, id).
+		Scan(&character.ID, &character.Name, &character.Description, &character.ImageUrl)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("character with id %d %w", id, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return character, nil
+}
+
+func (r *repository) CreateCharacter(ctx context.Context, character *entities.Character) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreateCharacter", queryCreateCharacter)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreateCharacter, character.Name, character.Description, character.ImageUrl).
+		Scan(&character.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "character")
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateCharacter(ctx context.Context, character *entities.Character) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdateCharacter", queryUpdateCharacter)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryUpdateCharacter, character.Name, character.Description, character.ImageUrl, character.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "character")
+	}
+
+	return checkAffected(result, "character", character.ID)
+}
+
+func (r *repository) DeleteCharacter(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteCharacter", queryDeleteCharacter)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteCharacter, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return checkAffected(result, "character", id)
+}
+
+func (r *repository) GetPersonByID(ctx context.Context, id uint64) (*entities.Person, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetPersonById", queryGetPersonById)
+	defer span.End()
+
+	person := &entities.Person{}
+	err := r.db.QueryRowContext(ctx, queryGetPersonById, id).
+		Scan(&person.ID, &person.Name, &person.Description, &person.ImageUrl)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("person with id %d %w", id, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return person, nil
+}
+
+func (r *repository) CreatePerson(ctx context.Context, person *entities.Person) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreatePerson", queryCreatePerson)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreatePerson, person.Name, person.Description, person.ImageUrl).
+		Scan(&person.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "person")
+	}
+
+	return nil
+}
+
+func (r *repository) UpdatePerson(ctx context.Context, person *entities.Person) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdatePerson", queryUpdatePerson)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryUpdatePerson, person.Name, person.Description, person.ImageUrl, person.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "person")
+	}
+
+	return checkAffected(result, "person", person.ID)
+}
+
+func (r *repository) DeletePerson(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeletePerson", queryDeletePerson)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeletePerson, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return checkAffected(result, "person", id)
+}
+
+func (r *repository) GetBySeasonID(ctx context.Context, seasonID uint64, language string) ([]*entities.Role, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBySeasonId", queryGetBySeasonId)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetBySeasonId, seasonID, language)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	roles, err := scanRoles(rows)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *repository) GetByPersonID(ctx context.Context, personID uint64) ([]*entities.Role, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByPersonId", queryGetByPersonId)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetByPersonId, personID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	roles, err := scanRoles(rows)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *repository) CreateRole(ctx context.Context, role *entities.Role) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreateRole", queryCreateRole)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreateRole, role.SeasonID, role.Character.ID, role.Person.ID, role.Language).
+		Scan(&role.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "role")
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteRole(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteRole", queryDeleteRole)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteRole, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return checkAffected(result, "role", id)
+}
+
+func scanRoles(rows *sql.Rows) ([]*entities.Role, error) {
+	roles := []*entities.Role{}
+	for rows.Next() {
+		role := &entities.Role{}
+		if err := rows.Scan(&role.ID, &role.SeasonID, &role.SeasonName, &role.SeasonSlug,
+			&role.Character.ID, &role.Character.Name, &role.Character.Description, &role.Character.ImageUrl,
+			&role.Person.ID, &role.Person.Name, &role.Person.Description, &role.Person.ImageUrl,
+			&role.Language); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return roles, nil
+}
+
+func closeRows(rows *sql.Rows) {
+	if err := rows.Close(); err != nil {
+		log.Printf("failed to close rows: %s", err)
+	}
+}
+
+func checkAffected(result sql.Result, kind string, id uint64) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s with id %d %w", kind, id, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func mapError(err error, kind string) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23505": // el papel ya existe
+			return &ex.ErrAlreadyExists{
+				Field:      kind,
+				Constraint: pgErr.Constraint,
+			}
+		case "23503": // la temporada, el personaje o la persona no existen
+			field := strings.TrimSuffix(strings.TrimPrefix(pgErr.Constraint, "voice_roles_"), "_fkey")
+			return &ex.ErrValidation{
+				Field:  field,
+				Reason: "does not exist",
+			}
+		default:
+			log.Printf("%s write failed: %s", kind, err)
+			return fmt.Errorf("%s write failed: %w", kind, err)
+		}
+	}
+
+	return err
+}
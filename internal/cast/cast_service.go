@@ -0,0 +1,153 @@
+package cast
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/credit"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/season"
+	"go.opentelemetry.io/otel"
+	"strings"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/cast")
+
+type service struct {
+	repository       Repository
+	seasonRepository season.Repository
+	creditRepository credit.Repository
+}
+
+func NewService(repository Repository, seasonRepository season.Repository, creditRepository credit.Repository) Service {
+	return &service{repository: repository, seasonRepository: seasonRepository, creditRepository: creditRepository}
+}
+
+func (s *service) GetCharacterByID(ctx context.Context, id uint64) (*entities.Character, error) {
+	ctx, span := tracer.Start(ctx, "cast.Service.GetCharacterByID")
+	defer span.End()
+
+	return s.repository.GetCharacterByID(ctx, id)
+}
+
+func (s *service) CreateCharacter(ctx context.Context, character *entities.Character) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.CreateCharacter")
+	defer span.End()
+
+	character.Name = strings.TrimSpace(character.Name)
+	character.Description = strings.TrimSpace(character.Description)
+
+	return s.repository.CreateCharacter(ctx, character)
+}
+
+func (s *service) UpdateCharacter(ctx context.Context, id uint64, character *entities.Character) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.UpdateCharacter")
+	defer span.End()
+
+	character.ID = id
+	character.Name = strings.TrimSpace(character.Name)
+	character.Description = strings.TrimSpace(character.Description)
+
+	return s.repository.UpdateCharacter(ctx, character)
+}
+
+func (s *service) DeleteCharacter(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.DeleteCharacter")
+	defer span.End()
+
+	return s.repository.DeleteCharacter(ctx, id)
+}
+
+func (s *service) GetPersonByID(ctx context.Context, id uint64) (*entities.Person, error) {
+	ctx, span := tracer.Start(ctx, "cast.Service.GetPersonByID")
+	defer span.End()
+
+	return s.repository.GetPersonByID(ctx, id)
+}
+
+func (s *service) CreatePerson(ctx context.Context, person *entities.Person) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.CreatePerson")
+	defer span.End()
+
+	person.Name = strings.TrimSpace(person.Name)
+	person.Description = strings.TrimSpace(person.Description)
+
+	return s.repository.CreatePerson(ctx, person)
+}
+
+func (s *service) UpdatePerson(ctx context.Context, id uint64, person *entities.Person) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.UpdatePerson")
+	defer span.End()
+
+	person.ID = id
+	person.Name = strings.TrimSpace(person.Name)
+	person.Description = strings.TrimSpace(person.Description)
+
+	return s.repository.UpdatePerson(ctx, person)
+}
+
+func (s *service) DeletePerson(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.DeletePerson")
+	defer span.End()
+
+	return s.repository.DeletePerson(ctx, id)
+}
+
+func (s *service) GetBySeasonID(ctx context.Context, seasonID uint64, language string) ([]*entities.Role, error) {
+	ctx, span := tracer.Start(ctx, "cast.Service.GetBySeasonID")
+	defer span.End()
+
+	if _, err := s.seasonRepository.GetById(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetBySeasonID(ctx, seasonID, strings.ToLower(strings.TrimSpace(language)))
+}
+
+func (s *service) GetByPersonID(ctx context.Context, personID uint64) (*entities.Person, []*entities.Role, error) {
+	ctx, span := tracer.Start(ctx, "cast.Service.GetByPersonID")
+	defer span.End()
+
+	person, err := s.repository.GetPersonByID(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles, err := s.repository.GetByPersonID(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return person, roles, nil
+}
+
+func (s *service) GetCreditsByPersonID(ctx context.Context, personID uint64) (*entities.Person, []*entities.Credit, error) {
+	ctx, span := tracer.Start(ctx, "cast.Service.GetCreditsByPersonID")
+	defer span.End()
+
+	person, err := s.repository.GetPersonByID(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credits, err := s.creditRepository.GetByPersonID(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return person, credits, nil
+}
+
+func (s *service) CreateRole(ctx context.Context, role *entities.Role) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.CreateRole")
+	defer span.End()
+
+	role.Language = strings.ToLower(strings.TrimSpace(role.Language))
+
+	return s.repository.CreateRole(ctx, role)
+}
+
+func (s *service) DeleteRole(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "cast.Service.DeleteRole")
+	defer span.End()
+
+	return s.repository.DeleteRole(ctx, id)
+}
@@ -0,0 +1,74 @@
+// Package comment gestiona los comentarios de los episodios: hilos de
+// respuestas, denuncias y moderación.
+package comment
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+const (
+	SortNewest = "newest"
+	SortOldest = "oldest"
+	// SortTop ordena por número de respuestas.
+	SortTop = "top"
+)
+
+// Page selecciona una página de comentarios.
+type Page struct {
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+type Repository interface {
+	// GetByEpisodeID devuelve los comentarios raíz del episodio y el total.
+	// Los comentarios que no están visibles sólo se incluyen si tienen
+	// respuestas, para no romper el hilo.
+	GetByEpisodeID(ctx context.Context, episodeID uint64, page Page) ([]*entities.Comment, int, error)
+	// GetReplies devuelve las respuestas directas de un comentario con el
+	// mismo criterio que GetByEpisodeID.
+	GetReplies(ctx context.Context, parentID uint64, page Page) ([]*entities.Comment, int, error)
+	GetByID(ctx context.Context, id uint64) (*entities.Comment, error)
+	// Create inserta el comentario y, si es una respuesta, actualiza el
+	// contador de respuestas del padre.
+	Create(ctx context.Context, comment *entities.Comment) error
+	// Update guarda el texto, el aviso de spoiler y el estado editados.
+	Update(ctx context.Context, comment *entities.Comment) error
+	// Delete vacía el comentario y lo marca como borrado por su autor.
+	Delete(ctx context.Context, id uint64) error
+	// Report registra la denuncia; un usuario sólo cuenta una vez por
+	// comentario y las denuncias repetidas se ignoran. Al llegar a
+	// threshold denuncias el comentario visible pasa a pendiente.
+	Report(ctx context.Context, report *entities.CommentReport, threshold int) error
+	// GetQueue devuelve la cola de moderación: sin status, los comentarios
+	// pendientes o denunciados; con status, los que tienen ese estado.
+	GetQueue(ctx context.Context, status string, page Page) ([]*entities.Comment, int, error)
+	// Moderate cambia el estado del comentario; dismissReports borra además
+	// sus denuncias.
+	Moderate(ctx context.Context, id uint64, status string, moderatorID uint64, dismissReports bool) (*entities.Comment, error)
+}
+
+type Service interface {
+	GetByEpisodeID(ctx context.Context, episodeID uint64, page Page) ([]*entities.Comment, int, error)
+	GetReplies(ctx context.Context, parentID uint64, page Page) ([]*entities.Comment, int, error)
+	Create(ctx context.Context, author *entities.User, comment *entities.Comment) error
+	Update(ctx context.Context, author *entities.User, id uint64, body string, spoiler bool) (*entities.Comment, error)
+	Delete(ctx context.Context, author *entities.User, id uint64) error
+	Report(ctx context.Context, user *entities.User, report *entities.CommentReport) error
+	GetQueue(ctx context.Context, status string, page Page) ([]*entities.Comment, int, error)
+	// Moderate aplica una acción de moderación: "approve", "hide" o "remove".
+	Moderate(ctx context.Context, moderator *entities.User, id uint64, action string) (*entities.Comment, error)
+}
+
+type Handler interface {
+	GetByEpisodeID(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	GetReplies(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	Report(ctx *fiber.Ctx) error
+	GetQueue(ctx *fiber.Ctx) error
+	Moderate(ctx *fiber.Ctx) error
+}
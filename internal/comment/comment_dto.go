@@ -0,0 +1,134 @@
+package comment
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+type commentRequest struct {
+	Body    string `json:"body" validate:"required"`
+	Spoiler bool   `json:"spoiler"`
+	// ParentID es el comentario al que se responde; 0 u omitido crea un
+	// comentario raíz.
+	ParentID uint64 `json:"parent_id"`
+}
+
+type updateRequest struct {
+	Body    string `json:"body" validate:"required"`
+	Spoiler bool   `json:"spoiler"`
+}
+
+type reportRequest struct {
+	Reason string `json:"reason" validate:"max=200"`
+}
+
+type moderationRequest struct {
+	// Action puede ser "approve", "hide" o "remove".
+	Action string `json:"action" validate:"required"`
+}
+
+type commentAuthor struct {
+	ID       uint64 `json:"id"`
+	Username string `json:"username"`
+}
+
+type commentResponse struct {
+	ID         uint64        `json:"id"`
+	EpisodeID  uint64        `json:"episode_id"`
+	ParentID   uint64        `json:"parent_id,omitempty"`
+	Author     commentAuthor `json:"author"`
+	Body       string        `json:"body"`
+	Spoiler    bool          `json:"spoiler"`
+	Status     string        `json:"status"`
+	ReplyCount int           `json:"reply_count"`
+	CreatedAt  time.Time     `json:"created_at"`
+	EditedAt   *time.Time    `json:"edited_at,omitempty"`
+}
+
+// newCommentResponse oculta el texto de los comentarios no visibles cuando
+// se listan públicamente: sólo aparecen para conservar sus respuestas.
+func newCommentResponse(comment *entities.Comment, public bool) *commentResponse {
+	response := &commentResponse{
+		ID:         comment.ID,
+		EpisodeID:  comment.EpisodeID,
+		ParentID:   comment.ParentID,
+		Author:     commentAuthor{ID: comment.UserID, Username: comment.Username},
+		Body:       comment.Body,
+		Spoiler:    comment.Spoiler,
+		Status:     comment.Status,
+		ReplyCount: comment.ReplyCount,
+		CreatedAt:  comment.CreatedAt,
+		EditedAt:   comment.EditedAt,
+	}
+	if public && comment.Status != entities.CommentVisible {
+		response.Body = ""
+	}
+
+	return response
+}
+
+type pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+type commentListResponse struct {
+	Data       []*commentResponse `json:"data"`
+	Pagination pagination         `json:"pagination"`
+}
+
+func newCommentListResponse(comments []*entities.Comment, page pagination) *commentListResponse {
+	list := &commentListResponse{Data: make([]*commentResponse, 0, len(comments)), Pagination: page}
+	for _, comment := range comments {
+		list.Data = append(list.Data, newCommentResponse(comment, true))
+	}
+
+	return list
+}
+
+// moderationResponse es un comentario de la cola de moderación, con sus
+// denuncias y las recibidas por su autor en total.
+type moderationResponse struct {
+	ID            uint64        `json:"id"`
+	EpisodeID     uint64        `json:"episode_id"`
+	ParentID      uint64        `json:"parent_id,omitempty"`
+	Author        commentAuthor `json:"author"`
+	Body          string        `json:"body"`
+	Spoiler       bool          `json:"spoiler"`
+	Status        string        `json:"status"`
+	ReportCount   int           `json:"report_count"`
+	AuthorReports int           `json:"author_reports"`
+	CreatedAt     time.Time     `json:"created_at"`
+	EditedAt      *time.Time    `json:"edited_at,omitempty"`
+}
+
+func newModerationResponse(comment *entities.Comment) *moderationResponse {
+	return &moderationResponse{
+		ID:            comment.ID,
+		EpisodeID:     comment.EpisodeID,
+		ParentID:      comment.ParentID,
+		Author:        commentAuthor{ID: comment.UserID, Username: comment.Username},
+		Body:          comment.Body,
+		Spoiler:       comment.Spoiler,
+		Status:        comment.Status,
+		ReportCount:   comment.ReportCount,
+		AuthorReports: comment.AuthorReports,
+		CreatedAt:     comment.CreatedAt,
+		EditedAt:      comment.EditedAt,
+	}
+}
+
+type moderationListResponse struct {
+	Data       []*moderationResponse `json:"data"`
+	Pagination pagination            `json:"pagination"`
+}
+
+func newModerationListResponse(comments []*entities.Comment, page pagination) *moderationListResponse {
+	list := &moderationListResponse{Data: make([]*moderationResponse, 0, len(comments)), Pagination: page}
+	for _, comment := range comments {
+		list.Data = append(list.Data, newModerationResponse(comment))
+	}
+
+	return list
+}
@@ -0,0 +1,104 @@
+package comment
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed words/*.txt
+var wordLists embed.FS
+
+// Filter detecta palabras no permitidas en un texto. Es una interfaz para
+// poder sustituir las listas incluidas por otro mecanismo, como un servicio
+// externo de moderación.
+type Filter interface {
+	// Find devuelve los intervalos [inicio, fin), en bytes, de las palabras
+	// no permitidas de text.
+	Find(text string) [][2]int
+}
+
+// folder pasa a minúsculas sin tildes; la ñ se conserva porque distingue
+// palabras.
+var folder = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u",
+)
+
+type wordFilter struct {
+	words map[string]bool
+}
+
+// NewFilter construye el filtro de palabras con las listas de
+// comments.filter_languages.
+func NewFilter(config *config.Config) (Filter, error) {
+	return NewWordFilter(config.Comments.FilterLanguages...)
+}
+
+// NewWordFilter carga las listas incluidas de los idiomas dados ("es",
+// "en"). Sin idiomas, el filtro no encuentra nada.
+func NewWordFilter(languages ...string) (Filter, error) {
+	words := map[string]bool{}
+	for _, language := range languages {
+		content, err := wordLists.ReadFile("words/" + language + ".txt")
+		if err != nil {
+			return nil, fmt.Errorf("no word list for language %q", language)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			word := strings.TrimSpace(scanner.Text())
+			if word != "" && !strings.HasPrefix(word, "#") {
+				words[fold(word)] = true
+			}
+		}
+	}
+
+	return &wordFilter{words: words}, nil
+}
+
+func (f *wordFilter) Find(text string) [][2]int {
+	var matches [][2]int
+	if len(f.words) == 0 {
+		return matches
+	}
+
+	start := -1
+	for i, r := range text + " " {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && f.words[fold(text[start:i])] {
+			matches = append(matches, [2]int{start, i})
+		}
+		start = -1
+	}
+
+	return matches
+}
+
+func fold(word string) string {
+	return folder.Replace(strings.ToLower(word))
+}
+
+// mask sustituye cada letra de las palabras encontradas por un asterisco.
+func mask(text string, matches [][2]int) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[m[0]:m[1]])))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
@@ -0,0 +1,283 @@
+package comment
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// queueStatuses son los valores aceptados por el parámetro status de la
+// cola de moderación; vacío es la cola de pendientes y denunciados.
+var queueStatuses = map[string]bool{
+	"":                      true,
+	entities.CommentPending: true,
+	entities.CommentHidden:  true,
+	entities.CommentRemoved: true,
+}
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetByEpisodeID(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	page, meta, err := parsePage(ctx, SortNewest)
+	if err != nil {
+		return err
+	}
+
+	comments, total, err := h.service.GetByEpisodeID(ctx.UserContext(), episodeID, page)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get comments")
+	}
+	meta.Total = total
+
+	return ctx.Status(http.StatusOK).JSON(newCommentListResponse(comments, meta))
+}
+
+func (h *handler) Create(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request commentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	comment := &entities.Comment{
+		EpisodeID: episodeID,
+		ParentID:  request.ParentID,
+		Body:      request.Body,
+		Spoiler:   request.Spoiler,
+	}
+	if err := h.service.Create(ctx.UserContext(), user, comment); err != nil {
+		return writeError(err, "Failed to create comment")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newCommentResponse(comment, false))
+}
+
+func (h *handler) GetReplies(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	page, meta, err := parsePage(ctx, SortOldest)
+	if err != nil {
+		return err
+	}
+
+	comments, total, err := h.service.GetReplies(ctx.UserContext(), id, page)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get replies")
+	}
+	meta.Total = total
+
+	return ctx.Status(http.StatusOK).JSON(newCommentListResponse(comments, meta))
+}
+
+func (h *handler) Update(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request updateRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	comment, err := h.service.Update(ctx.UserContext(), user, id, request.Body, request.Spoiler)
+	if err != nil {
+		return writeError(err, "Failed to update comment")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newCommentResponse(comment, false))
+}
+
+func (h *handler) Delete(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), user, id); err != nil {
+		return writeError(err, "Failed to delete comment")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) Report(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	// El motivo es opcional, así que se acepta un cuerpo vacío.
+	var request reportRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return response.NewBadRequestResponse("Invalid request body")
+		}
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	report := &entities.CommentReport{CommentID: id, Reason: request.Reason}
+	if err := h.service.Report(ctx.UserContext(), user, report); err != nil {
+		return writeError(err, "Failed to report comment")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) GetQueue(ctx *fiber.Ctx) error {
+	status := ctx.Query("status")
+	if !queueStatuses[status] {
+		return response.NewBadRequestResponse("Invalid status")
+	}
+
+	page, meta, err := parsePage(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	comments, total, err := h.service.GetQueue(ctx.UserContext(), status, page)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get moderation queue")
+	}
+	meta.Total = total
+
+	return ctx.Status(http.StatusOK).JSON(newModerationListResponse(comments, meta))
+}
+
+func (h *handler) Moderate(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request moderationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	comment, err := h.service.Moderate(ctx.UserContext(), user, id, request.Action)
+	if err != nil {
+		return writeError(err, "Failed to moderate comment")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newModerationResponse(comment))
+}
+
+// parsePage lee los parámetros sort, page y limit. Con defaultSort vacío
+// no se acepta sort.
+func parsePage(ctx *fiber.Ctx, defaultSort string) (Page, pagination, error) {
+	meta := pagination{Page: 1, Limit: defaultLimit}
+	page := Page{Sort: defaultSort}
+
+	if defaultSort != "" {
+		if sort := ctx.Query("sort"); sort != "" {
+			if _, ok := orders[sort]; !ok {
+				return page, meta, response.NewBadRequestResponse("Invalid sort")
+			}
+			page.Sort = sort
+		}
+	}
+	if value := ctx.Query("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return page, meta, response.NewBadRequestResponse("Invalid page")
+		}
+		meta.Page = n
+	}
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLimit {
+			return page, meta, response.NewBadRequestResponse("Invalid limit")
+		}
+		meta.Limit = n
+	}
+
+	page.Limit = meta.Limit
+	page.Offset = (meta.Page - 1) * meta.Limit
+
+	return page, meta, nil
+}
+
+func writeError(err error, message string) error {
+	var validation *ex.ErrValidation
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.Is(err, ex.ErrForbidden):
+		return response.NewForbiddenResponse(err.Error())
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
@@ -0,0 +1,194 @@
+package comment
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+var example = &commentResponse{
+	ID:         1,
+	EpisodeID:  1,
+	Author:     commentAuthor{ID: 1, Username: "eren"},
+	Body:       "What a first episode!",
+	Status:     entities.CommentVisible,
+	ReplyCount: 2,
+	CreatedAt:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+}
+
+var exampleModeration = &moderationResponse{
+	ID:            3,
+	EpisodeID:     1,
+	Author:        commentAuthor{ID: 2, Username: "reiner"},
+	Body:          "The armored titan is ****",
+	Status:        entities.CommentPending,
+	ReportCount:   5,
+	AuthorReports: 12,
+	CreatedAt:     time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC),
+}
+
+// OpenAPI documenta los comentarios de los episodios y su moderación.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("CommentV2", commentResponse{})
+	list := doc.Register("CommentListV2", commentListResponse{})
+	queue := doc.Register("ModerationListV2", moderationListResponse{})
+	moderated := doc.Register("ModeratedCommentV2", moderationResponse{})
+	request := doc.Register("CommentRequestV2", commentRequest{})
+	update := doc.Register("CommentUpdateRequestV2", updateRequest{})
+	report := doc.Register("CommentReportRequestV2", reportRequest{})
+	action := doc.Register("ModerationRequestV2", moderationRequest{})
+
+	page := []*openapi.Parameter{
+		openapi.QueryParam("page", "Page number, starting at 1", &openapi.Schema{Type: "integer"}),
+		openapi.QueryParam("limit", "Comments per page (1 to 100, default 20)", &openapi.Schema{Type: "integer"}),
+	}
+	sort := func(defaultSort string) *openapi.Parameter {
+		return openapi.QueryParam("sort", "Order of the comments (default "+defaultSort+"); top sorts by number of replies",
+			&openapi.Schema{Type: "string", Enum: []any{SortNewest, SortOldest, SortTop}})
+	}
+	threads := "Comments that are no longer visible are only listed, with an empty body, while they have replies."
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	notFound := openapi.Error(http.StatusNotFound, "comment with id 1 not found")
+	forbidden := openapi.Error(http.StatusForbidden, "changing comment 1 of another user is forbidden")
+	filter := "The body is checked against the word filter of comments.filter_languages; depending on " +
+		"comments.filter_action the offending words are masked, the comment is rejected or it is held for review."
+
+	doc.Add(http.MethodGet, "/api/v2/episodes/:id/comments", &openapi.Operation{
+		OperationID: "listEpisodeCommentsV2",
+		Summary:     "List the comments of an episode",
+		Description: "Returns the root comments; replies are listed with /api/v2/comments/:id/replies. " + threads,
+		Tags:        []string{"comments"},
+		Parameters:  append([]*openapi.Parameter{openapi.IDParam(), sort(SortNewest)}, page...),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Comments", list, commentListResponse{
+				Data: []*commentResponse{example}, Pagination: pagination{Page: 1, Limit: 20, Total: 1},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid sort"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get comments"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/episodes/:id/comments", &openapi.Operation{
+		OperationID: "createEpisodeCommentV2",
+		Summary:     "Comment on an episode",
+		Description: "Set parent_id to reply to another visible comment of the same episode. " + filter,
+		Tags:        []string{"comments"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, commentRequest{Body: "What a first episode!"}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created comment", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Body is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create comment"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/comments/:id/replies", &openapi.Operation{
+		OperationID: "listCommentRepliesV2",
+		Summary:     "List the replies of a comment",
+		Description: "Returns the direct replies. " + threads,
+		Tags:        []string{"comments"},
+		Parameters:  append([]*openapi.Parameter{openapi.IDParam(), sort(SortOldest)}, page...),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Replies", list, commentListResponse{
+				Data: []*commentResponse{}, Pagination: pagination{Page: 1, Limit: 20},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid limit"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get replies"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/comments/:id", &openapi.Operation{
+		OperationID: "updateCommentV2",
+		Summary:     "Edit a comment",
+		Description: "Only the author can edit a comment, while it is visible or pending review. " + filter,
+		Tags:        []string{"comments"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(update, updateRequest{Body: "What a first episode!", Spoiler: true}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Edited comment", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Body is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update comment"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/comments/:id", &openapi.Operation{
+		OperationID: "deleteCommentV2",
+		Summary:     "Delete a comment",
+		Description: "Only the author can delete a comment. Its replies are kept.",
+		Tags:        []string{"comments"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete comment"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/comments/:id/reports", &openapi.Operation{
+		OperationID: "reportCommentV2",
+		Summary:     "Report a comment",
+		Description: "Each user counts once per comment. When a visible comment reaches comments.report_threshold " +
+			"reports it is held for review.",
+		Tags:        []string{"comments"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(report, reportRequest{Reason: "spoilers"}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Reported"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'comment' can not be reported by its author"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to report comment"),
+		}),
+	})
+
+	adminForbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	doc.Add(http.MethodGet, "/api/v2/admin/comments", &openapi.Operation{
+		OperationID: "listModerationQueueV2",
+		Summary:     "List the moderation queue",
+		Description: "Without status, lists the comments pending review or with reports, most reported first. " +
+			"author_reports counts the reports received by all the comments of the author. Requires an administrator token.",
+		Tags:     []string{"admin"},
+		Security: openapi.Authenticated(),
+		Parameters: append([]*openapi.Parameter{
+			openapi.QueryParam("status", "Only comments with this status", &openapi.Schema{
+				Type: "string", Enum: []any{entities.CommentPending, entities.CommentHidden, entities.CommentRemoved},
+			}),
+		}, page...),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Moderation queue", queue, moderationListResponse{
+				Data: []*moderationResponse{exampleModeration}, Pagination: pagination{Page: 1, Limit: 20, Total: 1},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid status"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           adminForbidden,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get moderation queue"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/admin/comments/:id/moderation", &openapi.Operation{
+		OperationID: "moderateCommentV2",
+		Summary:     "Moderate a comment",
+		Description: "approve makes the comment visible and dismisses its reports, hide hides it from the episode " +
+			"and remove removes it for breaking the rules. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(action, moderationRequest{Action: "hide"}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Moderated comment", moderated, exampleModeration),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'action' must be approve, hide or remove"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           adminForbidden,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to moderate comment"),
+		}),
+	})
+}
@@ -0,0 +1,355 @@
+package comment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+)
+
+const (
+	columns = `c.id, c.episode_id, COALESCE(c.parent_id, 0), c.user_id, u.username, c.body, c.spoiler, c.status,
+		c.reply_count, c.report_count, c.created_at, c.edited_at`
+	// Las consultas de listas llevan %s en lugar del ORDER BY, que se toma
+	// de orders.
+	queryGetByEpisodeId = "SELECT " + columns + `, COUNT(*) OVER()
+		FROM comments c JOIN users u ON u.id = c.user_id
+		WHERE c.episode_id = $1 AND c.parent_id IS NULL AND (c.status = 'visible' OR c.reply_count > 0)
+		ORDER BY %s LIMIT $2 OFFSET $3`
+	queryGetReplies = "SELECT " + columns + `, COUNT(*) OVER()
+		FROM comments c JOIN users u ON u.id = c.user_id
+		WHERE c.parent_id = $1 AND (c.status = 'visible' OR c.reply_count > 0)
+		ORDER BY %s LIMIT $2 OFFSET $3`
+	queryGetById = "SELECT " + columns + " FROM comments c JOIN users u ON u.id = c.user_id WHERE c.id = $1"
+	queryCreate  = `INSERT INTO comments (episode_id, parent_id, user_id, body, spoiler, status)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6) RETURNING id, created_at`
+	queryIncrementReplies = "UPDATE comments SET reply_count = reply_count + 1 WHERE id = $1"
+	queryUpdate           = `UPDATE comments SET body = $1, spoiler = $2, status = $3, edited_at = NOW()
+		WHERE id = $4 RETURNING edited_at`
+	queryDelete       = "UPDATE comments SET status = 'deleted', body = '' WHERE id = $1"
+	queryCreateReport = `INSERT INTO comment_reports (comment_id, user_id, reason) VALUES ($1, $2, $3)
+		ON CONFLICT (comment_id, user_id) DO NOTHING`
+	queryIncrementReports = `UPDATE comments
+		SET report_count = report_count + 1,
+			status = CASE WHEN status = 'visible' AND report_count + 1 >= $2 THEN 'pending' ELSE status END
+		WHERE id = $1`
+	// author_reports cuenta todas las denuncias recibidas por el autor.
+	queryGetQueue = "SELECT " + columns + `,
+			(SELECT COUNT(*) FROM comment_reports r JOIN comments rc ON rc.id = r.comment_id WHERE rc.user_id = c.user_id),
+			COUNT(*) OVER()
+		FROM comments c JOIN users u ON u.id = c.user_id
+		WHERE CASE WHEN $1 = '' THEN c.status = 'pending' OR (c.status = 'visible' AND c.report_count > 0)
+			ELSE c.status = $1 END
+		ORDER BY c.report_count DESC, c.created_at, c.id
+		LIMIT $2 OFFSET $3`
+	queryModerate = `UPDATE comments
+		SET status = $1, moderated_by = $2, moderated_at = NOW(),
+			report_count = CASE WHEN $3 THEN 0 ELSE report_count END
+		WHERE id = $4`
+	queryDeleteReports = "DELETE FROM comment_reports WHERE comment_id = $1"
+)
+
+var orders = map[string]string{
+	SortNewest: "c.created_at DESC, c.id DESC",
+	SortOldest: "c.created_at, c.id",
+	SortTop:    "c.reply_count DESC, c.created_at DESC, c.id DESC",
+}
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetByEpisodeID(ctx context.Context, episodeID uint64, page Page) ([]*entities.Comment, int, error) {
+	query := fmt.Sprintf(queryGetByEpisodeId, orders[page.Sort])
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByEpisodeId", query)
+	defer span.End()
+
+	comments, total, err := r.list(ctx, query, false, episodeID, page.Limit, page.Offset)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (r *repository) GetReplies(ctx context.Context, parentID uint64, page Page) ([]*entities.Comment, int, error) {
+	query := fmt.Sprintf(queryGetReplies, orders[page.Sort])
+	ctx, span := telemetry.StartQuery(ctx, "queryGetReplies", query)
+	defer span.End()
+
+	comments, total, err := r.list(ctx, query, false, parentID, page.Limit, page.Offset)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint64) (*entities.Comment, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetById", queryGetById)
+	defer span.End()
+
+	comment := &entities.Comment{}
+	if err := scan(r.db.QueryRowContext(ctx, queryGetById, id), comment); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("comment with id %d %w", id, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *repository) Create(ctx context.Context, comment *entities.Comment) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", queryCreate)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = tx.QueryRowContext(ctx, queryCreate, comment.EpisodeID, comment.ParentID, comment.UserID, comment.Body,
+		comment.Spoiler, comment.Status).Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, comment)
+	}
+
+	if comment.ParentID != 0 {
+		if _, err := tx.ExecContext(ctx, queryIncrementReplies, comment.ParentID); err != nil {
+			telemetry.RecordError(span, err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) Update(ctx context.Context, comment *entities.Comment) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdate", queryUpdate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryUpdate, comment.Body, comment.Spoiler, comment.Status, comment.ID).
+		Scan(&comment.EditedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("comment with id %d %w", comment.ID, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDelete", queryDelete)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDelete, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("comment with id %d %w", id, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) Report(ctx context.Context, report *entities.CommentReport, threshold int) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreateReport", queryCreateReport)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.ExecContext(ctx, queryCreateReport, report.CommentID, report.UserID, report.Reason)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23503" { // el comentario no existe
+			return fmt.Errorf("comment with id %d %w", report.CommentID, ex.ErrNotFound)
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, queryIncrementReports, report.CommentID, threshold); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetQueue(ctx context.Context, status string, page Page) ([]*entities.Comment, int, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetQueue", queryGetQueue)
+	defer span.End()
+
+	comments, total, err := r.list(ctx, queryGetQueue, true, status, page.Limit, page.Offset)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (r *repository) Moderate(ctx context.Context, id uint64, status string, moderatorID uint64, dismissReports bool) (*entities.Comment, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryModerate", queryModerate)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.ExecContext(ctx, queryModerate, status, moderatorID, dismissReports, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("comment with id %d %w", id, ex.ErrNotFound)
+	}
+
+	if dismissReports {
+		if _, err := tx.ExecContext(ctx, queryDeleteReports, id); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	comment := &entities.Comment{}
+	if err := scan(tx.QueryRowContext(ctx, queryGetById, id), comment); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// list ejecuta una consulta de lista cuya última columna es el total de
+// filas sin paginar. withAuthorReports indica que la penúltima es el total
+// de denuncias del autor.
+func (r *repository) list(ctx context.Context, query string, withAuthorReports bool, args ...any) ([]*entities.Comment, int, error) {
+	rows, err := r.replica.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	comments := []*entities.Comment{}
+	total := 0
+	for rows.Next() {
+		comment := &entities.Comment{}
+		extra := []any{&total}
+		if withAuthorReports {
+			extra = []any{&comment.AuthorReports, &total}
+		}
+		if err := scan(rows, comment, extra...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return comments, total, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner, comment *entities.Comment, extra ...any) error {
+	dest := append([]any{&comment.ID, &comment.EpisodeID, &comment.ParentID, &comment.UserID, &comment.Username,
+		&comment.Body, &comment.Spoiler, &comment.Status, &comment.ReplyCount, &comment.ReportCount,
+		&comment.CreatedAt, &comment.EditedAt}, extra...)
+
+	return row.Scan(dest...)
+}
+
+func mapError(err error, comment *entities.Comment) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23503": // el episodio o el comentario padre no existen
+			if pgErr.Constraint == "comments_parent_id_fkey" {
+				return fmt.Errorf("comment with id %d %w", comment.ParentID, ex.ErrNotFound)
+			}
+			return fmt.Errorf("episode with id %d %w", comment.EpisodeID, ex.ErrNotFound)
+		default:
+			log.Printf("Comment write failed: %s", err)
+			return fmt.Errorf("comment write failed: %w", err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,215 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"go.opentelemetry.io/otel"
+	"strings"
+	"unicode/utf8"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/comment")
+
+// moderation asocia cada acción de moderación con el estado resultante y si
+// descarta las denuncias.
+var moderation = map[string]struct {
+	status         string
+	dismissReports bool
+}{
+	"approve": {entities.CommentVisible, true},
+	"hide":    {entities.CommentHidden, false},
+	"remove":  {entities.CommentRemoved, false},
+}
+
+type service struct {
+	repository      Repository
+	filter          Filter
+	filterAction    string
+	maxLength       int
+	reportThreshold int
+}
+
+func NewService(repository Repository, filter Filter, config *config.Config) Service {
+	return &service{
+		repository:      repository,
+		filter:          filter,
+		filterAction:    config.Comments.FilterAction,
+		maxLength:       config.Comments.MaxLength,
+		reportThreshold: config.Comments.ReportThreshold,
+	}
+}
+
+func (s *service) GetByEpisodeID(ctx context.Context, episodeID uint64, page Page) ([]*entities.Comment, int, error) {
+	ctx, span := tracer.Start(ctx, "comment.Service.GetByEpisodeID")
+	defer span.End()
+
+	return s.repository.GetByEpisodeID(ctx, episodeID, page)
+}
+
+func (s *service) GetReplies(ctx context.Context, parentID uint64, page Page) ([]*entities.Comment, int, error) {
+	ctx, span := tracer.Start(ctx, "comment.Service.GetReplies")
+	defer span.End()
+
+	return s.repository.GetReplies(ctx, parentID, page)
+}
+
+func (s *service) Create(ctx context.Context, author *entities.User, comment *entities.Comment) error {
+	ctx, span := tracer.Start(ctx, "comment.Service.Create")
+	defer span.End()
+
+	if comment.ParentID != 0 {
+		parent, err := s.repository.GetByID(ctx, comment.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent.EpisodeID != comment.EpisodeID {
+			return &ex.ErrValidation{Field: "parent_id", Reason: "belongs to another episode"}
+		}
+		if parent.Status != entities.CommentVisible {
+			return &ex.ErrValidation{Field: "parent_id", Reason: "does not accept replies"}
+		}
+	}
+
+	comment.UserID = author.ID
+	comment.Username = author.Username
+	comment.Status = entities.CommentVisible
+	if err := s.check(comment); err != nil {
+		return err
+	}
+
+	return s.repository.Create(ctx, comment)
+}
+
+func (s *service) Update(ctx context.Context, author *entities.User, id uint64, body string, spoiler bool) (*entities.Comment, error) {
+	ctx, span := tracer.Start(ctx, "comment.Service.Update")
+	defer span.End()
+
+	comment, err := s.owned(ctx, author, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Un comentario pendiente sigue pendiente aunque se edite: lo decide
+	// un moderador.
+	comment.Body = body
+	comment.Spoiler = spoiler
+	if err := s.check(comment); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *service) Delete(ctx context.Context, author *entities.User, id uint64) error {
+	ctx, span := tracer.Start(ctx, "comment.Service.Delete")
+	defer span.End()
+
+	if _, err := s.owned(ctx, author, id); err != nil {
+		return err
+	}
+
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *service) Report(ctx context.Context, user *entities.User, report *entities.CommentReport) error {
+	ctx, span := tracer.Start(ctx, "comment.Service.Report")
+	defer span.End()
+
+	comment, err := s.repository.GetByID(ctx, report.CommentID)
+	if err != nil {
+		return err
+	}
+	if comment.Status != entities.CommentVisible && comment.Status != entities.CommentPending {
+		return fmt.Errorf("comment with id %d %w", comment.ID, ex.ErrNotFound)
+	}
+	if comment.UserID == user.ID {
+		return &ex.ErrValidation{Field: "comment", Reason: "can not be reported by its author"}
+	}
+
+	report.UserID = user.ID
+	report.Reason = strings.TrimSpace(report.Reason)
+
+	return s.repository.Report(ctx, report, s.reportThreshold)
+}
+
+func (s *service) GetQueue(ctx context.Context, status string, page Page) ([]*entities.Comment, int, error) {
+	ctx, span := tracer.Start(ctx, "comment.Service.GetQueue")
+	defer span.End()
+
+	return s.repository.GetQueue(ctx, status, page)
+}
+
+func (s *service) Moderate(ctx context.Context, moderator *entities.User, id uint64, action string) (*entities.Comment, error) {
+	ctx, span := tracer.Start(ctx, "comment.Service.Moderate")
+	defer span.End()
+
+	result, ok := moderation[action]
+	if !ok {
+		return nil, &ex.ErrValidation{Field: "action", Reason: "must be approve, hide or remove"}
+	}
+
+	comment, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Status == entities.CommentDeleted {
+		return nil, fmt.Errorf("changing deleted comment %d is %w", id, ex.ErrForbidden)
+	}
+
+	return s.repository.Moderate(ctx, id, result.status, moderator.ID, result.dismissReports)
+}
+
+// owned devuelve el comentario si author puede editarlo o borrarlo: debe
+// ser suyo y no estar borrado ni moderado.
+func (s *service) owned(ctx context.Context, author *entities.User, id uint64) (*entities.Comment, error) {
+	comment, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case comment.Status == entities.CommentDeleted:
+		return nil, fmt.Errorf("comment with id %d %w", id, ex.ErrNotFound)
+	case comment.UserID != author.ID:
+		return nil, fmt.Errorf("changing comment %d of another user is %w", id, ex.ErrForbidden)
+	case comment.Status != entities.CommentVisible && comment.Status != entities.CommentPending:
+		return nil, fmt.Errorf("changing %s comment %d is %w", comment.Status, id, ex.ErrForbidden)
+	}
+
+	return comment, nil
+}
+
+// check valida el texto y le aplica el filtro de palabras según
+// comments.filter_action.
+func (s *service) check(comment *entities.Comment) error {
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" {
+		return &ex.ErrValidation{Field: "body", Reason: "is required"}
+	}
+	if utf8.RuneCountInString(comment.Body) > s.maxLength {
+		return &ex.ErrValidation{Field: "body", Reason: fmt.Sprintf("must not be longer than %d characters", s.maxLength)}
+	}
+
+	matches := s.filter.Find(comment.Body)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	switch s.filterAction {
+	case "reject":
+		return &ex.ErrValidation{Field: "body", Reason: "contains words that are not allowed"}
+	case "review":
+		comment.Status = entities.CommentPending
+	default:
+		comment.Body = mask(comment.Body, matches)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package credit gestiona los estudios de las temporadas y los créditos del
+// equipo de cada episodio.
+package credit
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type Repository interface {
+	// GetStudios devuelve los estudios con el número de temporadas de cada
+	// uno.
+	GetStudios(ctx context.Context) ([]*entities.Studio, error)
+	GetStudioByID(ctx context.Context, id uint64) (*entities.Studio, error)
+	CreateStudio(ctx context.Context, studio *entities.Studio) error
+	UpdateStudio(ctx context.Context, studio *entities.Studio) error
+	DeleteStudio(ctx context.Context, id uint64) error
+	// SetSeasonStudios reemplaza los estudios de la temporada y devuelve los
+	// nuevos.
+	SetSeasonStudios(ctx context.Context, seasonID uint64, studios []*entities.SeasonStudio) ([]*entities.SeasonStudio, error)
+	// GetStudiosBySeasonIDs devuelve los estudios de cada temporada.
+	GetStudiosBySeasonIDs(ctx context.Context, seasonIDs []uint64) (map[uint64][]*entities.SeasonStudio, error)
+	// GetSeasonsByStudioID devuelve las temporadas del estudio ordenadas por
+	// nombre.
+	GetSeasonsByStudioID(ctx context.Context, studioID uint64) ([]*entities.SeasonStudio, error)
+	// GetByEpisodeIDs devuelve los créditos de cada episodio en el orden de
+	// entities.CreditRoles.
+	GetByEpisodeIDs(ctx context.Context, episodeIDs []uint64) (map[uint64][]*entities.Credit, error)
+	// GetByPersonID devuelve los créditos de la persona ordenados por
+	// temporada y episodio.
+	GetByPersonID(ctx context.Context, personID uint64) ([]*entities.Credit, error)
+	Create(ctx context.Context, credit *entities.Credit) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+type Service interface {
+	GetStudios(ctx context.Context) ([]*entities.Studio, error)
+	// GetStudioSeasons devuelve el estudio junto con sus temporadas.
+	GetStudioSeasons(ctx context.Context, id uint64) (*entities.Studio, []*entities.SeasonStudio, error)
+	CreateStudio(ctx context.Context, studio *entities.Studio) error
+	UpdateStudio(ctx context.Context, id uint64, studio *entities.Studio) error
+	DeleteStudio(ctx context.Context, id uint64) error
+	SetSeasonStudios(ctx context.Context, seasonID uint64, studios []*entities.SeasonStudio) ([]*entities.SeasonStudio, error)
+	Create(ctx context.Context, credit *entities.Credit) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+type Handler interface {
+	GetStudios(ctx *fiber.Ctx) error
+	GetStudioSeasons(ctx *fiber.Ctx) error
+	CreateStudio(ctx *fiber.Ctx) error
+	UpdateStudio(ctx *fiber.Ctx) error
+	DeleteStudio(ctx *fiber.Ctx) error
+	SetSeasonStudios(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+}
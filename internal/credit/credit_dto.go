@@ -0,0 +1,125 @@
+package credit
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type studioRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type seasonStudioRequest struct {
+	StudioID uint64 `json:"studio_id" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=animation production"`
+}
+
+type seasonStudiosRequest struct {
+	// Studios reemplaza los estudios de la temporada; vacío los quita todos.
+	Studios []*seasonStudioRequest `json:"studios" validate:"dive"`
+}
+
+func (r *seasonStudiosRequest) toEntities() []*entities.SeasonStudio {
+	studios := make([]*entities.SeasonStudio, 0, len(r.Studios))
+	for _, studio := range r.Studios {
+		studios = append(studios, &entities.SeasonStudio{Studio: entities.Studio{ID: studio.StudioID}, Role: studio.Role})
+	}
+
+	return studios
+}
+
+type creditRequest struct {
+	PersonID uint64 `json:"person_id" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=director storyboard script animation_director"`
+}
+
+type studioResponse struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// Seasons cuenta las temporadas del estudio.
+	Seasons int `json:"seasons"`
+}
+
+func newStudioResponse(studio *entities.Studio) *studioResponse {
+	return &studioResponse{ID: studio.ID, Name: studio.Name, Slug: studio.Slug, Seasons: studio.Seasons}
+}
+
+type studioListResponse struct {
+	Data []*studioResponse `json:"data"`
+}
+
+func newStudioListResponse(studios []*entities.Studio) *studioListResponse {
+	list := &studioListResponse{Data: make([]*studioResponse, 0, len(studios))}
+	for _, studio := range studios {
+		list.Data = append(list.Data, newStudioResponse(studio))
+	}
+
+	return list
+}
+
+type studioSummary struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type seasonStudio struct {
+	Studio *studioSummary `json:"studio"`
+	Role   string         `json:"role"`
+}
+
+type seasonStudiosResponse struct {
+	SeasonID uint64          `json:"season_id"`
+	Data     []*seasonStudio `json:"data"`
+}
+
+func newSeasonStudiosResponse(seasonID uint64, studios []*entities.SeasonStudio) *seasonStudiosResponse {
+	list := &seasonStudiosResponse{SeasonID: seasonID, Data: make([]*seasonStudio, 0, len(studios))}
+	for _, studio := range studios {
+		list.Data = append(list.Data, &seasonStudio{
+			Studio: &studioSummary{ID: studio.Studio.ID, Name: studio.Studio.Name, Slug: studio.Studio.Slug},
+			Role:   studio.Role,
+		})
+	}
+
+	return list
+}
+
+type studioSeasonSummary struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+type studioSeason struct {
+	Season *studioSeasonSummary `json:"season"`
+	Role   string               `json:"role"`
+}
+
+type studioSeasonsResponse struct {
+	Studio *studioResponse `json:"studio"`
+	Data   []*studioSeason `json:"data"`
+}
+
+func newStudioSeasonsResponse(studio *entities.Studio, seasons []*entities.SeasonStudio) *studioSeasonsResponse {
+	list := &studioSeasonsResponse{Studio: newStudioResponse(studio), Data: make([]*studioSeason, 0, len(seasons))}
+	for _, season := range seasons {
+		list.Data = append(list.Data, &studioSeason{
+			Season: &studioSeasonSummary{ID: season.SeasonID, Title: season.SeasonName, Slug: season.SeasonSlug},
+			Role:   season.Role,
+		})
+	}
+
+	return list
+}
+
+type creditResponse struct {
+	ID        uint64 `json:"id"`
+	EpisodeID uint64 `json:"episode_id"`
+	PersonID  uint64 `json:"person_id"`
+	Role      string `json:"role"`
+}
+
+func newCreditResponse(credit *entities.Credit) *creditResponse {
+	return &creditResponse{ID: credit.ID, EpisodeID: credit.EpisodeID, PersonID: credit.Person.ID, Role: credit.Role}
+}
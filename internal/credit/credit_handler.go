@@ -0,0 +1,176 @@
+package credit
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetStudios(ctx *fiber.Ctx) error {
+	studios, err := h.service.GetStudios(ctx.UserContext())
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get studios")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newStudioListResponse(studios))
+}
+
+func (h *handler) GetStudioSeasons(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	studio, seasons, err := h.service.GetStudioSeasons(ctx.UserContext(), id)
+	if err != nil {
+		return writeError(err, "Failed to get seasons of studio")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newStudioSeasonsResponse(studio, seasons))
+}
+
+func (h *handler) CreateStudio(ctx *fiber.Ctx) error {
+	var request studioRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	studio := &entities.Studio{Name: request.Name}
+	if err := h.service.CreateStudio(ctx.UserContext(), studio); err != nil {
+		return writeError(err, "Failed to create studio")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newStudioResponse(studio))
+}
+
+func (h *handler) UpdateStudio(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request studioRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	studio := &entities.Studio{Name: request.Name}
+	if err := h.service.UpdateStudio(ctx.UserContext(), id, studio); err != nil {
+		return writeError(err, "Failed to update studio")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newStudioResponse(studio))
+}
+
+func (h *handler) DeleteStudio(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.DeleteStudio(ctx.UserContext(), id); err != nil {
+		return writeError(err, "Failed to delete studio")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) SetSeasonStudios(ctx *fiber.Ctx) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request seasonStudiosRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	studios, err := h.service.SetSeasonStudios(ctx.UserContext(), seasonID, request.toEntities())
+	if err != nil {
+		return writeError(err, "Failed to save studios of season")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSeasonStudiosResponse(seasonID, studios))
+}
+
+func (h *handler) Create(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request creditRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	credit := &entities.Credit{EpisodeID: episodeID, Person: entities.Person{ID: request.PersonID}, Role: request.Role}
+	if err := h.service.Create(ctx.UserContext(), credit); err != nil {
+		return writeError(err, "Failed to create credit")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newCreditResponse(credit))
+}
+
+func (h *handler) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), id); err != nil {
+		return writeError(err, "Failed to delete credit")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func writeError(err error, message string) error {
+	var alreadyExists *ex.ErrAlreadyExists
+	var validation *ex.ErrValidation
+
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &alreadyExists):
+		if alreadyExists.Field == "role" {
+			return response.NewConflictResponse("The person already has this role in the episode")
+		}
+		return response.NewConflictResponse("A studio with this " + alreadyExists.Field + " already exists")
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
@@ -0,0 +1,170 @@
+package credit
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"strings"
+)
+
+var exampleStudio = &studioResponse{ID: 1, Name: "Wit Studio", Slug: "wit-studio", Seasons: 3}
+
+// OpenAPI documenta los estudios, su asignación a las temporadas y los
+// créditos del equipo de los episodios.
+func OpenAPI(doc *openapi.Document) {
+	studio := doc.Register("StudioV2", studioResponse{})
+	studios := doc.Register("StudioListV2", studioListResponse{})
+	studioInput := doc.Register("StudioRequestV2", studioRequest{})
+	studioSeasons := doc.Register("StudioSeasonsV2", studioSeasonsResponse{})
+	seasonStudios := doc.Register("SeasonStudiosV2", seasonStudiosResponse{})
+	seasonStudiosInput := doc.Register("SeasonStudiosRequestV2", seasonStudiosRequest{})
+	credit := doc.Register("CreditV2", creditResponse{})
+	creditInput := doc.Register("CreditRequestV2", creditRequest{})
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+	notFound := openapi.Error(http.StatusNotFound, "studio with id 1 not found")
+	conflict := openapi.Error(http.StatusConflict, "A studio with this name already exists")
+	input := studioRequest{Name: exampleStudio.Name}
+
+	doc.Add(http.MethodGet, "/api/v2/studios", &openapi.Operation{
+		OperationID: "listStudiosV2",
+		Summary:     "List studios",
+		Description: "Sorted by name, with the number of seasons of each one.",
+		Tags:        []string{"credits"},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Studios", studios, studioListResponse{Data: []*studioResponse{exampleStudio}}),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get studios"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/studios/:id/seasons", &openapi.Operation{
+		OperationID: "listStudioSeasonsV2",
+		Summary:     "List the seasons of a studio",
+		Description: "Sorted by title. A studio with both roles in a season appears once per role.",
+		Tags:        []string{"credits"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Seasons of the studio", studioSeasons, studioSeasonsResponse{
+				Studio: exampleStudio,
+				Data: []*studioSeason{{
+					Season: &studioSeasonSummary{ID: 1, Title: "shingeki no kyojin", Slug: "shingeki-no-kyojin"},
+					Role:   entities.StudioAnimation,
+				}},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get seasons of studio"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/admin/studios", &openapi.Operation{
+		OperationID: "createStudioV2",
+		Summary:     "Create a studio",
+		Description: "The slug is derived from the name. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		RequestBody: openapi.JSONBody(studioInput, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created studio", studio, studioResponse{ID: 1, Name: exampleStudio.Name, Slug: exampleStudio.Slug}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Name is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusConflict:            conflict,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create studio"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/admin/studios/:id", &openapi.Operation{
+		OperationID: "updateStudioV2",
+		Summary:     "Rename a studio",
+		Description: "The slug changes with the name. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(studioInput, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Updated studio", studio, exampleStudio),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            notFound,
+			http.StatusConflict:            conflict,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update studio"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/admin/studios/:id", &openapi.Operation{
+		OperationID: "deleteStudioV2",
+		Summary:     "Delete a studio",
+		Description: "It is also removed from every season. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete studio"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/admin/seasons/:id/studios", &openapi.Operation{
+		OperationID: "setSeasonStudiosV2",
+		Summary:     "Replace the studios of a season",
+		Description: "The role is one of " + strings.Join(entities.StudioRoles, ", ") + "; an empty list removes every studio. " +
+			"Requires an administrator token.",
+		Tags:       []string{"admin"},
+		Security:   openapi.Authenticated(),
+		Parameters: []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(seasonStudiosInput, seasonStudiosRequest{Studios: []*seasonStudioRequest{
+			{StudioID: 1, Role: entities.StudioAnimation},
+		}}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Season studios", seasonStudios, seasonStudiosResponse{
+				SeasonID: 1,
+				Data: []*seasonStudio{{
+					Studio: &studioSummary{ID: 1, Name: exampleStudio.Name, Slug: exampleStudio.Slug},
+					Role:   entities.StudioAnimation,
+				}},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'studio_id' does not exist"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save studios of season"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/admin/episodes/:id/credits", &openapi.Operation{
+		OperationID: "createCreditV2",
+		Summary:     "Credit a person in an episode",
+		Description: "The role is one of " + strings.Join(entities.CreditRoles, ", ") + ". " +
+			"A person can hold several roles in the same episode. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(creditInput, creditRequest{PersonID: 2, Role: entities.CreditDirector}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created credit", credit, creditResponse{ID: 1, EpisodeID: 1, PersonID: 2, Role: entities.CreditDirector}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'person_id' does not exist"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusConflict:            openapi.Error(http.StatusConflict, "The person already has this role in the episode"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create credit"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/admin/credits/:id", &openapi.Operation{
+		OperationID: "deleteCreditV2",
+		Summary:     "Delete a credit",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "credit with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete credit"),
+		}),
+	})
+}
@@ -0,0 +1,414 @@
+package credit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"strings"
+)
+
+const (
+	queryGetStudios = `SELECT st.id, st.name, st.slug, COUNT(DISTINCT l.season_id)
+		FROM studios st LEFT JOIN season_studios l ON l.studio_id = st.id
+		GROUP BY st.id ORDER BY st.name`
+	queryGetStudioById = `SELECT st.id, st.name, st.slug, COUNT(DISTINCT l.season_id)
+		FROM studios st LEFT JOIN season_studios l ON l.studio_id = st.id
+		WHERE st.id = $1 GROUP BY st.id`
+	queryCreateStudio = "INSERT INTO studios (name, slug) VALUES ($1, $2) RETURNING id"
+	queryUpdateStudio = "UPDATE studios SET name = $1, slug = $2 WHERE id = $3"
+	queryDeleteStudio = "DELETE FROM studios WHERE id = $1"
+
+	// La temporada se bloquea en modo compartido para que no desaparezca
+	// mientras se enlazan sus estudios.
+	queryLockSeason          = "SELECT id FROM seasons WHERE id = $1 FOR KEY SHARE"
+	queryDeleteSeasonStudios = "DELETE FROM season_studios WHERE season_id = $1"
+	queryInsertSeasonStudios = `INSERT INTO season_studios (season_id, studio_id, role)
+		SELECT $1, t.studio_id, t.role FROM unnest($2::int[], $3::text[]) AS t(studio_id, role)`
+	querySelectSeasonStudios = `SELECT s.id, s.name, s.slug, st.id, st.name, st.slug, l.role
+		FROM season_studios l
+		JOIN seasons s ON s.id = l.season_id
+		JOIN studios st ON st.id = l.studio_id`
+	queryGetStudiosBySeasonIds = querySelectSeasonStudios + `
+		WHERE l.season_id = ANY($1) ORDER BY l.role, st.name`
+	queryGetSeasonsByStudioId = querySelectSeasonStudios + `
+		WHERE l.studio_id = $1 ORDER BY s.name, s.number, l.role`
+
+	querySelectCredits = `SELECT c.id, e.id, e.name, e.number, e.slug, s.id, s.name, s.slug,
+			p.id, p.name, p.description, p.image_url, c.role
+		FROM episode_credits c
+		JOIN episodes e ON e.id = c.episode_id
+		JOIN seasons s ON s.id = e.season_id
+		JOIN people p ON p.id = c.person_id`
+	queryGetByEpisodeIds = querySelectCredits + `
+		WHERE c.episode_id = ANY($1) ORDER BY array_position($2::text[], c.role::text), p.name`
+	queryGetByPersonId = querySelectCredits + `
+		WHERE c.person_id = $1 ORDER BY s.name, s.number, e.number, c.role`
+	queryCreate = `INSERT INTO episode_credits (episode_id, person_id, role)
+		VALUES ($1, $2, $3) RETURNING id`
+	queryDeleteById = "DELETE FROM episode_credits WHERE id = $1"
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetStudios(ctx context.Context) ([]*entities.Studio, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetStudios", queryGetStudios)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetStudios)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	studios := []*entities.Studio{}
+	for rows.Next() {
+		studio := &entities.Studio{}
+		if err := rows.Scan(&studio.ID, &studio.Name, &studio.Slug, &studio.Seasons); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		studios = append(studios, studio)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return studios, nil
+}
+
+func (r *repository) GetStudioByID(ctx context.Context, id uint64) (*entities.Studio, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetStudioById", queryGetStudioById)
+	defer span.End()
+
+	studio := &entities.Studio{}
+	err := r.db.QueryRowContext(ctx, queryGetStudioById, id).Scan(&studio.ID, &studio.Name, &studio.Slug, &studio.Seasons)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("studio with id %d %w", id, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return studio, nil
+}
+
+func (r *repository) CreateStudio(ctx context.Context, studio *entities.Studio) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreateStudio", queryCreateStudio)
+	defer span.End()
+
+	if err := r.db.QueryRowContext(ctx, queryCreateStudio, studio.Name, studio.Slug).Scan(&studio.ID); err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "studio")
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateStudio(ctx context.Context, studio *entities.Studio) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdateStudio", queryUpdateStudio)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryUpdateStudio, studio.Name, studio.Slug, studio.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, "studio")
+	}
+
+	return checkAffected(result, "studio", studio.ID)
+}
+
+func (r *repository) DeleteStudio(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteStudio", queryDeleteStudio)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteStudio, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return checkAffected(result, "studio", id)
+}
+
+func (r *repository) SetSeasonStudios(ctx context.Context, seasonID uint64, studios []*entities.SeasonStudio) ([]*entities.SeasonStudio, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryInsertSeasonStudios", queryInsertSeasonStudios)
+	defer span.End()
+
+	saved, err := r.setSeasonStudios(ctx, seasonID, studios)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, mapError(err, "studio")
+	}
+
+	return saved, nil
+}
+
+func (r *repository) setSeasonStudios(ctx context.Context, seasonID uint64, studios []*entities.SeasonStudio) ([]*entities.SeasonStudio, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var id uint64
+	if err := tx.QueryRowContext(ctx, queryLockSeason, seasonID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("season with id %d %w", seasonID, ex.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(studios))
+	roles := make([]string, 0, len(studios))
+	for _, studio := range studios {
+		ids = append(ids, int64(studio.Studio.ID))
+		roles = append(roles, studio.Role)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryDeleteSeasonStudios, seasonID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, queryInsertSeasonStudios, seasonID, pq.Array(ids), pq.Array(roles)); err != nil {
+		return nil, err
+	}
+
+	// Se leen en la transacción para no depender del retraso de la réplica.
+	rows, err := tx.QueryContext(ctx, queryGetStudiosBySeasonIds, pq.Array([]uint64{seasonID}))
+	if err != nil {
+		return nil, err
+	}
+	saved, err := scanSeasonStudios(rows)
+	closeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, tx.Commit()
+}
+
+func (r *repository) GetStudiosBySeasonIDs(ctx context.Context, seasonIDs []uint64) (map[uint64][]*entities.SeasonStudio, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetStudiosBySeasonIds", queryGetStudiosBySeasonIds)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetStudiosBySeasonIds, pq.Array(seasonIDs))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	list, err := scanSeasonStudios(rows)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	studios := map[uint64][]*entities.SeasonStudio{}
+	for _, studio := range list {
+		studios[studio.SeasonID] = append(studios[studio.SeasonID], studio)
+	}
+
+	return studios, nil
+}
+
+func (r *repository) GetSeasonsByStudioID(ctx context.Context, studioID uint64) ([]*entities.SeasonStudio, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetSeasonsByStudioId", queryGetSeasonsByStudioId)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetSeasonsByStudioId, studioID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	seasons, err := scanSeasonStudios(rows)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+func (r *repository) GetByEpisodeIDs(ctx context.Context, episodeIDs []uint64) (map[uint64][]*entities.Credit, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByEpisodeIds", queryGetByEpisodeIds)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetByEpisodeIds, pq.Array(episodeIDs), pq.Array(entities.CreditRoles))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	list, err := scanCredits(rows)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	credits := map[uint64][]*entities.Credit{}
+	for _, credit := range list {
+		credits[credit.EpisodeID] = append(credits[credit.EpisodeID], credit)
+	}
+
+	return credits, nil
+}
+
+func (r *repository) GetByPersonID(ctx context.Context, personID uint64) ([]*entities.Credit, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByPersonId", queryGetByPersonId)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetByPersonId, personID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	credits, err := scanCredits(rows)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+func (r *repository) Create(ctx context.Context, credit *entities.Credit) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", queryCreate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreate, credit.EpisodeID, credit.Person.ID, credit.Role).Scan(&credit.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Constraint == "episode_credits_episode_id_fkey" {
+			return fmt.Errorf("episode with id %d %w", credit.EpisodeID, ex.ErrNotFound)
+		}
+		return mapError(err, "credit")
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteById", queryDeleteById)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteById, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return checkAffected(result, "credit", id)
+}
+
+func scanSeasonStudios(rows *sql.Rows) ([]*entities.SeasonStudio, error) {
+	studios := []*entities.SeasonStudio{}
+	for rows.Next() {
+		studio := &entities.SeasonStudio{}
+		if err := rows.Scan(&studio.SeasonID, &studio.SeasonName, &studio.SeasonSlug,
+			&studio.Studio.ID, &studio.Studio.Name, &studio.Studio.Slug, &studio.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		studios = append(studios, studio)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return studios, nil
+}
+
+func scanCredits(rows *sql.Rows) ([]*entities.Credit, error) {
+	credits := []*entities.Credit{}
+	for rows.Next() {
+		credit := &entities.Credit{}
+		if err := rows.Scan(&credit.ID, &credit.EpisodeID, &credit.EpisodeName, &credit.EpisodeNumber, &credit.EpisodeSlug,
+			&credit.SeasonID, &credit.SeasonName, &credit.SeasonSlug,
+			&credit.Person.ID, &credit.Person.Name, &credit.Person.Description, &credit.Person.ImageUrl,
+			&credit.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		credits = append(credits, credit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return credits, nil
+}
+
+func closeRows(rows *sql.Rows) {
+	if err := rows.Close(); err != nil {
+		log.Printf("failed to close rows: %s", err)
+	}
+}
+
+func checkAffected(result sql.Result, kind string, id uint64) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s with id %d %w", kind, id, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func mapError(err error, kind string) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23505": // nombre o slug del estudio, o crédito repetidos
+			field := "name"
+			switch {
+			case strings.HasSuffix(pgErr.Constraint, "_slug_key"):
+				field = "slug"
+			case strings.HasPrefix(pgErr.Constraint, "episode_credits_"):
+				field = "role"
+			}
+			return &ex.ErrAlreadyExists{
+				Field:      field,
+				Constraint: pgErr.Constraint,
+			}
+		case "23503": // el estudio o la persona no existen
+			field := "studio_id"
+			if strings.HasPrefix(pgErr.Constraint, "episode_credits_") {
+				field = "person_id"
+			}
+			return &ex.ErrValidation{
+				Field:  field,
+				Reason: "does not exist",
+			}
+		default:
+			log.Printf("%s write failed: %s", kind, err)
+			return fmt.Errorf("%s write failed: %w", kind, err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,120 @@
+package credit
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.opentelemetry.io/otel"
+	"strings"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/credit")
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) GetStudios(ctx context.Context) ([]*entities.Studio, error) {
+	ctx, span := tracer.Start(ctx, "credit.Service.GetStudios")
+	defer span.End()
+
+	return s.repository.GetStudios(ctx)
+}
+
+func (s *service) GetStudioSeasons(ctx context.Context, id uint64) (*entities.Studio, []*entities.SeasonStudio, error) {
+	ctx, span := tracer.Start(ctx, "credit.Service.GetStudioSeasons")
+	defer span.End()
+
+	studio, err := s.repository.GetStudioByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seasons, err := s.repository.GetSeasonsByStudioID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return studio, seasons, nil
+}
+
+func (s *service) CreateStudio(ctx context.Context, studio *entities.Studio) error {
+	ctx, span := tracer.Start(ctx, "credit.Service.CreateStudio")
+	defer span.End()
+
+	studio.Name = strings.TrimSpace(studio.Name)
+	studio.Slug = slugify(studio.Name)
+
+	return s.repository.CreateStudio(ctx, studio)
+}
+
+func (s *service) UpdateStudio(ctx context.Context, id uint64, studio *entities.Studio) error {
+	ctx, span := tracer.Start(ctx, "credit.Service.UpdateStudio")
+	defer span.End()
+
+	studio.ID = id
+	studio.Name = strings.TrimSpace(studio.Name)
+	studio.Slug = slugify(studio.Name)
+	if err := s.repository.UpdateStudio(ctx, studio); err != nil {
+		return err
+	}
+
+	updated, err := s.repository.GetStudioByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	*studio = *updated
+
+	return nil
+}
+
+func (s *service) DeleteStudio(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "credit.Service.DeleteStudio")
+	defer span.End()
+
+	return s.repository.DeleteStudio(ctx, id)
+}
+
+func (s *service) SetSeasonStudios(ctx context.Context, seasonID uint64, studios []*entities.SeasonStudio) ([]*entities.SeasonStudio, error) {
+	ctx, span := tracer.Start(ctx, "credit.Service.SetSeasonStudios")
+	defer span.End()
+
+	// Un mismo estudio puede tener los dos papeles, pero cada uno una vez.
+	type key struct {
+		id   uint64
+		role string
+	}
+	seen := map[key]bool{}
+	unique := make([]*entities.SeasonStudio, 0, len(studios))
+	for _, studio := range studios {
+		k := key{studio.Studio.ID, studio.Role}
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, studio)
+		}
+	}
+
+	return s.repository.SetSeasonStudios(ctx, seasonID, unique)
+}
+
+func (s *service) Create(ctx context.Context, credit *entities.Credit) error {
+	ctx, span := tracer.Start(ctx, "credit.Service.Create")
+	defer span.End()
+
+	return s.repository.Create(ctx, credit)
+}
+
+func (s *service) Delete(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "credit.Service.Delete")
+	defer span.End()
+
+	return s.repository.Delete(ctx, id)
+}
+
+// slugify pasa el nombre a minúsculas y une sus palabras con guiones.
+func slugify(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
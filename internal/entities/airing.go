@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// Airing es la emisión programada de un episodio. TimeZone es la zona
+// horaria de la emisión, con la que se muestran AirsAt y EndsAt por
+// defecto; EndsAt suma la duración del episodio. Sequence cuenta los
+// cambios de fecha y UpdatedAt guarda el último.
+type Airing struct {
+	EpisodeID     uint64    `json:"episode_id"`
+	EpisodeName   string    `json:"episode_name"`
+	EpisodeNumber uint8     `json:"episode_number"`
+	EpisodeSlug   string    `json:"episode_slug"`
+	SeasonID      uint64    `json:"season_id"`
+	SeasonName    string    `json:"season_name"`
+	SeasonSlug    string    `json:"season_slug"`
+	AirsAt        time.Time `json:"airs_at"`
+	TimeZone      string    `json:"time_zone"`
+	EndsAt        time.Time `json:"ends_at"`
+	Sequence      int       `json:"sequence"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+// Character es un personaje de la serie.
+type Character struct {
+	ID          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ImageUrl    string `json:"image_url"`
+}
+
+// Person es una persona del reparto, como un actor de voz.
+type Person struct {
+	ID          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ImageUrl    string `json:"image_url"`
+}
+
+// Role indica que la persona da voz al personaje en el idioma y la
+// temporada. Al leerlo se rellenan también los datos de los tres.
+type Role struct {
+	ID         uint64    `json:"id"`
+	SeasonID   uint64    `json:"season_id"`
+	SeasonName string    `json:"season_name"`
+	SeasonSlug string    `json:"season_slug"`
+	Character  Character `json:"character"`
+	Person     Person    `json:"person"`
+	Language   string    `json:"language"`
+}
@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+const (
+	CommentVisible = "visible"
+	CommentPending = "pending"
+	CommentHidden  = "hidden"
+	CommentRemoved = "removed"
+	CommentDeleted = "deleted"
+)
+
+// Comment es un comentario de un episodio o una respuesta a otro
+// comentario. ParentID es 0 en los comentarios raíz. AuthorReports, el
+// total de denuncias recibidas por el autor, sólo se rellena en la cola de
+// moderación.
+type Comment struct {
+	ID            uint64     `json:"id"`
+	EpisodeID     uint64     `json:"episode_id"`
+	ParentID      uint64     `json:"parent_id"`
+	UserID        uint64     `json:"user_id"`
+	Username      string     `json:"username"`
+	Body          string     `json:"body"`
+	Spoiler       bool       `json:"spoiler"`
+	Status        string     `json:"status"`
+	ReplyCount    int        `json:"reply_count"`
+	ReportCount   int        `json:"report_count"`
+	AuthorReports int        `json:"author_reports"`
+	CreatedAt     time.Time  `json:"created_at"`
+	EditedAt      *time.Time `json:"edited_at"`
+}
+
+// CommentReport es la denuncia de un usuario sobre un comentario.
+type CommentReport struct {
+	CommentID uint64 `json:"comment_id"`
+	UserID    uint64 `json:"user_id"`
+	Reason    string `json:"reason"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+const (
+	StudioAnimation  = "animation"
+	StudioProduction = "production"
+)
+
+// StudioRoles son los papeles de un estudio en una temporada.
+var StudioRoles = []string{StudioAnimation, StudioProduction}
+
+const (
+	CreditDirector          = "director"
+	CreditStoryboard        = "storyboard"
+	CreditScript            = "script"
+	CreditAnimationDirector = "animation_director"
+)
+
+// CreditRoles son los puestos del equipo de un episodio, en el orden en que
+// se muestran.
+var CreditRoles = []string{CreditDirector, CreditScript, CreditStoryboard, CreditAnimationDirector}
+
+// Studio es un estudio de animación o de producción. Seasons cuenta sus
+// temporadas y sólo se rellena en los listados.
+type Studio struct {
+	ID      uint64 `json:"id"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Seasons int    `json:"seasons"`
+}
+
+// SeasonStudio indica que el estudio participó en la temporada con el
+// papel.
+type SeasonStudio struct {
+	SeasonID   uint64 `json:"season_id"`
+	SeasonName string `json:"season_name"`
+	SeasonSlug string `json:"season_slug"`
+	Studio     Studio `json:"studio"`
+	Role       string `json:"role"`
+}
+
+// Credit atribuye a la persona un puesto en el episodio. Al leerlo se
+// rellenan también los datos del episodio, su temporada y la persona.
+type Credit struct {
+	ID            uint64 `json:"id"`
+	EpisodeID     uint64 `json:"episode_id"`
+	EpisodeName   string `json:"episode_name"`
+	EpisodeNumber uint8  `json:"episode_number"`
+	EpisodeSlug   string `json:"episode_slug"`
+	SeasonID      uint64 `json:"season_id"`
+	SeasonName    string `json:"season_name"`
+	SeasonSlug    string `json:"season_slug"`
+	Person        Person `json:"person"`
+	Role          string `json:"role"`
+}
@@ -5,9 +5,15 @@ type Episode struct {
 	Name     string `json:"name" db:"name" validate:"required,min=3"`
 	Number   uint8  `json:"number" db:"number" validate:"required,min=1"`
 	Duration string `json:"duration" db:"duration" validate:"required,min=3"`
-	Url      string `json:"url" db:"url" validate:"required,min=10"`
+	Url      string `json:"url" db:"url" validate:"required,http_url,max=255"`
 	Slug     string `json:"slug" db:"slug"`
 	SeasonId uint64 `json:"season_id" db:"season_id" validate:"required,min=1"`
+	// Los campos siguientes sólo los expone v2 a través de sus DTOs; v1
+	// conserva la forma original del episodio.
+	// Rating sólo se carga en el detalle del episodio.
+	Rating *RatingSummary `json:"-"`
+	// Credits sólo se carga en el detalle del episodio.
+	Credits []*Credit `json:"-"`
 }
 
 type EpisodeWithSeasonSlug struct {
@@ -20,6 +26,12 @@ type EpisodeWithSeasonSlug struct {
 	Season   struct {
 		Slug string `json:"slug"`
 	} `json:"season"`
+	// Sources empieza por la fuente principal (Url) seguida de las fuentes
+	// activas por orden de preferencia. Como Rating y Credits, sólo lo
+	// expone v2.
+	Sources []*EpisodeSource `json:"-"`
+	Rating  *RatingSummary   `json:"-"`
+	Credits []*Credit        `json:"-"`
 }
 
 type EpisodeWithImage struct {
@@ -0,0 +1,27 @@
+package entities
+
+const (
+	Quality480p  = "480p"
+	Quality720p  = "720p"
+	Quality1080p = "1080p"
+
+	AudioSub = "sub"
+	AudioDub = "dub"
+	AudioRaw = "raw"
+)
+
+// EpisodeSource es una réplica de un episodio en un host concreto. Primary
+// marca la fuente que se construye a partir de Episode.Url, que no tiene
+// fila propia en episode_sources.
+type EpisodeSource struct {
+	ID        uint64 `json:"id,omitempty"`
+	EpisodeID uint64 `json:"episode_id"`
+	Host      string `json:"host"`
+	Quality   string `json:"quality,omitempty"`
+	Language  string `json:"language,omitempty"`
+	AudioType string `json:"audio_type,omitempty"`
+	Url       string `json:"url"`
+	Priority  int16  `json:"priority"`
+	Active    bool   `json:"active"`
+	Primary   bool   `json:"primary,omitempty"`
+}
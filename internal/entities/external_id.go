@@ -0,0 +1,10 @@
+package entities
+
+const ProviderMyAnimeList = "myanimelist"
+
+// ExternalID enlaza una temporada con su identificador en otro servicio.
+type ExternalID struct {
+	Provider   string `json:"provider"`
+	ExternalID string `json:"external_id"`
+	SeasonID   uint64 `json:"season_id"`
+}
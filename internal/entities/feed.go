@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// PublishedEpisode es un episodio publicado con los datos de su temporada
+// que muestran los feeds.
+type PublishedEpisode struct {
+	ID             uint64    `json:"id"`
+	Name           string    `json:"name"`
+	Number         uint8     `json:"number"`
+	Slug           string    `json:"slug"`
+	Url            string    `json:"url"`
+	PublishedAt    time.Time `json:"published_at"`
+	SeasonID       uint64    `json:"season_id"`
+	SeasonName     string    `json:"season_name"`
+	SeasonSlug     string    `json:"season_slug"`
+	SeasonImageUrl string    `json:"season_image_url"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+const (
+	ImageOwnerSeason  = "season"
+	ImageOwnerEpisode = "episode"
+)
+
+// Image es la imagen subida de una temporada o la miniatura de un episodio.
+// Key es el prefijo de sus objetos en el almacenamiento; Variants asocia el
+// nombre de cada variante ("original", "thumbnail", "card", "banner") con
+// su URL pública.
+type Image struct {
+	ID          uint64            `json:"id"`
+	OwnerType   string            `json:"owner_type"`
+	OwnerID     uint64            `json:"owner_id"`
+	ContentType string            `json:"content_type"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Size        int               `json:"size"`
+	Key         string            `json:"-"`
+	Variants    map[string]string `json:"variants"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
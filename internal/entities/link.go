@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+const (
+	LinkEpisode     = "episode"
+	LinkSource      = "source"
+	LinkSeasonImage = "season_image"
+)
+
+// LinkCheck es el resultado de la última comprobación de una URL guardada.
+// Kind y OwnerID identifican la fila de la que sale: un episodio, una fuente
+// o la imagen de una temporada. Failures cuenta los fallos seguidos.
+type LinkCheck struct {
+	Kind       string    `json:"kind"`
+	OwnerID    uint64    `json:"owner_id"`
+	Url        string    `json:"url"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Broken     bool      `json:"broken"`
+	Failures   int       `json:"failures"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+const (
+	RatingOwnerSeason  = "season"
+	RatingOwnerEpisode = "episode"
+
+	MinScore = 1
+	MaxScore = 10
+)
+
+// Rating es la puntuación de un usuario a una temporada o a un episodio.
+type Rating struct {
+	UserID    uint64    `json:"user_id"`
+	OwnerType string    `json:"owner_type"`
+	OwnerID   uint64    `json:"owner_id"`
+	Score     int       `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RatingSummary agrega las puntuaciones de una temporada o un episodio.
+// Histogram[i] cuenta las puntuaciones i+1.
+type RatingSummary struct {
+	Average   float64 `json:"average"`
+	Count     int     `json:"count"`
+	Histogram []int   `json:"histogram"`
+}
+
+// TopRated es una entrada del ranking de mejor valorados. Score es la media
+// bayesiana, que acerca a la media global las valoraciones con pocos votos.
+type TopRated struct {
+	OwnerType string         `json:"owner_type"`
+	ID        uint64         `json:"id"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	Score     float64        `json:"score"`
+	Rating    *RatingSummary `json:"rating"`
+}
@@ -6,4 +6,15 @@ type Season struct {
 	Number   uint8  `json:"number" db:"number" validate:"required,min=1"`
 	Slug     string `json:"slug" db:"slug"`
 	ImageUrl string `json:"image_url" db:"image_url" validate:"required,min=6"`
+	// Los campos siguientes sólo los expone v2 a través de sus DTOs; v1
+	// conserva la forma original de la temporada.
+	// Rating sólo se carga en el detalle de la temporada.
+	Rating *RatingSummary `json:"-"`
+	Genres []*Term        `json:"-"`
+	Tags   []*Term        `json:"-"`
+	// Studios sólo se carga en el detalle de la temporada.
+	Studios []*SeasonStudio `json:"-"`
+	// NextAiring es la próxima emisión programada; sólo se carga en el
+	// detalle y si la hay.
+	NextAiring *Airing `json:"-"`
 }
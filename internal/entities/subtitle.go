@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+const (
+	SubtitleFormatVTT = "vtt"
+	SubtitleFormatSRT = "srt"
+)
+
+// Subtitle es la pista de subtítulos de un episodio en un idioma. Content
+// siempre es WebVTT; Format es el formato en que se subió.
+type Subtitle struct {
+	ID        uint64    `json:"id"`
+	EpisodeID uint64    `json:"episode_id"`
+	Language  string    `json:"language"`
+	Label     string    `json:"label"`
+	Format    string    `json:"format"`
+	Content   string    `json:"-"`
+	CueCount  int       `json:"cue_count"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
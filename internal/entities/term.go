@@ -0,0 +1,23 @@
+package entities
+
+const (
+	TermGenre = "genre"
+	TermTag   = "tag"
+)
+
+// Term es un género o una etiqueta. Seasons cuenta las temporadas que lo
+// tienen y sólo se rellena en los listados.
+type Term struct {
+	ID      uint64 `json:"id"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Seasons int    `json:"seasons"`
+}
+
+// Facet cuenta las temporadas de un listado que tienen un género.
+type Facet struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,16 @@
+package entities
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+type User struct {
+	ID       uint64 `json:"id" db:"id"`
+	Username string `json:"username" db:"username"`
+	Role     string `json:"role" db:"role"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
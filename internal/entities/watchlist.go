@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+const (
+	WatchWatching    = "watching"
+	WatchPlanToWatch = "plan_to_watch"
+	WatchCompleted   = "completed"
+	WatchOnHold      = "on_hold"
+	WatchDropped     = "dropped"
+)
+
+// WatchStatuses son los estados de una entrada de la lista, en el orden en
+// que se muestran.
+var WatchStatuses = []string{WatchWatching, WatchCompleted, WatchOnHold, WatchDropped, WatchPlanToWatch}
+
+// WatchlistEntry es una temporada en la lista de un usuario. Episodes es el
+// número de episodios que tiene la temporada y Score 0 significa sin
+// puntuar. Las fechas sólo tienen día.
+type WatchlistEntry struct {
+	UserID     uint64     `json:"user_id"`
+	SeasonID   uint64     `json:"season_id"`
+	SeasonName string     `json:"season_name"`
+	SeasonSlug string     `json:"season_slug"`
+	Episodes   int        `json:"episodes"`
+	Status     string     `json:"status"`
+	Score      int        `json:"score"`
+	Progress   int        `json:"progress"`
+	StartedOn  *time.Time `json:"started_on"`
+	FinishedOn *time.Time `json:"finished_on"`
+	Notes      string     `json:"notes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
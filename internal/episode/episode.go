@@ -1,28 +1,36 @@
 package episode
 
 import (
+	"context"
 	"github.com/gofiber/fiber/v2"
 	"github.com/wicho90/anime-api/internal/entities"
 )
 
 type Repository interface {
-	GetAll() ([]*entities.Episode, error)
-	GetLatest() ([]*entities.EpisodeWithImage, error)
-	GetByID(id uint64) (*entities.Episode, error)
-	GetBySlug(slug string) (*entities.EpisodeWithSeasonSlug, error)
-	Create(episode *entities.Episode) error
-	Update(episode *entities.Episode) error
-	Delete(id uint64) error
+	GetAll(ctx context.Context) ([]*entities.Episode, error)
+	GetLatest(ctx context.Context) ([]*entities.EpisodeWithImage, error)
+	GetByID(ctx context.Context, id uint64) (*entities.Episode, error)
+	GetByIDs(ctx context.Context, ids []uint64) ([]*entities.Episode, error)
+	GetBySeasonIDs(ctx context.Context, seasonIDs []uint64) ([]*entities.Episode, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.EpisodeWithSeasonSlug, error)
+	Create(ctx context.Context, episode *entities.Episode) error
+	Update(ctx context.Context, episode *entities.Episode) error
+	Delete(ctx context.Context, id uint64) error
 }
 
 type Service interface {
-	GetAll() ([]*entities.Episode, error)
-	GetLatest() ([]*entities.EpisodeWithImage, error)
-	GetByID(id uint64) (*entities.Episode, error)
-	GetBySlug(slug string) (*entities.EpisodeWithSeasonSlug, error)
-	Create(episode *entities.Episode) error
-	Update(id uint64, episode *entities.Episode) error
-	Delete(id uint64) error
+	GetAll(ctx context.Context) ([]*entities.Episode, error)
+	GetLatest(ctx context.Context) ([]*entities.EpisodeWithImage, error)
+	GetByID(ctx context.Context, id uint64) (*entities.Episode, error)
+	// GetByIDs devuelve los episodios existentes entre ids, sin orden fijo.
+	GetByIDs(ctx context.Context, ids []uint64) ([]*entities.Episode, error)
+	// GetBySeasonIDs devuelve los episodios de varias temporadas ordenados
+	// por temporada y número.
+	GetBySeasonIDs(ctx context.Context, seasonIDs []uint64) ([]*entities.Episode, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.EpisodeWithSeasonSlug, error)
+	Create(ctx context.Context, episode *entities.Episode) error
+	Update(ctx context.Context, id uint64, episode *entities.Episode) error
+	Delete(ctx context.Context, id uint64) error
 }
 
 type Handler interface {
@@ -34,3 +42,8 @@ type Handler interface {
 	Delete(ctx *fiber.Ctx) error
 	GetBySlug(ctx *fiber.Ctx) error
 }
+
+// HandlerV2 sirve las mismas operaciones con las representaciones de la API v2.
+type HandlerV2 interface {
+	Handler
+}
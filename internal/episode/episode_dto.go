@@ -1,6 +1,220 @@
 package episode
 
-type createDTO struct {
-	Name          string `json:"name"  validate:"required,min=5"`
-	EpisodeNumber string `json:"episode_number" validate:"required,min=1"`
+import (
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"strconv"
+	"strings"
+)
+
+// Representaciones de la API v2, independientes de las columnas de la tabla.
+
+type episodeRequest struct {
+	Title           string `json:"title" validate:"required,min=3"`
+	Number          uint8  `json:"number" validate:"required,min=1"`
+	DurationSeconds uint32 `json:"duration_seconds" validate:"required,min=1"`
+	VideoUrl        string `json:"video_url" validate:"required,http_url,max=255"`
+	SeasonID        uint64 `json:"season_id" validate:"required,min=1"`
+}
+
+func (r *episodeRequest) toEntity() *entities.Episode {
+	return &entities.Episode{
+		Name:     r.Title,
+		Number:   r.Number,
+		Duration: fmt.Sprintf("%d seconds", r.DurationSeconds),
+		Url:      r.VideoUrl,
+		SeasonId: r.SeasonID,
+	}
+}
+
+type episodeSeason struct {
+	ID       uint64 `json:"id,omitempty"`
+	Slug     string `json:"slug,omitempty"`
+	ImageUrl string `json:"image_url,omitempty"`
+}
+
+// episodeSource es una fuente de reproducción; la principal no tiene id.
+type episodeSource struct {
+	ID        uint64 `json:"id,omitempty"`
+	Host      string `json:"host"`
+	Quality   string `json:"quality,omitempty"`
+	Language  string `json:"language,omitempty"`
+	AudioType string `json:"audio_type,omitempty"`
+	Url       string `json:"url"`
+	Primary   bool   `json:"primary"`
+}
+
+type episodeResponse struct {
+	ID              uint64        `json:"id"`
+	Slug            string        `json:"slug"`
+	Title           string        `json:"title"`
+	Number          uint8         `json:"number"`
+	DurationSeconds uint32        `json:"duration_seconds"`
+	VideoUrl        string        `json:"video_url"`
+	Season          episodeSeason `json:"season"`
+	// Sources sólo se incluye al buscar por slug.
+	Sources []*episodeSource `json:"sources,omitempty"`
+	// Rating sólo se incluye en el detalle.
+	Rating *entities.RatingSummary `json:"rating,omitempty"`
+	// Credits sólo se incluye en el detalle.
+	Credits []*episodeCredit `json:"credits,omitempty"`
+}
+
+func newEpisodeResponse(episode *entities.Episode) *episodeResponse {
+	return &episodeResponse{
+		ID:              episode.ID,
+		Slug:            episode.Slug,
+		Title:           episode.Name,
+		Number:          episode.Number,
+		DurationSeconds: IntervalSeconds(episode.Duration),
+		VideoUrl:        episode.Url,
+		Season:          episodeSeason{ID: episode.SeasonId},
+		Rating:          episode.Rating,
+		Credits:         newEpisodeCredits(episode.Credits),
+	}
+}
+
+func newEpisodeResponseWithSeasonSlug(episode *entities.EpisodeWithSeasonSlug) *episodeResponse {
+	return &episodeResponse{
+		ID:              episode.ID,
+		Slug:            episode.Slug,
+		Title:           episode.Name,
+		Number:          episode.Number,
+		DurationSeconds: IntervalSeconds(episode.Duration),
+		VideoUrl:        episode.Url,
+		Season:          episodeSeason{Slug: episode.Season.Slug},
+		Sources:         newEpisodeSources(episode.Sources),
+		Rating:          episode.Rating,
+		Credits:         newEpisodeCredits(episode.Credits),
+	}
+}
+
+type episodePerson struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+// episodeCredit es un puesto del equipo del episodio.
+type episodeCredit struct {
+	ID     uint64         `json:"id"`
+	Role   string         `json:"role"`
+	Person *episodePerson `json:"person"`
+}
+
+func newEpisodeCredits(credits []*entities.Credit) []*episodeCredit {
+	if len(credits) == 0 {
+		return nil
+	}
+
+	list := make([]*episodeCredit, 0, len(credits))
+	for _, credit := range credits {
+		list = append(list, &episodeCredit{
+			ID:     credit.ID,
+			Role:   credit.Role,
+			Person: &episodePerson{ID: credit.Person.ID, Name: credit.Person.Name},
+		})
+	}
+
+	return list
+}
+
+func newEpisodeSources(sources []*entities.EpisodeSource) []*episodeSource {
+	list := make([]*episodeSource, 0, len(sources))
+	for _, source := range sources {
+		list = append(list, &episodeSource{
+			ID:        source.ID,
+			Host:      source.Host,
+			Quality:   source.Quality,
+			Language:  source.Language,
+			AudioType: source.AudioType,
+			Url:       source.Url,
+			Primary:   source.Primary,
+		})
+	}
+
+	return list
+}
+
+// episodeSummary es la forma reducida de /episodes/latest.
+type episodeSummary struct {
+	ID     uint64        `json:"id"`
+	Slug   string        `json:"slug"`
+	Title  string        `json:"title"`
+	Season episodeSeason `json:"season"`
+}
+
+type episodeListResponse struct {
+	Data []*episodeResponse `json:"data"`
+}
+
+type episodeSummaryListResponse struct {
+	Data []*episodeSummary `json:"data"`
+}
+
+func newEpisodeListResponse(episodes []*entities.Episode) *episodeListResponse {
+	list := &episodeListResponse{Data: make([]*episodeResponse, 0, len(episodes))}
+	for _, episode := range episodes {
+		list.Data = append(list.Data, newEpisodeResponse(episode))
+	}
+
+	return list
+}
+
+func newEpisodeSummaryListResponse(episodes []*entities.EpisodeWithImage) *episodeSummaryListResponse {
+	list := &episodeSummaryListResponse{Data: make([]*episodeSummary, 0, len(episodes))}
+	for _, episode := range episodes {
+		list.Data = append(list.Data, &episodeSummary{
+			ID:     episode.ID,
+			Slug:   episode.Slug,
+			Title:  episode.Name,
+			Season: episodeSeason{ImageUrl: episode.Season.ImageUrl},
+		})
+	}
+
+	return list
+}
+
+// IntervalSeconds convierte un INTERVAL de Postgres con el formato de salida
+// por defecto ("01:02:03", "1 day 01:02:03") o uno recién creado a partir de
+// episodeRequest ("90 seconds") a segundos. Devuelve 0 si no lo reconoce.
+func IntervalSeconds(interval string) uint32 {
+	var total float64
+	fields := strings.Fields(interval)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			parts := strings.Split(field, ":")
+			if len(parts) != 3 {
+				return 0
+			}
+			h, err1 := strconv.ParseFloat(parts[0], 64)
+			m, err2 := strconv.ParseFloat(parts[1], 64)
+			s, err3 := strconv.ParseFloat(parts[2], 64)
+			if err1 != nil || err2 != nil || err3 != nil {
+				return 0
+			}
+			total += h*3600 + m*60 + s
+			continue
+		}
+
+		n, err := strconv.ParseFloat(field, 64)
+		if err != nil || i+1 >= len(fields) {
+			return 0
+		}
+		i++
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "day":
+			total += n * 86400
+		case "hour":
+			total += n * 3600
+		case "min", "minute":
+			total += n * 60
+		case "sec", "second":
+			total += n
+		default:
+			return 0
+		}
+	}
+
+	return uint32(total)
 }
@@ -22,7 +22,7 @@ func NewHandler(service Service, validator validator.Validator) Handler {
 }
 
 func (h *handler) GetAll(ctx *fiber.Ctx) error {
-	episodes, err := h.service.GetAll()
+	episodes, err := h.service.GetAll(ctx.UserContext())
 	if err != nil {
 		return response.NewInternalServerErrorResponse("Failed to get episodes")
 	}
@@ -31,7 +31,7 @@ func (h *handler) GetAll(ctx *fiber.Ctx) error {
 }
 
 func (h *handler) GetLatest(ctx *fiber.Ctx) error {
-	episodes, err := h.service.GetLatest()
+	episodes, err := h.service.GetLatest(ctx.UserContext())
 
 	if err != nil {
 		log.Println(err)
@@ -48,7 +48,7 @@ func (h *handler) GetById(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse("Invalid id")
 	}
 
-	episode, err := h.service.GetByID(id)
+	episode, err := h.service.GetByID(ctx.UserContext(), id)
 	if err != nil {
 
 		if errors.Is(err, ex.ErrNotFound) {
@@ -63,7 +63,7 @@ func (h *handler) GetById(ctx *fiber.Ctx) error {
 
 func (h *handler) GetBySlug(ctx *fiber.Ctx) error {
 	slug := ctx.Params("slug")
-	episode, err := h.service.GetBySlug(slug)
+	episode, err := h.service.GetBySlug(ctx.UserContext(), slug)
 	if err != nil {
 
 		if errors.Is(err, ex.ErrNotFound) {
@@ -87,9 +87,13 @@ func (h *handler) Create(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse(m)
 	}
 
-	err := h.service.Create(episode)
+	err := h.service.Create(ctx.UserContext(), episode)
 	if err != nil {
 
+		var validation *ex.ErrValidation
+		if errors.As(err, &validation) {
+			return response.NewBadRequestResponse(validation.Error())
+		}
 		if errors.Is(err, ex.ErrNotFound) {
 			return response.NewNotFoundResponse(err.Error())
 		}
@@ -116,8 +120,12 @@ func (h *handler) Update(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse(m)
 	}
 
-	err = h.service.Update(id, episode)
+	err = h.service.Update(ctx.UserContext(), id, episode)
 	if err != nil {
+		var validation *ex.ErrValidation
+		if errors.As(err, &validation) {
+			return response.NewBadRequestResponse(validation.Error())
+		}
 		if errors.Is(err, ex.ErrNotFound) {
 			return response.NewNotFoundResponse(err.Error())
 		}
@@ -135,7 +143,7 @@ func (h *handler) Delete(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse("Invalid id")
 	}
 
-	err = h.service.Delete(id)
+	err = h.service.Delete(ctx.UserContext(), id)
 	if err != nil {
 
 		if errors.Is(err, ex.ErrNotFound) {
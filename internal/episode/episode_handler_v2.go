@@ -0,0 +1,153 @@
+package episode
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type handlerV2 struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandlerV2(service Service, validator validator.Validator) HandlerV2 {
+	return &handlerV2{service: service, validator: validator}
+}
+
+func (h *handlerV2) GetAll(ctx *fiber.Ctx) error {
+	episodes, err := h.service.GetAll(ctx.UserContext())
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get episodes")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEpisodeListResponse(episodes))
+}
+
+func (h *handlerV2) GetLatest(ctx *fiber.Ctx) error {
+	episodes, err := h.service.GetLatest(ctx.UserContext())
+
+	if err != nil {
+		log.Println(err)
+		return response.NewInternalServerErrorResponse("Failed to get episodes")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEpisodeSummaryListResponse(episodes))
+}
+
+func (h *handlerV2) GetById(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	episode, err := h.service.GetByID(ctx.UserContext(), id)
+	if err != nil {
+
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to get episode")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEpisodeResponse(episode))
+}
+
+func (h *handlerV2) GetBySlug(ctx *fiber.Ctx) error {
+	episode, err := h.service.GetBySlug(ctx.UserContext(), ctx.Params("slug"))
+	if err != nil {
+
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to get episode")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEpisodeResponseWithSeasonSlug(episode))
+}
+
+func (h *handlerV2) Create(ctx *fiber.Ctx) error {
+	var request episodeRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	episode := request.toEntity()
+	err := h.service.Create(ctx.UserContext(), episode)
+	if err != nil {
+
+		var validation *ex.ErrValidation
+		if errors.As(err, &validation) {
+			return response.NewBadRequestResponse(validation.Error())
+		}
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to create episode")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newEpisodeResponse(episode))
+}
+
+func (h *handlerV2) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request episodeRequest
+	if err = ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	episode := request.toEntity()
+	err = h.service.Update(ctx.UserContext(), id, episode)
+	if err != nil {
+		var validation *ex.ErrValidation
+		if errors.As(err, &validation) {
+			return response.NewBadRequestResponse(validation.Error())
+		}
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to update episode")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEpisodeResponse(episode))
+}
+
+func (h *handlerV2) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	err = h.service.Delete(ctx.UserContext(), id)
+	if err != nil {
+
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to delete episode")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+package episode
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+var example = entities.Episode{
+	ID:       1,
+	Name:     "to you, in 2000 years",
+	Number:   1,
+	Duration: "00:24:00",
+	Url:      "https://video.example.com/snk/1",
+	Slug:     "shingeki-no-kyojin-1",
+	SeasonId: 1,
+}
+
+// OpenAPI documenta las rutas de /api/v1/episodes, obsoletas, y las de
+// /api/v2/episodes.
+func OpenAPI(doc *openapi.Document) {
+	openAPIV2(doc)
+
+	schema := doc.Register("Episode", entities.Episode{})
+	withSeason := doc.Register("EpisodeWithSeasonSlug", entities.EpisodeWithSeasonSlug{})
+	withImage := doc.Register("EpisodeWithImage", entities.EpisodeWithImage{})
+
+	input := map[string]any{
+		"name": example.Name, "number": example.Number, "duration": example.Duration,
+		"url": example.Url, "season_id": example.SeasonId,
+	}
+	bySlug := entities.EpisodeWithSeasonSlug{
+		ID: example.ID, Name: example.Name, Number: example.Number,
+		Duration: example.Duration, Url: example.Url, Slug: example.Slug,
+	}
+	bySlug.Season.Slug = "shingeki-no-kyojin"
+	latest := entities.EpisodeWithImage{ID: example.ID, Name: example.Name, Slug: example.Slug}
+	latest.Season.ImageUrl = "https://cdn.example.com/seasons/shingeki-no-kyojin.jpg"
+
+	doc.Add(http.MethodGet, "/api/v1/episodes", &openapi.Operation{
+		OperationID: "listEpisodes",
+		Summary:     "List episodes",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episodes", openapi.ArrayOf(schema), []entities.Episode{example}),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episodes"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v1/episodes/latest", &openapi.Operation{
+		OperationID: "listLatestEpisodes",
+		Summary:     "List the latest episodes with their season artwork",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episodes", openapi.ArrayOf(withImage), []entities.EpisodeWithImage{latest}),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episodes"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v1/episodes/:id", &openapi.Operation{
+		OperationID: "getEpisode",
+		Summary:     "Get an episode by id",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episode", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episode"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v1/episodes/slug/:slug", &openapi.Operation{
+		OperationID: "getEpisodeBySlug",
+		Summary:     "Get an episode by slug",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		Parameters: []*openapi.Parameter{
+			openapi.PathParam("slug", "Episode slug", &openapi.Schema{Type: "string"}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episode", withSeason, bySlug),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with slug x not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episode"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v1/episodes", &openapi.Operation{
+		OperationID: "createEpisode",
+		Summary:     "Create an episode",
+		Description: "The slug is derived from the season slug and the episode number. The url host must be listed in links.allowed_hosts, when set.",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		RequestBody: openapi.JSONBody(schema, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created episode", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Name is required"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create episode"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v1/episodes/:id", &openapi.Operation{
+		OperationID: "updateEpisode",
+		Summary:     "Replace an episode",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(schema, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Updated episode", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid request body"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update episode"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v1/episodes/:id", &openapi.Operation{
+		OperationID: "deleteEpisode",
+		Summary:     "Delete an episode",
+		Tags:        []string{"episodes"},
+		Deprecated:  true,
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete episode"),
+		}),
+	})
+}
@@ -0,0 +1,139 @@
+package episode
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"github.com/wicho90/anime-api/internal/rating"
+	"net/http"
+)
+
+// openAPIV2 documenta las rutas de /api/v2/episodes.
+func openAPIV2(doc *openapi.Document) {
+	schema := doc.Register("EpisodeV2", episodeResponse{})
+	list := doc.Register("EpisodeListV2", episodeListResponse{})
+	summaries := doc.Register("EpisodeSummaryListV2", episodeSummaryListResponse{})
+	request := doc.Register("EpisodeRequestV2", episodeRequest{})
+
+	v2 := newEpisodeResponse(&example)
+	detail := *v2
+	detail.Rating = rating.ExampleSummary
+	detail.Credits = []*episodeCredit{
+		{ID: 1, Role: entities.CreditDirector, Person: &episodePerson{ID: 2, Name: "Tetsurō Araki"}},
+		{ID: 2, Role: entities.CreditScript, Person: &episodePerson{ID: 3, Name: "Yasuko Kobayashi"}},
+	}
+	bySlug := detail
+	bySlug.Season = episodeSeason{Slug: "shingeki-no-kyojin"}
+	bySlug.Sources = []*episodeSource{
+		{Host: "video.example.com", Url: example.Url, Primary: true},
+		{
+			ID: 2, Host: "mirror.example.com", Quality: "1080p", Language: "ja", AudioType: "sub",
+			Url: "https://mirror.example.com/snk/1/1080p",
+		},
+	}
+	latest := &episodeSummary{
+		ID: example.ID, Slug: example.Slug, Title: example.Name,
+		Season: episodeSeason{ImageUrl: "https://cdn.example.com/seasons/shingeki-no-kyojin.jpg"},
+	}
+	input := episodeRequest{
+		Title: example.Name, Number: example.Number, DurationSeconds: v2.DurationSeconds,
+		VideoUrl: example.Url, SeasonID: example.SeasonId,
+	}
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	doc.Add(http.MethodGet, "/api/v2/episodes", &openapi.Operation{
+		OperationID: "listEpisodesV2",
+		Summary:     "List episodes",
+		Tags:        []string{"episodes"},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episodes", list, episodeListResponse{Data: []*episodeResponse{v2}}),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episodes"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/episodes/latest", &openapi.Operation{
+		OperationID: "listLatestEpisodesV2",
+		Summary:     "List the latest episodes with their season artwork",
+		Tags:        []string{"episodes"},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episodes", summaries, episodeSummaryListResponse{Data: []*episodeSummary{latest}}),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episodes"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/episodes/:id", &openapi.Operation{
+		OperationID: "getEpisodeV2",
+		Summary:     "Get an episode by id",
+		Description: "Includes the aggregated rating and the staff credits of the episode.",
+		Tags:        []string{"episodes"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episode", schema, detail),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episode"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/episodes/slug/:slug", &openapi.Operation{
+		OperationID: "getEpisodeBySlugV2",
+		Summary:     "Get an episode by slug",
+		Description: "Sources are ranked; the video url is always the first, primary source. Includes the aggregated rating and the staff credits of the episode.",
+		Tags:        []string{"episodes"},
+		Parameters: []*openapi.Parameter{
+			openapi.PathParam("slug", "Episode slug", &openapi.Schema{Type: "string"}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Episode", schema, bySlug),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with slug x not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get episode"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/episodes", &openapi.Operation{
+		OperationID: "createEpisodeV2",
+		Summary:     "Create an episode",
+		Description: "The slug is derived from the season slug and the episode number. The video_url host must be listed in links.allowed_hosts, when set. Requires an administrator token.",
+		Tags:        []string{"episodes"},
+		Security:    openapi.Authenticated(),
+		RequestBody: openapi.JSONBody(request, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created episode", schema, v2),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Title is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create episode"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/episodes/:id", &openapi.Operation{
+		OperationID: "updateEpisodeV2",
+		Summary:     "Replace an episode",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"episodes"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Updated episode", schema, v2),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid request body"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update episode"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/episodes/:id", &openapi.Operation{
+		OperationID: "deleteEpisodeV2",
+		Summary:     "Delete an episode",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"episodes"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete episode"),
+		}),
+	})
+}
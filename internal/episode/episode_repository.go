@@ -1,35 +1,46 @@
 package episode
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
 	"github.com/wicho90/anime-api/internal/entities"
 	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
 	"log"
 )
 
 const (
-	queryGetAll     = "SELECT id, name, number, duration, url, slug, season_id FROM episodes"
-	queryGetLatest  = "SELECT e.id, e.name, e.slug, s.image_url FROM episodes as e INNER JOIN seasons as s ON  season_id = s.id"
-	queryGetById    = "SELECT id, name, number, duration, url, slug, season_id FROM episodes WHERE id = $1"
-	queryCreate     = "INSERT INTO episodes (name, number, duration, url, slug, season_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
-	queryUpdate     = "UPDATE episodes SET name = $1, number = $2, duration = $3, url = $4, slug = $5, season_id = $6 WHERE id = $7"
-	queryDeleteById = "DELETE FROM episodes WHERE id = $1"
-	queryGetBySlug  = "SELECT e.id, e.name, e.number, e.duration, e.url, e.slug, s.slug FROM episodes as e INNER JOIN seasons as s ON  season_id = s.id WHERE e.slug= $1"
+	queryGetAll         = "SELECT id, name, number, duration, url, slug, season_id FROM episodes"
+	queryGetLatest      = "SELECT e.id, e.name, e.slug, s.image_url FROM episodes as e INNER JOIN seasons as s ON  season_id = s.id"
+	queryGetById        = "SELECT id, name, number, duration, url, slug, season_id FROM episodes WHERE id = $1"
+	queryGetByIds       = "SELECT id, name, number, duration, url, slug, season_id FROM episodes WHERE id = ANY($1)"
+	queryGetBySeasonIds = "SELECT id, name, number, duration, url, slug, season_id FROM episodes WHERE season_id = ANY($1) ORDER BY season_id, number"
+	queryCreate         = "INSERT INTO episodes (name, number, duration, url, slug, season_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+	queryUpdate         = "UPDATE episodes SET name = $1, number = $2, duration = $3, url = $4, slug = $5, season_id = $6 WHERE id = $7"
+	queryDeleteById     = "DELETE FROM episodes WHERE id = $1"
+	queryGetBySlug      = "SELECT e.id, e.name, e.number, e.duration, e.url, e.slug, s.slug FROM episodes as e INNER JOIN seasons as s ON  season_id = s.id WHERE e.slug= $1"
 )
 
 type repository struct {
-	db *sql.DB
+	db      *sql.DB
+	replica *database.Replica
 }
 
-func NewRepository(db *sql.DB) Repository {
-	return &repository{db: db}
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
 }
 
-func (r *repository) GetAll() ([]*entities.Episode, error) {
-	rows, err := r.db.Query(queryGetAll)
+func (r *repository) GetAll(ctx context.Context) ([]*entities.Episode, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetAll", queryGetAll)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetAll)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer func(rows *sql.Rows) {
@@ -44,21 +55,27 @@ func (r *repository) GetAll() ([]*entities.Episode, error) {
 		episode := &entities.Episode{}
 		if err := rows.Scan(&episode.ID, &episode.Name, &episode.Number,
 			&episode.Duration, &episode.Url, &episode.Slug, &episode.SeasonId); err != nil {
+			telemetry.RecordError(span, err)
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		episodes = append(episodes, episode)
 	}
 
 	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
 
 	return episodes, nil
 }
 
-func (r *repository) GetLatest() ([]*entities.EpisodeWithImage, error) {
-	rows, err := r.db.Query(queryGetLatest)
+func (r *repository) GetLatest(ctx context.Context) ([]*entities.EpisodeWithImage, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetLatest", queryGetLatest)
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, queryGetLatest)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer func(rows *sql.Rows) {
@@ -73,53 +90,115 @@ func (r *repository) GetLatest() ([]*entities.EpisodeWithImage, error) {
 		episode := &entities.EpisodeWithImage{}
 		if err := rows.Scan(&episode.ID, &episode.Name, &episode.Slug,
 			&episode.Season.ImageUrl); err != nil {
+			telemetry.RecordError(span, err)
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		episodes = append(episodes, episode)
 	}
 
 	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
 
 	return episodes, nil
 }
 
-func (r *repository) GetByID(id uint64) (*entities.Episode, error) {
+func (r *repository) GetByID(ctx context.Context, id uint64) (*entities.Episode, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetById", queryGetById)
+	defer span.End()
+
 	episode := &entities.Episode{}
 
-	err := r.db.QueryRow(queryGetById, id).Scan(&episode.ID, &episode.Name,
+	err := r.db.QueryRowContext(ctx, queryGetById, id).Scan(&episode.ID, &episode.Name,
 		&episode.Number, &episode.Duration, &episode.Url, &episode.Slug, &episode.SeasonId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("episode with id %d %v", id, ex.ErrNotFound)
 			return nil, fmt.Errorf("episode with id %d %w", id, ex.ErrNotFound)
 		}
+		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	return episode, nil
 }
 
-func (r *repository) GetBySlug(slug string) (*entities.EpisodeWithSeasonSlug, error) {
+func (r *repository) GetByIDs(ctx context.Context, ids []uint64) ([]*entities.Episode, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByIds", queryGetByIds)
+	defer span.End()
+
+	return r.queryEpisodes(ctx, span, queryGetByIds, pq.Array(ids))
+}
+
+func (r *repository) GetBySeasonIDs(ctx context.Context, seasonIDs []uint64) ([]*entities.Episode, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBySeasonIds", queryGetBySeasonIds)
+	defer span.End()
+
+	return r.queryEpisodes(ctx, span, queryGetBySeasonIds, pq.Array(seasonIDs))
+}
+
+// queryEpisodes ejecuta en la réplica una consulta que devuelve las columnas
+// de entities.Episode.
+func (r *repository) queryEpisodes(ctx context.Context, span trace.Span, query string, args ...any) ([]*entities.Episode, error) {
+	rows, err := r.replica.QueryContext(ctx, query, args...)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	var episodes []*entities.Episode
+	for rows.Next() {
+		episode := &entities.Episode{}
+		if err := rows.Scan(&episode.ID, &episode.Name, &episode.Number,
+			&episode.Duration, &episode.Url, &episode.Slug, &episode.SeasonId); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return episodes, nil
+}
+
+func (r *repository) GetBySlug(ctx context.Context, slug string) (*entities.EpisodeWithSeasonSlug, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBySlug", queryGetBySlug)
+	defer span.End()
+
 	episode := &entities.EpisodeWithSeasonSlug{}
 
-	err := r.db.QueryRow(queryGetBySlug, slug).Scan(&episode.ID, &episode.Name,
+	err := r.replica.QueryRowContext(ctx, queryGetBySlug, slug).Scan(&episode.ID, &episode.Name,
 		&episode.Number, &episode.Duration, &episode.Url, &episode.Slug, &episode.Season.Slug)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("episode with slug %s %w", slug, ex.ErrNotFound)
 		}
+		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	return episode, nil
 }
 
-func (r *repository) Create(episode *entities.Episode) error {
-	err := r.db.QueryRow(queryCreate, episode.Name, episode.Number,
+func (r *repository) Create(ctx context.Context, episode *entities.Episode) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", queryCreate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreate, episode.Name, episode.Number,
 		episode.Duration, episode.Url, episode.Slug, episode.SeasonId).Scan(&episode.ID)
 	if err != nil {
+		telemetry.RecordError(span, err)
 
 		if pgErr, ok := err.(*pq.Error); ok {
 			switch pgErr.Code {
@@ -145,10 +224,14 @@ func (r *repository) Create(episode *entities.Episode) error {
 	return nil
 }
 
-func (r *repository) Update(episode *entities.Episode) error {
-	result, err := r.db.Exec(queryUpdate, episode.Name, episode.Number, episode.Duration, episode.Url, episode.Slug, episode.SeasonId, episode.ID)
+func (r *repository) Update(ctx context.Context, episode *entities.Episode) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdate", queryUpdate)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryUpdate, episode.Name, episode.Number, episode.Duration, episode.Url, episode.Slug, episode.SeasonId, episode.ID)
 
 	if err != nil {
+		telemetry.RecordError(span, err)
 
 		if pgErr, ok := err.(*pq.Error); ok {
 
@@ -185,9 +268,13 @@ func (r *repository) Update(episode *entities.Episode) error {
 	return nil
 }
 
-func (r *repository) Delete(id uint64) error {
-	result, err := r.db.Exec(queryDeleteById, id)
+func (r *repository) Delete(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteById", queryDeleteById)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteById, id)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return err
 	}
 	rowsAffected, err := result.RowsAffected()
@@ -1,27 +1,47 @@
 package episode
 
 import (
+	"context"
 	"fmt"
+	"github.com/wicho90/anime-api/internal/credit"
 	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/links"
+	"github.com/wicho90/anime-api/internal/rating"
 	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/source"
+	"go.opentelemetry.io/otel"
 	"strconv"
 	"strings"
 )
 
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/episode")
+
 type service struct {
 	repository       Repository
 	seasonRepository season.Repository
+	sourceRepository source.Repository
+	ratingRepository rating.Repository
+	creditRepository credit.Repository
+	policy           *links.Policy
 }
 
-func NewService(repository Repository, seasonRepository season.Repository) Service {
+func NewService(repository Repository, seasonRepository season.Repository, sourceRepository source.Repository,
+	ratingRepository rating.Repository, creditRepository credit.Repository, policy *links.Policy) Service {
 	return &service{
 		repository:       repository,
 		seasonRepository: seasonRepository,
+		sourceRepository: sourceRepository,
+		ratingRepository: ratingRepository,
+		creditRepository: creditRepository,
+		policy:           policy,
 	}
 }
 
-func (s *service) GetAll() ([]*entities.Episode, error) {
-	episodes, err := s.repository.GetAll()
+func (s *service) GetAll(ctx context.Context) ([]*entities.Episode, error) {
+	ctx, span := tracer.Start(ctx, "episode.Service.GetAll")
+	defer span.End()
+
+	episodes, err := s.repository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -29,8 +49,11 @@ func (s *service) GetAll() ([]*entities.Episode, error) {
 	return episodes, nil
 }
 
-func (s *service) GetLatest() ([]*entities.EpisodeWithImage, error) {
-	episodes, err := s.repository.GetLatest()
+func (s *service) GetLatest(ctx context.Context) ([]*entities.EpisodeWithImage, error) {
+	ctx, span := tracer.Start(ctx, "episode.Service.GetLatest")
+	defer span.End()
+
+	episodes, err := s.repository.GetLatest(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,17 +61,73 @@ func (s *service) GetLatest() ([]*entities.EpisodeWithImage, error) {
 	return episodes, nil
 }
 
-func (s *service) GetByID(id uint64) (*entities.Episode, error) {
-	episode, err := s.repository.GetByID(id)
+func (s *service) GetByID(ctx context.Context, id uint64) (*entities.Episode, error) {
+	ctx, span := tracer.Start(ctx, "episode.Service.GetByID")
+	defer span.End()
+
+	episode, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	episode.Rating, err = s.ratingRepository.GetSummary(ctx, entities.RatingOwnerEpisode, episode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	episode.Credits, err = s.getCredits(ctx, episode.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return episode, err
+	return episode, nil
 }
 
-func (s *service) GetBySlug(slug string) (*entities.EpisodeWithSeasonSlug, error) {
-	episode, err := s.repository.GetBySlug(slug)
+// getCredits devuelve los créditos del equipo del episodio.
+func (s *service) getCredits(ctx context.Context, id uint64) ([]*entities.Credit, error) {
+	credits, err := s.creditRepository.GetByEpisodeIDs(ctx, []uint64{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return credits[id], nil
+}
+
+func (s *service) GetByIDs(ctx context.Context, ids []uint64) ([]*entities.Episode, error) {
+	ctx, span := tracer.Start(ctx, "episode.Service.GetByIDs")
+	defer span.End()
+
+	return s.repository.GetByIDs(ctx, ids)
+}
+
+func (s *service) GetBySeasonIDs(ctx context.Context, seasonIDs []uint64) ([]*entities.Episode, error) {
+	ctx, span := tracer.Start(ctx, "episode.Service.GetBySeasonIDs")
+	defer span.End()
+
+	return s.repository.GetBySeasonIDs(ctx, seasonIDs)
+}
+
+func (s *service) GetBySlug(ctx context.Context, slug string) (*entities.EpisodeWithSeasonSlug, error) {
+	ctx, span := tracer.Start(ctx, "episode.Service.GetBySlug")
+	defer span.End()
+
+	episode, err := s.repository.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := s.sourceRepository.GetByEpisodeID(ctx, episode.ID, source.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	episode.Sources = source.WithPrimary(episode.ID, episode.Url, sources)
+
+	episode.Rating, err = s.ratingRepository.GetSummary(ctx, entities.RatingOwnerEpisode, episode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	episode.Credits, err = s.getCredits(ctx, episode.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +135,15 @@ func (s *service) GetBySlug(slug string) (*entities.EpisodeWithSeasonSlug, error
 	return episode, nil
 }
 
-func (s *service) Create(episode *entities.Episode) error {
-	seasonFound, err := s.seasonRepository.GetById(episode.SeasonId)
+func (s *service) Create(ctx context.Context, episode *entities.Episode) error {
+	ctx, span := tracer.Start(ctx, "episode.Service.Create")
+	defer span.End()
+
+	if err := s.policy.Check("url", episode.Url); err != nil {
+		return err
+	}
+
+	seasonFound, err := s.seasonRepository.GetById(ctx, episode.SeasonId)
 	if err != nil {
 		return err
 	}
@@ -65,7 +151,7 @@ func (s *service) Create(episode *entities.Episode) error {
 	episode.Name = strings.TrimSpace(strings.ToLower(episode.Name))
 	episode.Slug = seasonFound.Slug + "-" + strconv.Itoa(int(episode.Number))
 
-	err = s.repository.Create(episode)
+	err = s.repository.Create(ctx, episode)
 	if err != nil {
 		return fmt.Errorf("failed to create episode: %w", err)
 	}
@@ -73,14 +159,21 @@ func (s *service) Create(episode *entities.Episode) error {
 	return nil
 }
 
-func (s *service) Update(id uint64, episode *entities.Episode) error {
+func (s *service) Update(ctx context.Context, id uint64, episode *entities.Episode) error {
+	ctx, span := tracer.Start(ctx, "episode.Service.Update")
+	defer span.End()
+
+	if err := s.policy.Check("url", episode.Url); err != nil {
+		return err
+	}
+
 	// TODO hacer transacción
-	_, err := s.repository.GetByID(id)
+	_, err := s.repository.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	seasonFound, err := s.seasonRepository.GetById(episode.SeasonId)
+	seasonFound, err := s.seasonRepository.GetById(ctx, episode.SeasonId)
 	if err != nil {
 		return err
 	}
@@ -89,7 +182,7 @@ func (s *service) Update(id uint64, episode *entities.Episode) error {
 	episode.Name = strings.TrimSpace(strings.ToLower(episode.Name))
 	episode.Slug = seasonFound.Slug + "-" + strconv.Itoa(int(episode.Number))
 
-	err = s.repository.Update(episode)
+	err = s.repository.Update(ctx, episode)
 	if err != nil {
 		return err
 	}
@@ -97,8 +190,11 @@ func (s *service) Update(id uint64, episode *entities.Episode) error {
 	return nil
 }
 
-func (s *service) Delete(id uint64) error {
-	if err := s.repository.Delete(id); err != nil {
+func (s *service) Delete(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "episode.Service.Delete")
+	defer span.End()
+
+	if err := s.repository.Delete(ctx, id); err != nil {
 		return err
 	}
 
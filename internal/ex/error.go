@@ -7,6 +7,8 @@ import (
 
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrForbidden indica que el usuario no puede modificar el recurso.
+	ErrForbidden = errors.New("forbidden")
 )
 
 type AlreadyExistError struct {
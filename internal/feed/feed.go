@@ -0,0 +1,42 @@
+// Package feed publica los episodios nuevos como feeds RSS 2.0 y Atom.
+package feed
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+const (
+	mimeRSS  = "application/rss+xml"
+	mimeAtom = "application/atom+xml"
+)
+
+// Feed son los últimos episodios publicados, del más reciente al más
+// antiguo. Season sólo se indica en el feed de una temporada; Updated es la
+// publicación más reciente, o cero si no hay episodios.
+type Feed struct {
+	Season   *entities.Season
+	Episodes []*entities.PublishedEpisode
+	Updated  time.Time
+}
+
+type Repository interface {
+	GetLatest(ctx context.Context, limit int) ([]*entities.PublishedEpisode, error)
+	GetLatestBySeasonID(ctx context.Context, seasonID uint64, limit int) ([]*entities.PublishedEpisode, error)
+	// GetSeasonBySlug devuelve la primera temporada con el slug.
+	GetSeasonBySlug(ctx context.Context, slug string) (*entities.Season, error)
+}
+
+type Service interface {
+	GetLatest(ctx context.Context) (*Feed, error)
+	GetBySeasonSlug(ctx context.Context, slug string) (*Feed, error)
+}
+
+type Handler interface {
+	GetLatestRSS(ctx *fiber.Ctx) error
+	GetLatestAtom(ctx *fiber.Ctx) error
+	GetSeasonRSS(ctx *fiber.Ctx) error
+	GetSeasonAtom(ctx *fiber.Ctx) error
+}
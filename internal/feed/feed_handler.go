@@ -0,0 +1,109 @@
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) Handler {
+	return &handler{service: service}
+}
+
+// encoder genera el cuerpo de un feed en un formato.
+type encoder func(feed *Feed, l links) ([]byte, error)
+
+func (h *handler) GetLatestRSS(ctx *fiber.Ctx) error {
+	return h.getLatest(ctx, encodeRSS, mimeRSS)
+}
+
+func (h *handler) GetLatestAtom(ctx *fiber.Ctx) error {
+	return h.getLatest(ctx, encodeAtom, mimeAtom)
+}
+
+func (h *handler) GetSeasonRSS(ctx *fiber.Ctx) error {
+	return h.getSeason(ctx, encodeRSS, mimeRSS)
+}
+
+func (h *handler) GetSeasonAtom(ctx *fiber.Ctx) error {
+	return h.getSeason(ctx, encodeAtom, mimeAtom)
+}
+
+func (h *handler) getLatest(ctx *fiber.Ctx, encode encoder, contentType string) error {
+	feed, err := h.service.GetLatest(ctx.UserContext())
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get feed")
+	}
+
+	return send(ctx, feed, encode, contentType)
+}
+
+func (h *handler) getSeason(ctx *fiber.Ctx, encode encoder, contentType string) error {
+	feed, err := h.service.GetBySeasonSlug(ctx.UserContext(), ctx.Params("slug"))
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to get feed")
+	}
+
+	return send(ctx, feed, encode, contentType)
+}
+
+// send responde con el feed o con 304 si el cliente ya tiene esta versión.
+// El ETag se calcula sobre el cuerpo, así que también cambia cuando se
+// edita o se borra un episodio; Last-Modified es la publicación más
+// reciente.
+func send(ctx *fiber.Ctx, feed *Feed, encode encoder, contentType string) error {
+	body, err := encode(feed, links{baseURL: ctx.BaseURL(), self: ctx.BaseURL() + ctx.Path()})
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get feed")
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	ctx.Set(fiber.HeaderETag, etag)
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	if !feed.Updated.IsZero() {
+		ctx.Set(fiber.HeaderLastModified, feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(ctx, etag, feed.Updated) {
+		return ctx.SendStatus(http.StatusNotModified)
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType+"; charset=utf-8")
+
+	return ctx.Status(http.StatusOK).Send(body)
+}
+
+// notModified evalúa las condiciones de la petición como indica RFC 9110:
+// If-None-Match, si está, tiene prioridad sobre If-Modified-Since.
+func notModified(ctx *fiber.Ctx, etag string, updated time.Time) bool {
+	if header := ctx.Get(fiber.HeaderIfNoneMatch); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := ctx.Get(fiber.HeaderIfModifiedSince); header != "" && !updated.IsZero() {
+		since, err := http.ParseTime(header)
+		return err == nil && !updated.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
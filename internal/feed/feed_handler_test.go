@@ -0,0 +1,141 @@
+package feed
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeService struct {
+	feed *Feed
+}
+
+func (s *fakeService) GetLatest(context.Context) (*Feed, error) {
+	return s.feed, nil
+}
+
+func (s *fakeService) GetBySeasonSlug(context.Context, string) (*Feed, error) {
+	return s.feed, nil
+}
+
+func newTestApp(feed *Feed) *fiber.App {
+	h := NewHandler(&fakeService{feed: feed})
+	app := fiber.New()
+	app.Get("/feed.rss", h.GetLatestRSS)
+	app.Get("/feed.atom", h.GetLatestAtom)
+	return app
+}
+
+func request(t *testing.T, app *fiber.App, path string, headers map[string]string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestSendHeaders(t *testing.T) {
+	app := newTestApp(newTestFeed())
+
+	for _, tt := range []struct {
+		path, contentType string
+	}{
+		{"/feed.rss", "application/rss+xml; charset=utf-8"},
+		{"/feed.atom", "application/atom+xml; charset=utf-8"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			res := request(t, app, tt.path, nil)
+			body, _ := io.ReadAll(res.Body)
+
+			if res.StatusCode != http.StatusOK || len(body) == 0 {
+				t.Fatalf("got %d with %d bytes", res.StatusCode, len(body))
+			}
+			if got := res.Header.Get(fiber.HeaderContentType); got != tt.contentType {
+				t.Errorf("got Content-Type %q, want %q", got, tt.contentType)
+			}
+			if got := res.Header.Get(fiber.HeaderLastModified); got != "Mon, 12 Oct 2026 06:00:00 GMT" {
+				t.Errorf("got Last-Modified %q", got)
+			}
+			if etag := res.Header.Get(fiber.HeaderETag); len(etag) != 34 || etag[0] != '"' {
+				t.Errorf("got ETag %q, want 32 quoted hex digits", etag)
+			}
+		})
+	}
+}
+
+func TestSendETag(t *testing.T) {
+	feed := newTestFeed()
+	first := request(t, newTestApp(feed), "/feed.rss", nil).Header.Get(fiber.HeaderETag)
+	again := request(t, newTestApp(newTestFeed()), "/feed.rss", nil).Header.Get(fiber.HeaderETag)
+	atom := request(t, newTestApp(feed), "/feed.atom", nil).Header.Get(fiber.HeaderETag)
+
+	edited := newTestFeed()
+	edited.Episodes[1].Name = "to you, 2000 years from now"
+	changed := request(t, newTestApp(edited), "/feed.rss", nil).Header.Get(fiber.HeaderETag)
+
+	if first != again {
+		t.Errorf("ETag changed between identical feeds: %s, %s", first, again)
+	}
+	if first == atom {
+		t.Error("RSS and Atom share the ETag")
+	}
+	if first == changed {
+		t.Error("ETag did not change after editing an older episode")
+	}
+}
+
+func TestSendConditional(t *testing.T) {
+	app := newTestApp(newTestFeed())
+	etag := request(t, app, "/feed.rss", nil).Header.Get(fiber.HeaderETag)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"no conditions", nil, http.StatusOK},
+		{"matching etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"weak etag", map[string]string{"If-None-Match": "W/" + etag}, http.StatusNotModified},
+		{"etag in a list", map[string]string{"If-None-Match": `"other", ` + etag}, http.StatusNotModified},
+		{"any etag", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"other etag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": "Mon, 12 Oct 2026 06:00:00 GMT"}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": "Mon, 12 Oct 2026 05:59:59 GMT"}, http.StatusOK},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+		{"etag takes precedence", map[string]string{
+			"If-None-Match": `"other"`, "If-Modified-Since": "Mon, 12 Oct 2026 06:00:00 GMT",
+		}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := request(t, app, "/feed.rss", tt.headers)
+			if res.StatusCode != tt.want {
+				t.Errorf("got %d, want %d", res.StatusCode, tt.want)
+			}
+			if res.Header.Get(fiber.HeaderETag) != etag {
+				t.Errorf("got ETag %q, want %q", res.Header.Get(fiber.HeaderETag), etag)
+			}
+		})
+	}
+}
+
+func TestSendEmptyFeed(t *testing.T) {
+	app := newTestApp(newFeed(nil, nil))
+
+	res := request(t, app, "/feed.atom", map[string]string{"If-Modified-Since": "Mon, 12 Oct 2026 06:00:00 GMT"})
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("got %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if got := res.Header.Get(fiber.HeaderLastModified); got != "" {
+		t.Errorf("got Last-Modified %q on an empty feed", got)
+	}
+}
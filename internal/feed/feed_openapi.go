@@ -0,0 +1,68 @@
+package feed
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+// OpenAPI documenta los feeds RSS y Atom de episodios nuevos.
+func OpenAPI(doc *openapi.Document) {
+	season := &entities.Season{ID: 1, Name: "shingeki no kyojin", Number: 1, Slug: "shingeki-no-kyojin"}
+	example := newFeed(season, []*entities.PublishedEpisode{{
+		ID: 13, Name: "the last day", Number: 13, Slug: "shingeki-no-kyojin-13",
+		Url:         "https://videos.example.com/shingeki-no-kyojin-13.mp4",
+		PublishedAt: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC),
+		SeasonID:    1, SeasonName: season.Name, SeasonSlug: season.Slug,
+		SeasonImageUrl: "https://cdn.example.com/seasons/shingeki-no-kyojin.jpg",
+	}})
+	l := links{baseURL: "https://anime.example.com", self: "https://anime.example.com/api/v2/seasons/slug/shingeki-no-kyojin/feed.rss"}
+	exampleRSS, _ := encodeRSS(example, l)
+	l.self = "https://anime.example.com/api/v2/seasons/slug/shingeki-no-kyojin/feed.atom"
+	exampleAtom, _ := encodeAtom(example, l)
+
+	feed := func(contentType string, body []byte) *openapi.Response {
+		return &openapi.Response{
+			Description: "Feed",
+			Content:     map[string]*openapi.MediaType{contentType: {Schema: &openapi.Schema{Type: "string"}, Example: string(body)}},
+		}
+	}
+	notModified := openapi.NoContent("The feed has not changed since the version in If-None-Match or If-Modified-Since")
+	description := "Newest episodes first; the number of episodes is set by feeds.items. Each item links to the episode and " +
+		"carries the season image as enclosure. Responses include ETag and Last-Modified for conditional requests."
+	slug := openapi.PathParam("slug", "Season slug", &openapi.Schema{Type: "string"})
+
+	for _, format := range []struct {
+		id, name, extension, contentType string
+		body                             []byte
+	}{
+		{"RSS", "RSS 2.0", "rss", mimeRSS, exampleRSS},
+		{"Atom", "Atom", "atom", mimeAtom, exampleAtom},
+	} {
+		doc.Add(http.MethodGet, "/api/v2/episodes/feed."+format.extension, &openapi.Operation{
+			OperationID: "getEpisodesFeed" + format.id + "V2",
+			Summary:     "Get the " + format.name + " feed of new episodes",
+			Description: description,
+			Tags:        []string{"feeds"},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  feed(format.contentType, format.body),
+				http.StatusNotModified:         notModified,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get feed"),
+			}),
+		})
+		doc.Add(http.MethodGet, "/api/v2/seasons/slug/:slug/feed."+format.extension, &openapi.Operation{
+			OperationID: "getSeasonFeed" + format.id + "V2",
+			Summary:     "Get the " + format.name + " feed of new episodes of a season",
+			Description: description,
+			Tags:        []string{"feeds"},
+			Parameters:  []*openapi.Parameter{slug},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  feed(format.contentType, format.body),
+				http.StatusNotModified:         notModified,
+				http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with slug shingeki-no-kyojin not found"),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get feed"),
+			}),
+		})
+	}
+}
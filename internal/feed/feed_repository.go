@@ -0,0 +1,92 @@
+package feed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+)
+
+const (
+	querySelectPublished = `SELECT e.id, e.name, e.number, e.slug, e.url, e.published_at, s.id, s.name, s.slug, s.image_url
+		FROM episodes e
+		JOIN seasons s ON s.id = e.season_id`
+	queryGetLatest = querySelectPublished + `
+		ORDER BY e.published_at DESC, e.id DESC LIMIT $1`
+	queryGetLatestBySeasonID = querySelectPublished + `
+		WHERE e.season_id = $1
+		ORDER BY e.published_at DESC, e.id DESC LIMIT $2`
+	queryGetSeasonBySlug = "SELECT id, name, number, slug, image_url FROM seasons WHERE slug = $1 ORDER BY id LIMIT 1"
+)
+
+type repository struct {
+	replica *database.Replica
+}
+
+func NewRepository(replica *database.Replica) Repository {
+	return &repository{replica: replica}
+}
+
+func (r *repository) GetLatest(ctx context.Context, limit int) ([]*entities.PublishedEpisode, error) {
+	return r.getPublished(ctx, "queryGetLatest", queryGetLatest, limit)
+}
+
+func (r *repository) GetLatestBySeasonID(ctx context.Context, seasonID uint64, limit int) ([]*entities.PublishedEpisode, error) {
+	return r.getPublished(ctx, "queryGetLatestBySeasonID", queryGetLatestBySeasonID, seasonID, limit)
+}
+
+func (r *repository) getPublished(ctx context.Context, name, query string, args ...any) ([]*entities.PublishedEpisode, error) {
+	ctx, span := telemetry.StartQuery(ctx, name, query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query, args...)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	episodes := []*entities.PublishedEpisode{}
+	for rows.Next() {
+		episode := &entities.PublishedEpisode{}
+		if err := rows.Scan(&episode.ID, &episode.Name, &episode.Number, &episode.Slug, &episode.Url, &episode.PublishedAt,
+			&episode.SeasonID, &episode.SeasonName, &episode.SeasonSlug, &episode.SeasonImageUrl); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return episodes, nil
+}
+
+func (r *repository) GetSeasonBySlug(ctx context.Context, slug string) (*entities.Season, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetSeasonBySlug", queryGetSeasonBySlug)
+	defer span.End()
+
+	season := &entities.Season{}
+	err := r.replica.QueryRowContext(ctx, queryGetSeasonBySlug, slug).
+		Scan(&season.ID, &season.Name, &season.Number, &season.Slug, &season.ImageUrl)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("season with slug %s %w", slug, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return season, nil
+}
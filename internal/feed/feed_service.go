@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/feed")
+
+type service struct {
+	repository Repository
+	items      int
+}
+
+func NewService(repository Repository, config *config.Config) Service {
+	return &service{repository: repository, items: config.Feeds.Items}
+}
+
+func (s *service) GetLatest(ctx context.Context) (*Feed, error) {
+	ctx, span := tracer.Start(ctx, "feed.Service.GetLatest")
+	defer span.End()
+
+	episodes, err := s.repository.GetLatest(ctx, s.items)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFeed(nil, episodes), nil
+}
+
+func (s *service) GetBySeasonSlug(ctx context.Context, slug string) (*Feed, error) {
+	ctx, span := tracer.Start(ctx, "feed.Service.GetBySeasonSlug")
+	defer span.End()
+
+	season, err := s.repository.GetSeasonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := s.repository.GetLatestBySeasonID(ctx, season.ID, s.items)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFeed(season, episodes), nil
+}
+
+func newFeed(season *entities.Season, episodes []*entities.PublishedEpisode) *Feed {
+	feed := &Feed{Season: season, Episodes: episodes}
+	for _, episode := range episodes {
+		if episode.PublishedAt.After(feed.Updated) {
+			feed.Updated = episode.PublishedAt
+		}
+	}
+
+	return feed
+}
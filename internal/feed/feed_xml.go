@@ -0,0 +1,224 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	title = "Anime API"
+	// ttl sugiere a los lectores cada cuántos minutos volver a descargar el
+	// feed.
+	ttl = 60
+)
+
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomSpace string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	TTL           int       `xml:"ttl"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	Url    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int    `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Summary   string     `xml:"summary"`
+	Links     []atomLink `xml:"link"`
+}
+
+// links son las URL absolutas que necesitan los feeds: baseURL es el
+// origen de la petición y self, la URL del propio feed.
+type links struct {
+	baseURL string
+	self    string
+}
+
+// home es la página del feed: la temporada o el listado de episodios.
+func (l links) home(feed *Feed) string {
+	if feed.Season != nil {
+		return fmt.Sprintf("%s/api/v2/seasons/%d", l.baseURL, feed.Season.ID)
+	}
+	return l.baseURL + "/api/v2/episodes/latest"
+}
+
+// episode identifica al episodio; no cambia aunque cambie su URL.
+func (l links) episode(episode *entities.PublishedEpisode) string {
+	return fmt.Sprintf("%s/api/v2/episodes/%d", l.baseURL, episode.ID)
+}
+
+// absolute completa las URL relativas de las imágenes del almacenamiento
+// local.
+func (l links) absolute(value string) string {
+	if strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "//") {
+		return l.baseURL + value
+	}
+	return value
+}
+
+func feedTitle(feed *Feed) string {
+	if feed.Season != nil {
+		return title + ": " + feed.Season.Name
+	}
+	return title
+}
+
+func episodeTitle(episode *entities.PublishedEpisode) string {
+	return fmt.Sprintf("%s - Episode %d: %s", episode.SeasonName, episode.Number, episode.Name)
+}
+
+func episodeSummary(episode *entities.PublishedEpisode) string {
+	return fmt.Sprintf("Episode %d of %s is available.", episode.Number, episode.SeasonName)
+}
+
+// imageType deduce el tipo de la imagen de la temporada por su extensión.
+func imageType(value string) string {
+	if u, err := url.Parse(value); err == nil {
+		if t := mime.TypeByExtension(path.Ext(u.Path)); strings.HasPrefix(t, "image/") {
+			return t
+		}
+	}
+	return "image/jpeg"
+}
+
+func encodeRSS(feed *Feed, l links) ([]byte, error) {
+	channel := rssChannel{
+		Title:       feedTitle(feed),
+		Link:        l.home(feed),
+		Description: "Newly published episodes.",
+		Self:        atomLink{Rel: "self", Href: l.self, Type: mimeRSS},
+		TTL:         ttl,
+		Items:       make([]rssItem, 0, len(feed.Episodes)),
+	}
+	if feed.Season != nil {
+		channel.Description = "Newly published episodes of " + feed.Season.Name + "."
+	}
+	if !feed.Updated.IsZero() {
+		channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, episode := range feed.Episodes {
+		item := rssItem{
+			Title:       episodeTitle(episode),
+			Link:        episode.Url,
+			Description: episodeSummary(episode),
+			GUID:        rssGUID{Value: l.episode(episode)},
+			PubDate:     episode.PublishedAt.UTC().Format(time.RFC1123Z),
+		}
+		// La longitud de la imagen no se conoce; RSS admite 0 en ese caso.
+		if episode.SeasonImageUrl != "" {
+			image := l.absolute(episode.SeasonImageUrl)
+			item.Enclosure = &rssEnclosure{Url: image, Type: imageType(image)}
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	return marshal(&rss{Version: "2.0", AtomSpace: "http://www.w3.org/2005/Atom", Channel: channel})
+}
+
+func encodeAtom(feed *Feed, l links) ([]byte, error) {
+	// updated es obligatorio; un feed vacío usa una fecha fija para que su
+	// ETag no cambie en cada petición.
+	updated := feed.Updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	atom := &atomFeed{
+		ID:      l.self,
+		Title:   feedTitle(feed),
+		Updated: updated.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: title},
+		Links: []atomLink{
+			{Rel: "self", Href: l.self, Type: mimeAtom},
+			{Rel: "alternate", Href: l.home(feed), Type: "application/json"},
+		},
+		Entries: make([]atomEntry, 0, len(feed.Episodes)),
+	}
+
+	for _, episode := range feed.Episodes {
+		published := episode.PublishedAt.UTC().Format(time.RFC3339)
+		entry := atomEntry{
+			ID:        l.episode(episode),
+			Title:     episodeTitle(episode),
+			Updated:   published,
+			Published: published,
+			Summary:   episodeSummary(episode),
+			Links:     []atomLink{{Rel: "alternate", Href: episode.Url}},
+		}
+		if episode.SeasonImageUrl != "" {
+			image := l.absolute(episode.SeasonImageUrl)
+			entry.Links = append(entry.Links, atomLink{Rel: "enclosure", Href: image, Type: imageType(image)})
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return marshal(atom)
+}
+
+func marshal(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
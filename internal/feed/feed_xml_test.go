@@ -0,0 +1,178 @@
+package feed
+
+import (
+	"encoding/xml"
+	"github.com/wicho90/anime-api/internal/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testLinks = links{baseURL: "https://anime.example.com", self: "https://anime.example.com/api/v2/episodes/feed.rss"}
+
+func newTestFeed() *Feed {
+	season := &entities.Season{ID: 1, Name: "shingeki no kyojin", Slug: "shingeki-no-kyojin"}
+	return newFeed(season, []*entities.PublishedEpisode{
+		{
+			ID: 2, Name: "that day", Number: 2, Url: "https://video.example.com/snk/2",
+			PublishedAt: time.Date(2026, time.October, 12, 15, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+			SeasonName:  "shingeki no kyojin", SeasonImageUrl: "/uploads/seasons/1/card.png",
+		},
+		{
+			ID: 1, Name: "to you, in 2000 years", Number: 1, Url: "https://video.example.com/snk/1",
+			PublishedAt: time.Date(2026, time.October, 5, 6, 0, 0, 0, time.UTC),
+			SeasonName:  "shingeki no kyojin", SeasonImageUrl: "https://cdn.example.com/snk.webp?v=2",
+		},
+	})
+}
+
+func TestNewFeedUpdated(t *testing.T) {
+	tests := []struct {
+		name     string
+		episodes []*entities.PublishedEpisode
+		want     time.Time
+	}{
+		{"no episodes", nil, time.Time{}},
+		{"latest publication", newTestFeed().Episodes, time.Date(2026, time.October, 12, 6, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newFeed(nil, tt.episodes).Updated; !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeRSS(t *testing.T) {
+	body, err := encodeRSS(newTestFeed(), testLinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(body), xml.Header) {
+		t.Error("missing XML declaration")
+	}
+
+	var doc rss
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	channel := doc.Channel
+	if doc.Version != "2.0" || channel.Title != "Anime API: shingeki no kyojin" {
+		t.Errorf("got channel %+v", channel)
+	}
+	// Al leer, atom:link también coincide con el campo link, así que éste se
+	// comprueba en el texto.
+	if !strings.Contains(string(body), "<link>https://anime.example.com/api/v2/seasons/1</link>") {
+		t.Error("missing link to the season")
+	}
+	if channel.LastBuildDate != "Mon, 12 Oct 2026 06:00:00 +0000" || channel.TTL != ttl {
+		t.Errorf("got lastBuildDate %q and ttl %d", channel.LastBuildDate, channel.TTL)
+	}
+	if !strings.Contains(string(body), `<atom:link rel="self" href="`+testLinks.self+`" type="application/rss+xml">`) {
+		t.Error("missing atom:link to the feed itself")
+	}
+
+	want := []rssItem{
+		{
+			Title: "shingeki no kyojin - Episode 2: that day", Link: "https://video.example.com/snk/2",
+			Description: "Episode 2 of shingeki no kyojin is available.",
+			GUID:        rssGUID{Value: "https://anime.example.com/api/v2/episodes/2"},
+			PubDate:     "Mon, 12 Oct 2026 06:00:00 +0000",
+			Enclosure:   &rssEnclosure{Url: "https://anime.example.com/uploads/seasons/1/card.png", Type: "image/png"},
+		},
+		{
+			Title: "shingeki no kyojin - Episode 1: to you, in 2000 years", Link: "https://video.example.com/snk/1",
+			Description: "Episode 1 of shingeki no kyojin is available.",
+			GUID:        rssGUID{Value: "https://anime.example.com/api/v2/episodes/1"},
+			PubDate:     "Mon, 05 Oct 2026 06:00:00 +0000",
+			Enclosure:   &rssEnclosure{Url: "https://cdn.example.com/snk.webp?v=2", Type: "image/webp"},
+		},
+	}
+	if len(channel.Items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(channel.Items), len(want))
+	}
+	for i, item := range channel.Items {
+		w := want[i]
+		if item.Title != w.Title || item.Link != w.Link || item.Description != w.Description || item.GUID != w.GUID || item.PubDate != w.PubDate {
+			t.Errorf("item %d: got %+v, want %+v", i, item, w)
+		}
+		if item.Enclosure == nil || *item.Enclosure != *w.Enclosure {
+			t.Errorf("item %d: got enclosure %+v, want %+v", i, item.Enclosure, w.Enclosure)
+		}
+	}
+}
+
+func TestEncodeAtom(t *testing.T) {
+	body, err := encodeAtom(newTestFeed(), testLinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc atomFeed
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if doc.XMLName.Space != "http://www.w3.org/2005/Atom" || doc.ID != testLinks.self || doc.Updated != "2026-10-12T06:00:00Z" {
+		t.Errorf("got feed %s %q updated %q", doc.XMLName.Space, doc.ID, doc.Updated)
+	}
+	if len(doc.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(doc.Entries))
+	}
+
+	entry := doc.Entries[0]
+	if entry.ID != "https://anime.example.com/api/v2/episodes/2" || entry.Published != "2026-10-12T06:00:00Z" || entry.Updated != entry.Published {
+		t.Errorf("got entry %+v", entry)
+	}
+	wantLinks := []atomLink{
+		{Rel: "alternate", Href: "https://video.example.com/snk/2"},
+		{Rel: "enclosure", Href: "https://anime.example.com/uploads/seasons/1/card.png", Type: "image/png"},
+	}
+	if len(entry.Links) != len(wantLinks) || entry.Links[0] != wantLinks[0] || entry.Links[1] != wantLinks[1] {
+		t.Errorf("got links %+v, want %+v", entry.Links, wantLinks)
+	}
+}
+
+func TestEncodeEmptyFeeds(t *testing.T) {
+	empty := newFeed(nil, nil)
+
+	body, err := encodeAtom(empty, testLinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var atom atomFeed
+	if err := xml.Unmarshal(body, &atom); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if atom.Updated != "1970-01-01T00:00:00Z" || atom.Title != title || len(atom.Entries) != 0 {
+		t.Errorf("got %+v", atom)
+	}
+
+	body, err = encodeRSS(empty, testLinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "lastBuildDate") {
+		t.Error("empty RSS feed has a lastBuildDate")
+	}
+	if !strings.Contains(string(body), "<link>https://anime.example.com/api/v2/episodes/latest</link>") {
+		t.Error("empty RSS feed does not link to the latest episodes")
+	}
+}
+
+func TestImageType(t *testing.T) {
+	tests := []struct {
+		url, want string
+	}{
+		{"https://cdn.example.com/a.png", "image/png"},
+		{"https://cdn.example.com/a.webp?v=1", "image/webp"},
+		{"/uploads/a.gif", "image/gif"},
+		{"https://cdn.example.com/a", "image/jpeg"},
+		{"https://cdn.example.com/a.html", "image/jpeg"},
+	}
+	for _, tt := range tests {
+		if got := imageType(tt.url); got != tt.want {
+			t.Errorf("imageType(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+)
+
+// Handler sirve /graphql. Prepare analiza la petición y clasifica la
+// operación para el limitador (consulta = lectura, mutación = escritura);
+// debe ir antes de ratelimit.Middleware y Serve al final.
+type Handler interface {
+	Prepare(ctx *fiber.Ctx) error
+	Serve(ctx *fiber.Ctx) error
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// prepared es el resultado de Prepare que Serve recoge de ctx.Locals.
+type prepared struct {
+	request  request
+	document *ast.Document
+	status   int
+	errors   []gqlerrors.FormattedError
+}
+
+const localsPrepared = "graph.prepared"
+
+type handler struct {
+	schema         graphql.Schema
+	limits         limits
+	seasonService  season.Service
+	episodeService episode.Service
+}
+
+func NewHandler(
+	seasonService season.Service,
+	episodeService episode.Service,
+	validator validator.Validator,
+	config *config.Config,
+) (Handler, error) {
+	schema, err := newSchema(&resolver{
+		seasonService:  seasonService,
+		episodeService: episodeService,
+		validator:      validator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &handler{
+		schema: schema,
+		limits: limits{
+			maxDepth:      config.GraphQL.MaxDepth,
+			maxComplexity: config.GraphQL.MaxComplexity,
+			listFactor:    config.GraphQL.ListFactor,
+		},
+		seasonService:  seasonService,
+		episodeService: episodeService,
+	}, nil
+}
+
+func (h *handler) Prepare(ctx *fiber.Ctx) error {
+	p := h.prepare(ctx)
+	ctx.Locals(localsPrepared, p)
+
+	group := ratelimit.GroupWrite
+	if p.errors == nil && operationType(p.document, p.request.OperationName) == ast.OperationTypeQuery {
+		group = ratelimit.GroupRead
+	}
+	ratelimit.Classify(ctx, group)
+
+	return ctx.Next()
+}
+
+func (h *handler) prepare(ctx *fiber.Ctx) *prepared {
+	p := &prepared{}
+
+	switch ctx.Method() {
+	case fiber.MethodPost:
+		if err := json.Unmarshal(ctx.Body(), &p.request); err != nil {
+			return p.fail(http.StatusBadRequest, "Invalid request body")
+		}
+	default:
+		p.request.Query = ctx.Query("query")
+		p.request.OperationName = ctx.Query("operationName")
+		if variables := ctx.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &p.request.Variables); err != nil {
+				return p.fail(http.StatusBadRequest, "Invalid variables")
+			}
+		}
+	}
+	if p.request.Query == "" {
+		return p.fail(http.StatusBadRequest, "Missing query")
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: p.request.Query})
+	if err != nil {
+		p.status = http.StatusBadRequest
+		p.errors = gqlerrors.FormatErrors(err)
+		return p
+	}
+	p.document = document
+
+	if result := graphql.ValidateDocument(&h.schema, document, nil); !result.IsValid {
+		p.status = http.StatusBadRequest
+		p.errors = result.Errors
+		return p
+	}
+
+	// Las mutaciones no se aceptan por GET para que no puedan dispararse
+	// desde un enlace.
+	if ctx.Method() != fiber.MethodPost && operationType(document, p.request.OperationName) == ast.OperationTypeMutation {
+		return p.fail(http.StatusMethodNotAllowed, "Mutations must use POST")
+	}
+
+	// Como las rutas de escritura de HTTP, las mutaciones sólo las pueden
+	// ejecutar administradores.
+	if operationType(document, p.request.OperationName) == ast.OperationTypeMutation {
+		user, ok := auth.CurrentUser(ctx)
+		if !ok {
+			return p.fail(http.StatusUnauthorized, "Authentication required")
+		}
+		if !user.IsAdmin() {
+			return p.fail(http.StatusForbidden, "Administrator role required")
+		}
+	}
+
+	if err := h.limits.check(h.schema, document, p.request.OperationName); err != nil {
+		return p.fail(http.StatusBadRequest, err.Error())
+	}
+
+	return p
+}
+
+func (p *prepared) fail(status int, message string) *prepared {
+	p.status = status
+	p.errors = []gqlerrors.FormattedError{gqlerrors.NewFormattedError(message)}
+
+	return p
+}
+
+func (h *handler) Serve(ctx *fiber.Ctx) error {
+	p, ok := ctx.Locals(localsPrepared).(*prepared)
+	if !ok {
+		p = h.prepare(ctx)
+	}
+	if p.errors != nil {
+		return ctx.Status(p.status).JSON(&graphql.Result{Errors: p.errors})
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           p.document,
+		OperationName: p.request.OperationName,
+		Args:          p.request.Variables,
+		Context:       withLoaders(ctx.UserContext(), newLoaders(h.seasonService, h.episodeService)),
+	})
+
+	return ctx.Status(http.StatusOK).JSON(result)
+}
+
+func operationType(doc *ast.Document, operationName string) string {
+	if doc == nil {
+		return ""
+	}
+
+	operation, _ := splitDocument(doc, operationName)
+	if operation == nil {
+		return ""
+	}
+
+	return operation.Operation
+}
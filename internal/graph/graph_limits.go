@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"strings"
+)
+
+// limits estima el coste de una operación recorriendo su selección con los
+// tipos del esquema. Cada campo cuesta un punto y el coste de la selección de
+// un campo de tipo lista se multiplica por listFactor, ya que se evalúa una
+// vez por elemento. Los campos de introspección (__schema, __typename...) no
+// cuentan.
+type limits struct {
+	maxDepth      int
+	maxComplexity int
+	listFactor    int
+}
+
+type cost struct {
+	depth      int
+	complexity int
+}
+
+func (l limits) check(schema graphql.Schema, doc *ast.Document, operationName string) error {
+	operation, fragments := splitDocument(doc, operationName)
+	if operation == nil {
+		return fmt.Errorf("operation %q not found", operationName)
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	c := l.measure(root, operation.SelectionSet, fragments, map[string]bool{})
+	if c.depth > l.maxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", c.depth, l.maxDepth)
+	}
+	if c.complexity > l.maxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", c.complexity, l.maxComplexity)
+	}
+
+	return nil
+}
+
+func (l limits) measure(parent *graphql.Object, set *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, visiting map[string]bool) cost {
+	var total cost
+	if set == nil || parent == nil {
+		return total
+	}
+
+	add := func(c cost) {
+		total.complexity += c.complexity
+		if c.depth > total.depth {
+			total.depth = c.depth
+		}
+	}
+
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+
+			field, ok := parent.Fields()[s.Name.Value]
+			if !ok {
+				continue
+			}
+
+			multiplier := 1
+			t := graphql.GetNullable(field.Type)
+			if _, ok := t.(*graphql.List); ok {
+				multiplier = l.listFactor
+			}
+			child, _ := graphql.GetNamed(field.Type).(*graphql.Object)
+
+			sub := l.measure(child, s.SelectionSet, fragments, visiting)
+			add(cost{depth: sub.depth + 1, complexity: 1 + sub.complexity*multiplier})
+		case *ast.InlineFragment:
+			add(l.measure(parent, s.SelectionSet, fragments, visiting))
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			add(l.measure(parent, fragment.SelectionSet, fragments, visiting))
+			delete(visiting, name)
+		}
+	}
+
+	return total
+}
+
+func splitDocument(doc *ast.Document, operationName string) (*ast.OperationDefinition, map[string]*ast.FragmentDefinition) {
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				if operation == nil {
+					operation = d
+				}
+			}
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		}
+	}
+
+	return operation, fragments
+}
@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/season"
+	"sync"
+)
+
+// loader agrupa las cargas por clave de un mismo nivel de la consulta en una
+// sola llamada a fetch. Los resolvers devuelven el thunk de Load y graphql-go
+// los ejecuta después de resolver todos los campos hermanos, así que el
+// primer thunk que se evalúa encuentra ya todas las claves pendientes. Cada
+// petición usa sus propios loaders, que hacen también de caché.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	entries map[K]*entry[V]
+}
+
+type entry[V any] struct {
+	done  bool
+	value V
+	err   error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, entries: map[K]*entry[V]{}}
+}
+
+func (l *loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	e, ok := l.entries[key]
+	if !ok {
+		e = &entry[V]{}
+		l.entries[key] = e
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !e.done {
+			l.dispatch(ctx)
+		}
+
+		return e.value, e.err
+	}
+}
+
+// Prime guarda un valor ya conocido para que no se vuelva a consultar. Si la
+// clave ya está pendiente completa la misma entrada, que es la que esperan
+// los thunks devueltos por Load.
+func (l *loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		l.entries[key] = &entry[V]{done: true, value: value}
+		return
+	}
+	if !e.done {
+		e.done, e.value = true, value
+	}
+}
+
+func (l *loader[K, V]) dispatch(ctx context.Context) {
+	keys := make([]K, 0, len(l.pending))
+	for _, key := range l.pending {
+		if !l.entries[key].done {
+			keys = append(keys, key)
+		}
+	}
+	l.pending = nil
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		e := l.entries[key]
+		if e.done {
+			continue
+		}
+		e.done, e.value, e.err = true, values[key], err
+	}
+}
+
+type loaders struct {
+	seasons        *loader[uint64, *entities.Season]
+	episodes       *loader[uint64, *entities.Episode]
+	seasonEpisodes *loader[uint64, []*entities.Episode]
+}
+
+func newLoaders(seasonService season.Service, episodeService episode.Service) *loaders {
+	return &loaders{
+		seasons: newLoader(func(ctx context.Context, ids []uint64) (map[uint64]*entities.Season, error) {
+			seasons, err := seasonService.GetByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[uint64]*entities.Season, len(seasons))
+			for _, season := range seasons {
+				byID[season.ID] = season
+			}
+			return byID, nil
+		}),
+		episodes: newLoader(func(ctx context.Context, ids []uint64) (map[uint64]*entities.Episode, error) {
+			episodes, err := episodeService.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[uint64]*entities.Episode, len(episodes))
+			for _, episode := range episodes {
+				byID[episode.ID] = episode
+			}
+			return byID, nil
+		}),
+		seasonEpisodes: newLoader(func(ctx context.Context, seasonIDs []uint64) (map[uint64][]*entities.Episode, error) {
+			episodes, err := episodeService.GetBySeasonIDs(ctx, seasonIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			bySeason := make(map[uint64][]*entities.Episode, len(seasonIDs))
+			for _, id := range seasonIDs {
+				bySeason[id] = []*entities.Episode{}
+			}
+			for _, episode := range episodes {
+				bySeason[episode.SeasonId] = append(bySeason[episode.SeasonId], episode)
+			}
+			return bySeason, nil
+		}),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoaderPrimeFillsPendingEntry(t *testing.T) {
+	var fetched [][]int
+	l := newLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		fetched = append(fetched, keys)
+		values := make(map[int]string, len(keys))
+		for _, key := range keys {
+			values[key] = "fetched"
+		}
+		return values, nil
+	})
+
+	primed := l.Load(context.Background(), 1)
+	other := l.Load(context.Background(), 2)
+	l.Prime(1, "primed")
+
+	tests := []struct {
+		name  string
+		thunk func() (string, error)
+		want  string
+	}{
+		{"primed", primed, "primed"},
+		{"fetched", other, "fetched"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.thunk()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if len(fetched) != 1 || len(fetched[0]) != 1 || fetched[0][0] != 2 {
+		t.Errorf("fetched %v, want [[2]]", fetched)
+	}
+}
+
+func TestLoaderPrimeKeepsLoadedValue(t *testing.T) {
+	l := newLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		return map[int]string{1: "fetched"}, nil
+	})
+
+	thunk := l.Load(context.Background(), 1)
+	if _, err := thunk(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Prime(1, "primed")
+
+	if got, _ := l.Load(context.Background(), 1)(); got != "fetched" {
+		t.Errorf("got %q, want %q", got, "fetched")
+	}
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+// graphResponse documenta el cuerpo de graphql.Result.
+type graphResponse struct {
+	Data   map[string]any `json:"data,omitempty"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+// OpenAPI documenta /graphql. El esquema GraphQL se obtiene con una consulta
+// de introspección.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("GraphQLRequest", request{})
+	result := doc.Register("GraphQLResponse", graphResponse{})
+
+	query := request{Query: "{ season(id: 1) { name episodes { number name next { slug } } } }"}
+	data := map[string]any{"data": map[string]any{"season": map[string]any{
+		"name": "shingeki no kyojin",
+		"episodes": []map[string]any{
+			{"number": 1, "name": "to you, in 2000 years", "next": map[string]any{"slug": "shingeki-no-kyojin-2"}},
+		},
+	}}}
+	invalid := map[string]any{"errors": []map[string]any{{"message": "query depth 9 exceeds the limit of 8"}}}
+
+	responses := func() map[string]*openapi.Response {
+		return openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:              openapi.JSON("Execution result; resolver errors are listed in errors", result, data),
+			http.StatusBadRequest:      openapi.JSON("Unparsable, invalid or too expensive query", result, invalid),
+			http.StatusUnauthorized:    openapi.Error(http.StatusUnauthorized, "Invalid token"),
+			http.StatusTooManyRequests: openapi.Error(http.StatusTooManyRequests, "Rate limit exceeded"),
+		})
+	}
+
+	doc.Add(http.MethodPost, "/graphql", &openapi.Operation{
+		OperationID: "graphql",
+		Summary:     "Execute a GraphQL query or mutation",
+		Description: "Queries use the read rate limit budget and mutations the write budget. " +
+			"Mutations require an administrator token. Queries are rejected before execution when they exceed the configured depth or complexity.",
+		Tags:        []string{"graphql"},
+		RequestBody: openapi.JSONBody(schema, query),
+		Responses: func() map[string]*openapi.Response {
+			r := responses()
+			r["403"] = openapi.JSON("Mutation sent without an administrator token", result, map[string]any{
+				"errors": []map[string]any{{"message": "Administrator role required"}},
+			})
+			return r
+		}(),
+	})
+	doc.Add(http.MethodGet, "/graphql", &openapi.Operation{
+		OperationID: "graphqlQuery",
+		Summary:     "Execute a GraphQL query",
+		Description: "Mutations are only accepted over POST.",
+		Tags:        []string{"graphql"},
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParam("query", "GraphQL document", &openapi.Schema{Type: "string"}),
+			openapi.QueryParam("operationName", "Operation to run when the document has several", &openapi.Schema{Type: "string"}),
+			openapi.QueryParam("variables", "JSON object with the variables", &openapi.Schema{Type: "string"}),
+		},
+		Responses: func() map[string]*openapi.Response {
+			r := responses()
+			r["405"] = openapi.JSON("Mutation sent over GET", result, map[string]any{
+				"errors": []map[string]any{{"message": "Mutations must use POST"}},
+			})
+			return r
+		}(),
+	})
+}
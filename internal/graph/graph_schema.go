@@ -0,0 +1,466 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/validator"
+	"strconv"
+)
+
+type resolver struct {
+	seasonService  season.Service
+	episodeService episode.Service
+	validator      validator.Validator
+}
+
+func newSchema(r *resolver) (graphql.Schema, error) {
+	var seasonType, episodeType *graphql.Object
+
+	seasonType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Season",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: seasonField(func(s *entities.Season) any { return id(s.ID) })},
+				"name":     {Type: graphql.NewNonNull(graphql.String), Resolve: seasonField(func(s *entities.Season) any { return s.Name })},
+				"number":   {Type: graphql.NewNonNull(graphql.Int), Resolve: seasonField(func(s *entities.Season) any { return int(s.Number) })},
+				"slug":     {Type: graphql.NewNonNull(graphql.String), Resolve: seasonField(func(s *entities.Season) any { return s.Slug })},
+				"imageUrl": {Type: graphql.NewNonNull(graphql.String), Resolve: seasonField(func(s *entities.Season) any { return s.ImageUrl })},
+				"episodes": {
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(episodeType))),
+					Description: "Episodes of the season ordered by number.",
+					Resolve:     r.seasonEpisodes,
+				},
+			}
+		}),
+	})
+
+	episodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Episode",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: episodeField(func(e *entities.Episode) any { return id(e.ID) })},
+				"name":     {Type: graphql.NewNonNull(graphql.String), Resolve: episodeField(func(e *entities.Episode) any { return e.Name })},
+				"number":   {Type: graphql.NewNonNull(graphql.Int), Resolve: episodeField(func(e *entities.Episode) any { return int(e.Number) })},
+				"duration": {Type: graphql.NewNonNull(graphql.String), Resolve: episodeField(func(e *entities.Episode) any { return e.Duration })},
+				"url":      {Type: graphql.NewNonNull(graphql.String), Resolve: episodeField(func(e *entities.Episode) any { return e.Url })},
+				"slug":     {Type: graphql.NewNonNull(graphql.String), Resolve: episodeField(func(e *entities.Episode) any { return e.Slug })},
+				"season":   {Type: seasonType, Resolve: r.episodeSeason},
+				"previous": {
+					Type:        episodeType,
+					Description: "Previous episode of the same season.",
+					Resolve:     r.sibling(-1),
+				},
+				"next": {
+					Type:        episodeType,
+					Description: "Next episode of the same season.",
+					Resolve:     r.sibling(1),
+				},
+			}
+		}),
+	})
+
+	idArgs := graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"seasons": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(seasonType))),
+				Resolve: r.seasons,
+			},
+			"season": {
+				Type:    seasonType,
+				Args:    idArgs,
+				Resolve: r.season,
+			},
+			"episodes": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(episodeType))),
+				Resolve: r.episodes,
+			},
+			"latestEpisodes": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(episodeType))),
+				Resolve: r.latestEpisodes,
+			},
+			"episode": {
+				Type:        episodeType,
+				Description: "Episode by id or slug.",
+				Args: graphql.FieldConfigArgument{
+					"id":   {Type: graphql.ID},
+					"slug": {Type: graphql.String},
+				},
+				Resolve: r.episode,
+			},
+		},
+	})
+
+	seasonInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "SeasonInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":     {Type: graphql.NewNonNull(graphql.String)},
+			"number":   {Type: graphql.NewNonNull(graphql.Int)},
+			"imageUrl": {Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	episodeInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "EpisodeInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":     {Type: graphql.NewNonNull(graphql.String)},
+			"number":   {Type: graphql.NewNonNull(graphql.Int)},
+			"duration": {Type: graphql.NewNonNull(graphql.String)},
+			"url":      {Type: graphql.NewNonNull(graphql.String)},
+			"seasonId": {Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createSeason": {
+				Type:    graphql.NewNonNull(seasonType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(seasonInput)}},
+				Resolve: r.createSeason,
+			},
+			"updateSeason": {
+				Type: graphql.NewNonNull(seasonType),
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: graphql.NewNonNull(graphql.ID)},
+					"input": {Type: graphql.NewNonNull(seasonInput)},
+				},
+				Resolve: r.updateSeason,
+			},
+			"deleteSeason": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    idArgs,
+				Resolve: r.deleteSeason,
+			},
+			"createEpisode": {
+				Type:    graphql.NewNonNull(episodeType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(episodeInput)}},
+				Resolve: r.createEpisode,
+			},
+			"updateEpisode": {
+				Type: graphql.NewNonNull(episodeType),
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: graphql.NewNonNull(graphql.ID)},
+					"input": {Type: graphql.NewNonNull(episodeInput)},
+				},
+				Resolve: r.updateEpisode,
+			},
+			"deleteEpisode": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    idArgs,
+				Resolve: r.deleteEpisode,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func seasonField(get func(*entities.Season) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(*entities.Season)), nil
+	}
+}
+
+func episodeField(get func(*entities.Episode) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(*entities.Episode)), nil
+	}
+}
+
+func (r *resolver) seasons(p graphql.ResolveParams) (any, error) {
+	seasons, err := r.seasonService.GetAll(p.Context)
+	if err != nil {
+		return nil, publicError(err, "Failed to get seasons")
+	}
+
+	l := loadersFrom(p.Context)
+	for _, season := range seasons {
+		l.seasons.Prime(season.ID, season)
+	}
+
+	return seasons, nil
+}
+
+func (r *resolver) season(p graphql.ResolveParams) (any, error) {
+	seasonID, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return thunk(loadersFrom(p.Context).seasons.Load(p.Context, seasonID), "Failed to get season"), nil
+}
+
+func (r *resolver) episodes(p graphql.ResolveParams) (any, error) {
+	episodes, err := r.episodeService.GetAll(p.Context)
+	if err != nil {
+		return nil, publicError(err, "Failed to get episodes")
+	}
+
+	l := loadersFrom(p.Context)
+	for _, episode := range episodes {
+		l.episodes.Prime(episode.ID, episode)
+	}
+
+	return episodes, nil
+}
+
+// latestEpisodes reutiliza el orden de GetLatest y carga los episodios
+// completos en una sola consulta.
+func (r *resolver) latestEpisodes(p graphql.ResolveParams) (any, error) {
+	latest, err := r.episodeService.GetLatest(p.Context)
+	if err != nil {
+		return nil, publicError(err, "Failed to get episodes")
+	}
+
+	l := loadersFrom(p.Context)
+	thunks := make([]func() (*entities.Episode, error), 0, len(latest))
+	for _, episode := range latest {
+		thunks = append(thunks, l.episodes.Load(p.Context, episode.ID))
+	}
+
+	return func() (any, error) {
+		episodes := make([]*entities.Episode, 0, len(thunks))
+		for _, load := range thunks {
+			episode, err := load()
+			if err != nil {
+				return nil, publicError(err, "Failed to get episodes")
+			}
+			if episode != nil {
+				episodes = append(episodes, episode)
+			}
+		}
+		return episodes, nil
+	}, nil
+}
+
+func (r *resolver) episode(p graphql.ResolveParams) (any, error) {
+	l := loadersFrom(p.Context)
+
+	if slug, ok := p.Args["slug"].(string); ok {
+		found, err := r.episodeService.GetBySlug(p.Context, slug)
+		if err != nil {
+			if errors.Is(err, ex.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, publicError(err, "Failed to get episode")
+		}
+		return thunk(l.episodes.Load(p.Context, found.ID), "Failed to get episode"), nil
+	}
+
+	if _, ok := p.Args["id"]; !ok {
+		return nil, errors.New("episode needs an id or a slug")
+	}
+	episodeID, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return thunk(l.episodes.Load(p.Context, episodeID), "Failed to get episode"), nil
+}
+
+func (r *resolver) seasonEpisodes(p graphql.ResolveParams) (any, error) {
+	s := p.Source.(*entities.Season)
+	load := loadersFrom(p.Context).seasonEpisodes.Load(p.Context, s.ID)
+
+	return func() (any, error) {
+		episodes, err := load()
+		if err != nil {
+			return nil, publicError(err, "Failed to get episodes")
+		}
+		return episodes, nil
+	}, nil
+}
+
+func (r *resolver) episodeSeason(p graphql.ResolveParams) (any, error) {
+	e := p.Source.(*entities.Episode)
+	return thunk(loadersFrom(p.Context).seasons.Load(p.Context, e.SeasonId), "Failed to get season"), nil
+}
+
+// sibling resuelve el episodio anterior o siguiente a partir de los episodios
+// de la temporada, que se cargan una sola vez por temporada.
+func (r *resolver) sibling(offset int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		e := p.Source.(*entities.Episode)
+		load := loadersFrom(p.Context).seasonEpisodes.Load(p.Context, e.SeasonId)
+
+		return func() (any, error) {
+			episodes, err := load()
+			if err != nil {
+				return nil, publicError(err, "Failed to get episodes")
+			}
+			for _, candidate := range episodes {
+				if int(candidate.Number) == int(e.Number)+offset {
+					return candidate, nil
+				}
+			}
+			return nil, nil
+		}, nil
+	}
+}
+
+func (r *resolver) createSeason(p graphql.ResolveParams) (any, error) {
+	season := seasonFromInput(p.Args["input"])
+	if m, err := r.validator.Validate(season); err != nil {
+		return nil, errors.New(m)
+	}
+
+	if err := r.seasonService.Create(p.Context, season); err != nil {
+		return nil, publicError(err, "Failed to create season")
+	}
+
+	return season, nil
+}
+
+func (r *resolver) updateSeason(p graphql.ResolveParams) (any, error) {
+	seasonID, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	season := seasonFromInput(p.Args["input"])
+	if m, err := r.validator.Validate(season); err != nil {
+		return nil, errors.New(m)
+	}
+
+	if err := r.seasonService.Update(p.Context, seasonID, season); err != nil {
+		return nil, publicError(err, "Failed to update season")
+	}
+
+	return season, nil
+}
+
+func (r *resolver) deleteSeason(p graphql.ResolveParams) (any, error) {
+	seasonID, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.seasonService.Delete(p.Context, seasonID); err != nil {
+		return nil, publicError(err, "Failed to delete season")
+	}
+
+	return true, nil
+}
+
+func (r *resolver) createEpisode(p graphql.ResolveParams) (any, error) {
+	episode, err := episodeFromInput(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+	if m, err := r.validator.Validate(episode); err != nil {
+		return nil, errors.New(m)
+	}
+
+	if err := r.episodeService.Create(p.Context, episode); err != nil {
+		return nil, publicError(err, "Failed to create episode")
+	}
+
+	return episode, nil
+}
+
+func (r *resolver) updateEpisode(p graphql.ResolveParams) (any, error) {
+	episodeID, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	episode, err := episodeFromInput(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+	if m, err := r.validator.Validate(episode); err != nil {
+		return nil, errors.New(m)
+	}
+
+	if err := r.episodeService.Update(p.Context, episodeID, episode); err != nil {
+		return nil, publicError(err, "Failed to update episode")
+	}
+
+	return episode, nil
+}
+
+func (r *resolver) deleteEpisode(p graphql.ResolveParams) (any, error) {
+	episodeID, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.episodeService.Delete(p.Context, episodeID); err != nil {
+		return nil, publicError(err, "Failed to delete episode")
+	}
+
+	return true, nil
+}
+
+func seasonFromInput(input any) *entities.Season {
+	values := input.(map[string]any)
+	return &entities.Season{
+		Name:     values["name"].(string),
+		Number:   uint8(values["number"].(int)),
+		ImageUrl: values["imageUrl"].(string),
+	}
+}
+
+func episodeFromInput(input any) (*entities.Episode, error) {
+	values := input.(map[string]any)
+	seasonID, err := parseID(values["seasonId"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Episode{
+		Name:     values["name"].(string),
+		Number:   uint8(values["number"].(int)),
+		Duration: values["duration"].(string),
+		Url:      values["url"].(string),
+		SeasonId: seasonID,
+	}, nil
+}
+
+// thunk adapta el resultado de loader.Load a la firma que graphql-go
+// reconoce como valor diferido. Un nil tipado se convierte en null.
+func thunk[V any](load func() (*V, error), message string) func() (any, error) {
+	return func() (any, error) {
+		value, err := load()
+		if err != nil {
+			return nil, publicError(err, message)
+		}
+		if value == nil {
+			return nil, nil
+		}
+		return value, nil
+	}
+}
+
+func id(n uint64) string {
+	return strconv.FormatUint(n, 10)
+}
+
+func parseID(value any) (uint64, error) {
+	s, _ := value.(string)
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", s)
+	}
+
+	return n, nil
+}
+
+// publicError oculta los errores internos igual que los handlers REST; los
+// de "no encontrado" y de validación se muestran tal cual.
+func publicError(err error, message string) error {
+	var alreadyExists *ex.ErrAlreadyExists
+	var validation *ex.ErrValidation
+	if errors.Is(err, ex.ErrNotFound) || errors.As(err, &alreadyExists) || errors.As(err, &validation) {
+		return err
+	}
+
+	return errors.New(message)
+}
@@ -0,0 +1,21 @@
+package health
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Checker es una dependencia que debe estar disponible para que la API
+// acepte tráfico. Los checkers se registran en el grupo fx "readiness".
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type Handler interface {
+	Liveness(ctx *fiber.Ctx) error
+	Readiness(ctx *fiber.Ctx) error
+	// Drain hace que la readiness falle a partir de ese momento para que el
+	// orquestador deje de enviar tráfico antes de cerrar el servidor.
+	Drain()
+}
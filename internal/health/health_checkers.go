@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/migrations"
+	"strings"
+)
+
+type databaseChecker struct {
+	db *sql.DB
+}
+
+func NewDatabaseChecker(db *sql.DB) Checker {
+	return &databaseChecker{db: db}
+}
+
+func (c *databaseChecker) Name() string {
+	return "database"
+}
+
+func (c *databaseChecker) Check(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+type replicaChecker struct {
+	replica *database.Replica
+}
+
+// NewReplicaChecker sólo devuelve un checker si hay una réplica configurada;
+// si no, Replica es la primaria y ya la comprueba el de la base de datos.
+func NewReplicaChecker(config *config.Config, replica *database.Replica) []Checker {
+	if config.Database.ReplicaURL == "" {
+		return nil
+	}
+
+	return []Checker{&replicaChecker{replica: replica}}
+}
+
+func (c *replicaChecker) Name() string {
+	return "database_replica"
+}
+
+func (c *replicaChecker) Check(ctx context.Context) error {
+	return c.replica.PingContext(ctx)
+}
+
+type migrationsChecker struct {
+	db *sql.DB
+}
+
+func NewMigrationsChecker(db *sql.DB) Checker {
+	return &migrationsChecker{db: db}
+}
+
+func (c *migrationsChecker) Name() string {
+	return "migrations"
+}
+
+func (c *migrationsChecker) Check(ctx context.Context) error {
+	pending, err := migrations.Pending(ctx, c.db)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("pending migrations: %s", strings.Join(pending, ", "))
+	}
+
+	return nil
+}
+
+// Cache es una caché externa que se comprueba con un ping.
+type Cache interface {
+	Ping(ctx context.Context) error
+}
+
+type cacheChecker struct {
+	cache Cache
+}
+
+// NewCacheChecker comprueba que la caché responde. La única caché de la API
+// es el store del rate limit, que en Redis se comparte entre réplicas.
+func NewCacheChecker(cache Cache) Checker {
+	return &cacheChecker{cache: cache}
+}
+
+func (c *cacheChecker) Name() string {
+	return "cache"
+}
+
+func (c *cacheChecker) Check(ctx context.Context) error {
+	return c.cache.Ping(ctx)
+}
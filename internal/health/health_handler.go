@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/config"
+	"go.uber.org/fx"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	statusOK       = "ok"
+	statusFail     = "fail"
+	statusDraining = "draining"
+)
+
+type checkResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+type report struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+type handler struct {
+	checkers []Checker
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+type Params struct {
+	fx.In
+
+	Config   *config.Config
+	Checkers []Checker `group:"readiness"`
+}
+
+func NewHandler(p Params) Handler {
+	return &handler{
+		checkers: p.Checkers,
+		timeout:  p.Config.Health.CheckTimeout,
+	}
+}
+
+func (h *handler) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *handler) Liveness(ctx *fiber.Ctx) error {
+	return ctx.Status(http.StatusOK).JSON(report{Status: statusOK})
+}
+
+func (h *handler) Readiness(ctx *fiber.Ctx) error {
+	if h.draining.Load() {
+		return ctx.Status(http.StatusServiceUnavailable).JSON(report{Status: statusDraining})
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx.UserContext(), h.timeout)
+	defer cancel()
+
+	results := make(map[string]checkResult, len(h.checkers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, checker := range h.checkers {
+		wg.Add(1)
+		go func(checker Checker) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := checker.Check(checkCtx)
+			result := checkResult{Status: statusOK, Duration: time.Since(start).String()}
+			if err != nil {
+				result.Status = statusFail
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[checker.Name()] = result
+			mu.Unlock()
+		}(checker)
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	body := report{Status: statusOK, Checks: results}
+	for _, result := range results {
+		if result.Status != statusOK {
+			status = http.StatusServiceUnavailable
+			body.Status = statusFail
+			break
+		}
+	}
+
+	return ctx.Status(status).JSON(body)
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+// OpenAPI documenta /healthz y /readyz.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("HealthReport", report{})
+	ok := report{Status: statusOK}
+	ready := report{Status: statusOK, Checks: map[string]checkResult{
+		"database": {Status: statusOK, Duration: "1.2ms"},
+	}}
+	failing := report{Status: statusFail, Checks: map[string]checkResult{
+		"database": {Status: statusFail, Duration: "2s", Error: "context deadline exceeded"},
+	}}
+
+	doc.Add(http.MethodGet, "/healthz", &openapi.Operation{
+		OperationID: "liveness",
+		Summary:     "Liveness probe",
+		Tags:        []string{"health"},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("The process is alive", schema, ok),
+		}),
+	})
+	doc.Add(http.MethodGet, "/readyz", &openapi.Operation{
+		OperationID: "readiness",
+		Summary:     "Readiness probe with per-dependency checks",
+		Tags:        []string{"health"},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                 openapi.JSON("Ready to receive traffic", schema, ready),
+			http.StatusServiceUnavailable: openapi.JSON("A check failed or the server is draining", schema, failing),
+		}),
+	})
+}
@@ -0,0 +1,36 @@
+// Package links valida las URL que se guardan en episodios y fuentes y
+// comprueba periódicamente que las URL del catálogo siguen respondiendo.
+package links
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+type Repository interface {
+	// Targets devuelve las URL del catálogo que hay que comprobar.
+	Targets(ctx context.Context) ([]*entities.LinkCheck, error)
+	// Record guarda el resultado de una comprobación y actualiza
+	// check.Failures con los fallos seguidos de la URL.
+	Record(ctx context.Context, check *entities.LinkCheck) error
+	// Prune borra los resultados que no se han vuelto a comprobar desde
+	// before, es decir, los de filas que ya no existen.
+	Prune(ctx context.Context, before time.Time) error
+	// LastCheckedAt devuelve el momento de la última comprobación, o el
+	// instante cero si no hay ninguna.
+	LastCheckedAt(ctx context.Context) (time.Time, error)
+	GetBroken(ctx context.Context, kind string) ([]*entities.LinkCheck, error)
+	// Lock toma un bloqueo consultivo para que sólo una instancia compruebe
+	// los enlaces a la vez. ok es false si otra instancia ya lo tiene.
+	Lock(ctx context.Context) (unlock func(), ok bool, err error)
+}
+
+type Service interface {
+	GetBroken(ctx context.Context, kind string) ([]*entities.LinkCheck, error)
+}
+
+type Handler interface {
+	GetBroken(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,304 @@
+package links
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.uber.org/fx"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// startDelay es la espera mínima antes de una comprobación, para no
+// competir con el arranque del servidor.
+const startDelay = time.Minute
+
+const userAgent = "anime-api-link-checker/1.0"
+
+// maxRedirects es el número de redirecciones que se siguen, el mismo que
+// net/http por defecto.
+const maxRedirects = 10
+
+// Checker comprueba con peticiones HEAD las URL de episodios, fuentes e
+// imágenes de temporadas y guarda el resultado en link_checks. Sólo
+// consulta hosts permitidos por la política, también al redirigir, y nunca
+// se conecta a direcciones privadas, de loopback o de enlace local.
+type Checker struct {
+	repository  Repository
+	policy      *Policy
+	client      *http.Client
+	staticDir   string
+	interval    time.Duration
+	concurrency int
+}
+
+func NewChecker(repository Repository, policy *Policy, config *config.Config) *Checker {
+	dialer := &net.Dialer{Timeout: config.Links.CheckTimeout, Control: publicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Con un proxy se marcaría la dirección del proxy, no la del destino.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Checker{
+		repository: repository,
+		policy:     policy,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Links.CheckTimeout,
+			CheckRedirect: func(request *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				if !policy.allows(request.URL) {
+					return fmt.Errorf("redirect to host %q is not allowed", request.URL.Host)
+				}
+				return nil
+			},
+		},
+		staticDir:   config.Server.StaticDir,
+		interval:    config.Links.CheckInterval,
+		concurrency: config.Links.CheckConcurrency,
+	}
+}
+
+// Start lanza la comprobación periódica con la aplicación y la detiene al
+// parar. Con varias instancias, la fecha de la última comprobación y el
+// bloqueo del repositorio evitan repetir el trabajo.
+func Start(lc fx.Lifecycle, checker *Checker) {
+	if checker.interval == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				checker.loop(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
+
+func (c *Checker) loop(ctx context.Context) {
+	for {
+		timer := time.NewTimer(c.untilNext(ctx))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := c.run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Link check failed: %s", err)
+		}
+	}
+}
+
+// untilNext calcula la espera hasta la siguiente comprobación a partir de la
+// última, que puede haber hecho otra instancia.
+func (c *Checker) untilNext(ctx context.Context) time.Duration {
+	last, err := c.repository.LastCheckedAt(ctx)
+	if err != nil {
+		return c.interval
+	}
+
+	wait := time.Until(last.Add(c.interval))
+	if wait < startDelay {
+		return startDelay
+	}
+
+	return wait
+}
+
+func (c *Checker) run(ctx context.Context) error {
+	unlock, ok, err := c.repository.Lock(ctx)
+	if err != nil || !ok {
+		return err
+	}
+	defer unlock()
+
+	// Otra instancia puede haber terminado mientras se esperaba.
+	last, err := c.repository.LastCheckedAt(ctx)
+	if err != nil {
+		return err
+	}
+	if time.Since(last) < c.interval-startDelay {
+		return nil
+	}
+
+	started := time.Now()
+	targets, err := c.repository.Targets(ctx)
+	if err != nil {
+		return err
+	}
+
+	jobs := make(chan *entities.LinkCheck)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	broken := 0
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range jobs {
+				c.check(ctx, target)
+				if err := c.repository.Record(ctx, target); err != nil {
+					if ctx.Err() != nil {
+						continue
+					}
+					log.Printf("Failed to record check of %s: %s", target.Url, err)
+					continue
+				}
+				if target.Broken {
+					mu.Lock()
+					broken++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+send:
+	for _, target := range targets {
+		select {
+		case jobs <- target:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Una comprobación interrumpida no debe borrar resultados que no llegó
+	// a renovar.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	log.Printf("Checked %d links, %d broken", len(targets), broken)
+
+	return c.repository.Prune(ctx, started)
+}
+
+// check rellena el resultado de la comprobación de target. Las rutas
+// relativas son archivos servidos desde server.static_dir y se buscan en
+// disco.
+func (c *Checker) check(ctx context.Context, target *entities.LinkCheck) {
+	target.StatusCode, target.Error = 0, ""
+
+	u, err := url.Parse(target.Url)
+	switch {
+	case err != nil:
+		target.Error = "invalid URL"
+	case (u.Scheme == "http" || u.Scheme == "https") && !c.policy.allows(u):
+		target.Error = fmt.Sprintf("host %q is not allowed", u.Host)
+	case u.Scheme == "http" || u.Scheme == "https":
+		target.StatusCode, err = c.request(ctx, target.Url)
+		if err != nil {
+			target.Error = err.Error()
+		}
+	case u.Scheme == "" && u.Host == "" && path.IsAbs(u.Path):
+		target.StatusCode = c.stat(u.Path)
+	default:
+		target.Error = "unsupported URL scheme"
+	}
+
+	target.Broken = target.Error != "" || target.StatusCode >= http.StatusBadRequest
+	target.CheckedAt = time.Now()
+}
+
+// request hace una petición HEAD y repite con GET si el servidor no la
+// admite. Se siguen las redirecciones.
+func (c *Checker) request(ctx context.Context, rawUrl string) (int, error) {
+	status, err := c.do(ctx, http.MethodHead, rawUrl)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		return c.do(ctx, http.MethodGet, rawUrl)
+	}
+
+	return status, err
+}
+
+func (c *Checker) do(ctx context.Context, method, rawUrl string) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, method, rawUrl, nil)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("User-Agent", userAgent)
+	if method == http.MethodGet {
+		request.Header.Set("Range", "bytes=0-0")
+	}
+
+	res, err := c.client.Do(request)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	return res.StatusCode, nil
+}
+
+// publicOnly es el Control del dialer: rechaza la conexión si la dirección,
+// ya resuelta, no es pública.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %q", host)
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("address %s is not public", ip)
+	}
+
+	return nil
+}
+
+func (c *Checker) stat(urlPath string) int {
+	name := filepath.Join(c.staticDir, filepath.FromSlash(path.Clean(urlPath)))
+	info, err := os.Stat(name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	case err != nil:
+		return http.StatusInternalServerError
+	case info.IsDir():
+		return http.StatusNotFound
+	}
+
+	return http.StatusOK
+}
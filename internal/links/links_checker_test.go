@@ -0,0 +1,131 @@
+package links
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/entities"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestChecker(hosts ...string) *Checker {
+	cfg := &config.Config{}
+	cfg.Links.AllowedHosts = hosts
+	cfg.Links.CheckTimeout = time.Second
+	return NewChecker(nil, NewPolicy(cfg), cfg)
+}
+
+func TestPublicOnly(t *testing.T) {
+	tests := []struct {
+		address string
+		public  bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"[::1]:80", false},
+		{"10.0.0.1:80", false},
+		{"172.16.5.4:80", false},
+		{"192.168.1.1:80", false},
+		{"[fd00::1]:80", false},
+		{"169.254.169.254:80", false},
+		{"[fe80::1]:80", false},
+		{"0.0.0.0:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+		{"[::ffff:10.0.0.1]:80", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := publicOnly("tcp", tt.address, nil)
+			if (err == nil) != tt.public {
+				t.Errorf("publicOnly(%q) = %v, want public %t", tt.address, err, tt.public)
+			}
+		})
+	}
+}
+
+func TestCheckerRedirects(t *testing.T) {
+	checker := newTestChecker("video.example.com")
+
+	via := func(n int) []*http.Request {
+		requests := make([]*http.Request, n)
+		for i := range requests {
+			requests[i] = httptest.NewRequest(http.MethodHead, "https://video.example.com/1", nil)
+		}
+		return requests
+	}
+
+	tests := []struct {
+		name string
+		to   string
+		via  int
+		want string
+	}{
+		{"allowed host", "https://video.example.com/2", 1, ""},
+		{"host outside the allow-list", "http://169.254.169.254/latest/meta-data", 1, `redirect to host "169.254.169.254" is not allowed`},
+		{"too many redirects", "https://video.example.com/2", maxRedirects, "stopped after 10 redirects"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checker.client.CheckRedirect(httptest.NewRequest(http.MethodHead, tt.to, nil), via(tt.via))
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckerCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	}))
+	defer server.Close()
+
+	local := strings.TrimPrefix(server.URL, "http://")
+	checker := newTestChecker(local, "video.example.com")
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"host outside the allow-list", "https://evil.example.com/1", `host "evil.example.com" is not allowed`},
+		{"loopback address", server.URL + "/1", "is not public"},
+		{"unsupported scheme", "ftp://video.example.com/1", "unsupported URL scheme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &entities.LinkCheck{Url: tt.url}
+			checker.check(context.Background(), target)
+
+			if !target.Broken {
+				t.Error("expected the link to be broken")
+			}
+			if !strings.Contains(target.Error, tt.want) {
+				t.Errorf("got error %q, want it to contain %q", target.Error, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckerWithoutAllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	}))
+	defer server.Close()
+
+	// Sin lista se comprueba cualquier host, pero nunca direcciones privadas.
+	target := &entities.LinkCheck{Url: server.URL + "/1"}
+	newTestChecker().check(context.Background(), target)
+
+	if !target.Broken || !strings.Contains(target.Error, "is not public") {
+		t.Errorf("got broken %t and error %q, want a loopback error", target.Broken, target.Error)
+	}
+}
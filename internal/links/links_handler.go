@@ -0,0 +1,38 @@
+package links
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/response"
+	"net/http"
+)
+
+// kinds son los valores aceptados por el parámetro kind.
+var kinds = map[string]bool{
+	"":                       true,
+	entities.LinkEpisode:     true,
+	entities.LinkSource:      true,
+	entities.LinkSeasonImage: true,
+}
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) Handler {
+	return &handler{service: service}
+}
+
+func (h *handler) GetBroken(ctx *fiber.Ctx) error {
+	kind := ctx.Query("kind")
+	if !kinds[kind] {
+		return response.NewBadRequestResponse("Invalid kind")
+	}
+
+	checks, err := h.service.GetBroken(ctx.UserContext(), kind)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get broken links")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(checks)
+}
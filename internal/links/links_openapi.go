@@ -0,0 +1,47 @@
+package links
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+var example = entities.LinkCheck{
+	Kind:       entities.LinkEpisode,
+	OwnerID:    1,
+	Url:        "https://video.example.com/snk/1",
+	StatusCode: http.StatusNotFound,
+	Broken:     true,
+	Failures:   3,
+	CheckedAt:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+}
+
+// OpenAPI documenta la consulta de enlaces rotos.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("LinkCheck", entities.LinkCheck{})
+
+	doc.Add(http.MethodGet, "/api/v1/admin/broken-links", &openapi.Operation{
+		OperationID: "listBrokenLinks",
+		Summary:     "List broken links",
+		Description: "Episode URLs, source URLs and season images are checked periodically with HEAD requests " +
+			"(GET when HEAD is not supported) every links.check_interval. A link is broken when the request fails " +
+			"or answers with a 4xx or 5xx status; failures counts the consecutive failed checks of the same URL. " +
+			"Requires an administrator token.",
+		Tags:     []string{"admin"},
+		Security: openapi.Authenticated(),
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParam("kind", "Only links of this kind", &openapi.Schema{
+				Type: "string", Enum: []any{entities.LinkEpisode, entities.LinkSource, entities.LinkSeasonImage},
+			}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Broken links, most failed first",
+				openapi.ArrayOf(schema), []entities.LinkCheck{example}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid kind"),
+			http.StatusUnauthorized:        openapi.Error(http.StatusUnauthorized, "Authentication required"),
+			http.StatusForbidden:           openapi.Error(http.StatusForbidden, "Administrator role required"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get broken links"),
+		}),
+	})
+}
@@ -0,0 +1,71 @@
+package links
+
+import (
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/ex"
+	"net/url"
+	"strings"
+)
+
+// Policy decide qué URL se aceptan al crear o editar episodios y fuentes.
+type Policy struct {
+	hosts []string
+}
+
+func NewPolicy(config *config.Config) *Policy {
+	hosts := make([]string, 0, len(config.Links.AllowedHosts))
+	for _, host := range config.Links.AllowedHosts {
+		hosts = append(hosts, strings.ToLower(host))
+	}
+
+	return &Policy{hosts: hosts}
+}
+
+// Check exige una URL http(s) absoluta, sin credenciales y cuyo host esté
+// permitido. Los errores son ex.ErrValidation sobre field.
+func (p *Policy) Check(field, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return &ex.ErrValidation{Field: field, Reason: "must be an absolute http or https URL"}
+	}
+	if u.User != nil {
+		return &ex.ErrValidation{Field: field, Reason: "must not contain credentials"}
+	}
+	if !p.allows(u) {
+		return &ex.ErrValidation{Field: field, Reason: fmt.Sprintf("host %q is not allowed", u.Host)}
+	}
+
+	return nil
+}
+
+// allows compara el host con los patrones permitidos. Los patrones con
+// puerto sólo aceptan ese puerto; los demás, cualquiera. "*.example.com"
+// acepta los subdominios de example.com, pero no example.com.
+func (p *Policy) allows(u *url.URL) bool {
+	if len(p.hosts) == 0 {
+		return true
+	}
+	name := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	host := name
+	if port := u.Port(); port != "" {
+		host = name + ":" + port
+	}
+
+	for _, pattern := range p.hosts {
+		candidate := name
+		if strings.Contains(pattern, ":") {
+			candidate = host
+		}
+
+		if domain, found := strings.CutPrefix(pattern, "*."); found {
+			if strings.HasSuffix(candidate, "."+domain) {
+				return true
+			}
+		} else if candidate == pattern {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,192 @@
+package links
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"time"
+)
+
+// lockID identifica el bloqueo consultivo de la comprobación de enlaces.
+const lockID = 0x6c696e6b // "link"
+
+const (
+	queryTargets = `SELECT 'episode', id, url FROM episodes WHERE url <> ''
+		UNION ALL SELECT 'source', id, url FROM episode_sources
+		UNION ALL SELECT 'season_image', id, image_url FROM seasons WHERE image_url <> ''`
+	// failures vuelve a empezar cuando la URL responde o cambia.
+	queryRecord = `INSERT INTO link_checks (kind, owner_id, url, status_code, error, broken, failures, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CASE WHEN $6 THEN 1 ELSE 0 END, $7)
+		ON CONFLICT (kind, owner_id) DO UPDATE
+		SET url = EXCLUDED.url, status_code = EXCLUDED.status_code, error = EXCLUDED.error, broken = EXCLUDED.broken,
+			failures = CASE
+				WHEN NOT EXCLUDED.broken THEN 0
+				WHEN link_checks.url = EXCLUDED.url THEN link_checks.failures + 1
+				ELSE 1
+			END,
+			checked_at = EXCLUDED.checked_at
+		RETURNING failures`
+	queryPrune         = "DELETE FROM link_checks WHERE checked_at < $1"
+	queryLastCheckedAt = "SELECT MAX(checked_at) FROM link_checks"
+	queryGetBroken     = `SELECT kind, owner_id, url, status_code, error, broken, failures, checked_at
+		FROM link_checks
+		WHERE broken AND ($1 = '' OR kind = $1)
+		ORDER BY failures DESC, checked_at DESC`
+	queryLock   = "SELECT pg_try_advisory_lock($1)"
+	queryUnlock = "SELECT pg_advisory_unlock($1)"
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) Targets(ctx context.Context) ([]*entities.LinkCheck, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryTargets", queryTargets)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryTargets)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	targets := []*entities.LinkCheck{}
+	for rows.Next() {
+		target := &entities.LinkCheck{}
+		if err := rows.Scan(&target.Kind, &target.OwnerID, &target.Url); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		targets = append(targets, target)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return targets, nil
+}
+
+func (r *repository) Record(ctx context.Context, check *entities.LinkCheck) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryRecord", queryRecord)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryRecord, check.Kind, check.OwnerID, check.Url, check.StatusCode,
+		check.Error, check.Broken, check.CheckedAt).Scan(&check.Failures)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to record link check: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Prune(ctx context.Context, before time.Time) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryPrune", queryPrune)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, queryPrune, before); err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to prune link checks: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) LastCheckedAt(ctx context.Context) (time.Time, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryLastCheckedAt", queryLastCheckedAt)
+	defer span.End()
+
+	// Se lee de la primaria: las instancias se coordinan con este valor y
+	// la réplica podría ir retrasada.
+	var last sql.NullTime
+	if err := r.db.QueryRowContext(ctx, queryLastCheckedAt).Scan(&last); err != nil {
+		telemetry.RecordError(span, err)
+		return time.Time{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return last.Time, nil
+}
+
+func (r *repository) GetBroken(ctx context.Context, kind string) ([]*entities.LinkCheck, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBroken", queryGetBroken)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetBroken, kind)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	checks := []*entities.LinkCheck{}
+	for rows.Next() {
+		check := &entities.LinkCheck{}
+		if err := rows.Scan(&check.Kind, &check.OwnerID, &check.Url, &check.StatusCode, &check.Error,
+			&check.Broken, &check.Failures, &check.CheckedAt); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		checks = append(checks, check)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return checks, nil
+}
+
+// Lock usa una conexión propia porque los bloqueos consultivos pertenecen a
+// la sesión: hay que liberarlo en la misma conexión que lo tomó.
+func (r *repository) Lock(ctx context.Context) (func(), bool, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryLock", queryLock)
+	defer span.End()
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, false, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, queryLock, lockID).Scan(&locked); err != nil || !locked {
+		_ = conn.Close()
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return nil, false, fmt.Errorf("failed to take lock: %w", err)
+		}
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		if _, err := conn.ExecContext(context.Background(), queryUnlock, lockID); err != nil {
+			log.Printf("Failed to release link check lock: %s", err)
+		}
+		_ = conn.Close()
+	}
+
+	return unlock, true, nil
+}
@@ -0,0 +1,26 @@
+package links
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/links")
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) GetBroken(ctx context.Context, kind string) ([]*entities.LinkCheck, error) {
+	ctx, span := tracer.Start(ctx, "links.Service.GetBroken")
+	defer span.End()
+
+	return s.repository.GetBroken(ctx, kind)
+}
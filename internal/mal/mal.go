@@ -0,0 +1,82 @@
+// Package mal importa en la lista de seguimiento las listas exportadas de
+// MyAnimeList.
+package mal
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+// Formas de emparejar una entrada de la exportación con una temporada.
+const (
+	MatchExternalID = "external_id"
+	MatchSlug       = "slug"
+	MatchTitle      = "title"
+)
+
+// Entry es un anime de la exportación con su estado ya traducido a los de
+// la lista de seguimiento.
+type Entry struct {
+	MalID      uint64
+	Title      string
+	Type       string
+	Episodes   int
+	Status     string
+	Score      int
+	Progress   int
+	StartedOn  *time.Time
+	FinishedOn *time.Time
+	Notes      string
+}
+
+// Match es una entrada emparejada con una temporada del catálogo; By indica
+// cómo se emparejó.
+type Match struct {
+	Entry  *Entry
+	Season *entities.Season
+	By     string
+}
+
+// Preview es el resultado de emparejar una exportación sin guardar nada.
+type Preview struct {
+	Matched   []*Match
+	Unmatched []*Entry
+}
+
+// Result es el resultado de una importación. Skipped cuenta las temporadas
+// emparejadas que ya estaban en la lista y no se reemplazaron.
+type Result struct {
+	Preview  *Preview
+	Imported []*entities.WatchlistEntry
+	Skipped  int
+}
+
+type Repository interface {
+	// GetExternalIDs devuelve las temporadas por su identificador en
+	// MyAnimeList.
+	GetExternalIDs(ctx context.Context) (map[string]uint64, error)
+	// SetExternalID enlaza la temporada con un identificador de
+	// MyAnimeList, reemplazando el que tuviera.
+	SetExternalID(ctx context.Context, id *entities.ExternalID) error
+	DeleteExternalID(ctx context.Context, seasonID uint64) error
+}
+
+type Service interface {
+	// Preview empareja las entradas de la exportación, en XML o comprimida
+	// con gzip, con las temporadas del catálogo.
+	Preview(ctx context.Context, export []byte) (*Preview, error)
+	// Import empareja la exportación y guarda las entradas emparejadas en la
+	// lista del usuario en una sola transacción.
+	Import(ctx context.Context, userID uint64, export []byte, overwrite bool) (*Result, error)
+	SetExternalID(ctx context.Context, id *entities.ExternalID) error
+	DeleteExternalID(ctx context.Context, seasonID uint64) error
+}
+
+type Handler interface {
+	Preview(ctx *fiber.Ctx) error
+	Import(ctx *fiber.Ctx) error
+	SetExternalID(ctx *fiber.Ctx) error
+	DeleteExternalID(ctx *fiber.Ctx) error
+}
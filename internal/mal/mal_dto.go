@@ -0,0 +1,113 @@
+package mal
+
+import (
+	"time"
+)
+
+type externalIDRequest struct {
+	MalID uint64 `json:"mal_id" validate:"required,min=1"`
+}
+
+type externalIDResponse struct {
+	SeasonID uint64 `json:"season_id"`
+	MalID    string `json:"mal_id"`
+}
+
+type entryResponse struct {
+	MalID      uint64  `json:"mal_id"`
+	Title      string  `json:"title"`
+	Type       string  `json:"type"`
+	Episodes   int     `json:"episodes"`
+	Status     string  `json:"status"`
+	Score      int     `json:"score,omitempty"`
+	Progress   int     `json:"progress"`
+	StartedOn  *string `json:"started_on"`
+	FinishedOn *string `json:"finished_on"`
+}
+
+func newEntryResponse(entry *Entry) *entryResponse {
+	return &entryResponse{
+		MalID:      entry.MalID,
+		Title:      entry.Title,
+		Type:       entry.Type,
+		Episodes:   entry.Episodes,
+		Status:     entry.Status,
+		Score:      entry.Score,
+		Progress:   entry.Progress,
+		StartedOn:  formatDate(entry.StartedOn),
+		FinishedOn: formatDate(entry.FinishedOn),
+	}
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+
+	return &formatted
+}
+
+type matchSeason struct {
+	ID     uint64 `json:"id"`
+	Slug   string `json:"slug"`
+	Title  string `json:"title"`
+	Number uint8  `json:"number"`
+}
+
+type matchResponse struct {
+	Entry     *entryResponse `json:"entry"`
+	Season    matchSeason    `json:"season"`
+	MatchedBy string         `json:"matched_by"`
+}
+
+type previewResponse struct {
+	Matched   []*matchResponse `json:"matched"`
+	Unmatched []*entryResponse `json:"unmatched"`
+}
+
+func newPreviewResponse(preview *Preview) *previewResponse {
+	response := &previewResponse{
+		Matched:   make([]*matchResponse, 0, len(preview.Matched)),
+		Unmatched: newEntryResponses(preview.Unmatched),
+	}
+	for _, match := range preview.Matched {
+		response.Matched = append(response.Matched, &matchResponse{
+			Entry: newEntryResponse(match.Entry),
+			Season: matchSeason{
+				ID:     match.Season.ID,
+				Slug:   match.Season.Slug,
+				Title:  match.Season.Name,
+				Number: match.Season.Number,
+			},
+			MatchedBy: match.By,
+		})
+	}
+
+	return response
+}
+
+func newEntryResponses(entries []*Entry) []*entryResponse {
+	responses := make([]*entryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, newEntryResponse(entry))
+	}
+
+	return responses
+}
+
+// importResponse resume la importación; las entradas sin emparejar se
+// devuelven para que el usuario las añada a mano.
+type importResponse struct {
+	Imported  int              `json:"imported"`
+	Skipped   int              `json:"skipped"`
+	Unmatched []*entryResponse `json:"unmatched"`
+}
+
+func newImportResponse(result *Result) *importResponse {
+	return &importResponse{
+		Imported:  len(result.Imported),
+		Skipped:   result.Skipped,
+		Unmatched: newEntryResponses(result.Preview.Unmatched),
+	}
+}
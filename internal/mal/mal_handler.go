@@ -0,0 +1,143 @@
+package mal
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) Preview(ctx *fiber.Ctx) error {
+	if _, ok := auth.CurrentUser(ctx); !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	export, err := readExport(ctx)
+	if err != nil {
+		return err
+	}
+
+	preview, err := h.service.Preview(ctx.UserContext(), export)
+	if err != nil {
+		return writeError(err, "Failed to read export")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newPreviewResponse(preview))
+}
+
+func (h *handler) Import(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	overwrite := false
+	if value := ctx.FormValue("overwrite"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return response.NewBadRequestResponse("Invalid overwrite")
+		}
+		overwrite = parsed
+	}
+
+	export, err := readExport(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.Import(ctx.UserContext(), user.ID, export, overwrite)
+	if err != nil {
+		return writeError(err, "Failed to import list")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newImportResponse(result))
+}
+
+func (h *handler) SetExternalID(ctx *fiber.Ctx) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request externalIDRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	id := &entities.ExternalID{SeasonID: seasonID, ExternalID: strconv.FormatUint(request.MalID, 10)}
+	if err := h.service.SetExternalID(ctx.UserContext(), id); err != nil {
+		var alreadyExists *ex.ErrAlreadyExists
+		if errors.As(err, &alreadyExists) {
+			return response.NewConflictResponse("The MyAnimeList id is already linked to another season")
+		}
+		return writeError(err, "Failed to save MyAnimeList id")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(&externalIDResponse{SeasonID: id.SeasonID, MalID: id.ExternalID})
+}
+
+func (h *handler) DeleteExternalID(ctx *fiber.Ctx) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.DeleteExternalID(ctx.UserContext(), seasonID); err != nil {
+		return writeError(err, "Failed to delete MyAnimeList id")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+// readExport lee la exportación del campo "file" de un formulario
+// multipart.
+func readExport(ctx *fiber.Ctx) ([]byte, error) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, response.NewBadRequestResponse("The file field is required")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, response.NewInternalServerErrorResponse("Failed to read file")
+	}
+	defer file.Close()
+
+	export, err := io.ReadAll(file)
+	if err != nil {
+		return nil, response.NewInternalServerErrorResponse("Failed to read file")
+	}
+
+	return export, nil
+}
+
+func writeError(err error, message string) error {
+	var validation *ex.ErrValidation
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
@@ -0,0 +1,116 @@
+package mal
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sequel separa el número de temporada del final de un título ya
+// normalizado: "x season 2", "x 2nd season", "x s2" o "x 2", con un
+// "part n" opcional detrás.
+var sequel = regexp.MustCompile(`^(.+?) (?:season (\d+)|(\d+)(?:st|nd|rd|th) season|s(\d+)|(\d+))(?: part \d+)?$`)
+
+// macrons pasa a ASCII las vocales largas de las transcripciones del
+// japonés.
+var macrons = strings.NewReplacer("ā", "a", "ē", "e", "ī", "i", "ō", "o", "ū", "u", "â", "a", "ê", "e", "î", "i", "ô", "o", "û", "u")
+
+// matcher empareja entradas de la exportación con temporadas, primero por
+// identificador de MyAnimeList, después por slug y por último por título.
+type matcher struct {
+	byExternalID map[string]*entities.Season
+	bySlug       map[string][]*entities.Season
+	byTitle      map[string][]*entities.Season
+}
+
+func newMatcher(seasons []*entities.Season, externalIDs map[string]uint64) *matcher {
+	m := &matcher{
+		byExternalID: map[string]*entities.Season{},
+		bySlug:       map[string][]*entities.Season{},
+		byTitle:      map[string][]*entities.Season{},
+	}
+
+	byID := map[uint64]*entities.Season{}
+	for _, season := range seasons {
+		byID[season.ID] = season
+		slug := strings.ToLower(season.Slug)
+		m.bySlug[slug] = append(m.bySlug[slug], season)
+		title := normalize(season.Name)
+		m.byTitle[title] = append(m.byTitle[title], season)
+	}
+	for externalID, seasonID := range externalIDs {
+		if season, ok := byID[seasonID]; ok {
+			m.byExternalID[externalID] = season
+		}
+	}
+
+	return m
+}
+
+// match devuelve la temporada de la entrada y cómo se emparejó, o nil.
+func (m *matcher) match(entry *Entry) (*entities.Season, string) {
+	if season, ok := m.byExternalID[strconv.FormatUint(entry.MalID, 10)]; ok {
+		return season, MatchExternalID
+	}
+
+	// Primero se prueba el título completo, porque un número final puede
+	// ser parte del nombre ("Mob Psycho 100", "86"); sólo si no coincide se
+	// lee como número de temporada.
+	title := normalize(entry.Title)
+	slug := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(entry.Title), " ", "-"))
+	if season := pick(m.bySlug[slug], 0); season != nil {
+		return season, MatchSlug
+	}
+	if season := pick(m.byTitle[title], 0); season != nil {
+		return season, MatchTitle
+	}
+
+	parts := sequel.FindStringSubmatch(title)
+	if parts == nil {
+		return nil, ""
+	}
+	base, number := parts[1], 0
+	for _, n := range parts[2:] {
+		if n != "" {
+			number, _ = strconv.Atoi(n)
+		}
+	}
+	if season := pick(m.byTitle[base], number); season != nil {
+		return season, MatchTitle
+	}
+
+	return nil, ""
+}
+
+// pick elige entre las temporadas con el mismo título la del número
+// indicado, o la primera si number es 0. Si sólo hay una y no se pide un
+// número, es esa aunque no sea la primera.
+func pick(candidates []*entities.Season, number int) *entities.Season {
+	if len(candidates) == 1 && number == 0 {
+		return candidates[0]
+	}
+
+	want := number
+	if want == 0 {
+		want = 1
+	}
+	for _, season := range candidates {
+		if int(season.Number) == want {
+			return season
+		}
+	}
+
+	return nil
+}
+
+// normalize pasa un título a minúsculas y deja sólo letras y números
+// separados por un espacio.
+func normalize(title string) string {
+	title = macrons.Replace(strings.ToLower(title))
+
+	return strings.Join(strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
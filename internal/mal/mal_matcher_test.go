@@ -0,0 +1,55 @@
+package mal
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"testing"
+)
+
+func TestMatcherMatch(t *testing.T) {
+	seasons := []*entities.Season{
+		{ID: 1, Name: "Shingeki no Kyojin", Slug: "shingeki-no-kyojin", Number: 1},
+		{ID: 2, Name: "Shingeki no Kyojin", Slug: "shingeki-no-kyojin-2", Number: 2},
+		{ID: 3, Name: "Mob Psycho 100", Slug: "mob-psycho-100", Number: 1},
+		{ID: 4, Name: "Mob Psycho 100", Slug: "mob-psycho-100-ii", Number: 2},
+		{ID: 5, Name: "Kaiju No. 8", Slug: "kaiju-no-8", Number: 1},
+		{ID: 6, Name: "86", Slug: "eighty-six", Number: 1},
+		{ID: 7, Name: "Dr. Stone", Slug: "dr-stone", Number: 3},
+	}
+	m := newMatcher(seasons, map[string]uint64{"16498": 1})
+
+	tests := []struct {
+		title string
+		malID uint64
+		want  uint64
+		by    string
+	}{
+		{"Anything", 16498, 1, MatchExternalID},
+		{"shingeki-no-kyojin-2", 0, 2, MatchSlug},
+		{"Shingeki no Kyojin", 0, 1, MatchSlug},
+		{"Shingeki no Kyojin Season 2", 0, 2, MatchTitle},
+		{"Shingeki no Kyojin 2nd Season", 0, 2, MatchTitle},
+		{"Shingeki no Kyojin S2 Part 2", 0, 2, MatchTitle},
+		{"Mob Psycho 100", 0, 3, MatchSlug},
+		{"Mob Psycho 100 II", 0, 4, MatchSlug},
+		{"Mob Psycho 100 Season 2", 0, 4, MatchTitle},
+		{"Kaiju No. 8", 0, 5, MatchTitle},
+		{"86", 0, 6, MatchTitle},
+		{"86 Part 2", 0, 0, ""},
+		{"Dr. Stone", 0, 7, MatchTitle},
+		{"Shingeki no Kyojin Season 4", 0, 0, ""},
+		{"Unknown", 0, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			season, by := m.match(&Entry{MalID: tt.malID, Title: tt.title})
+
+			var got uint64
+			if season != nil {
+				got = season.ID
+			}
+			if got != tt.want || by != tt.by {
+				t.Errorf("match(%q) = %d by %q, want %d by %q", tt.title, got, by, tt.want, tt.by)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package mal
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+var (
+	startedOn  = "2013-04-07"
+	finishedOn = "2013-09-29"
+)
+
+var exampleEntry = &entryResponse{
+	MalID:      16498,
+	Title:      "Shingeki no Kyojin",
+	Type:       "TV",
+	Episodes:   25,
+	Status:     entities.WatchCompleted,
+	Score:      9,
+	Progress:   25,
+	StartedOn:  &startedOn,
+	FinishedOn: &finishedOn,
+}
+
+var exampleUnmatched = &entryResponse{
+	MalID:    5114,
+	Title:    "Fullmetal Alchemist: Brotherhood",
+	Type:     "TV",
+	Episodes: 64,
+	Status:   entities.WatchPlanToWatch,
+}
+
+// OpenAPI documenta la importación de listas de MyAnimeList y los
+// identificadores de MyAnimeList de las temporadas.
+func OpenAPI(doc *openapi.Document) {
+	preview := doc.Register("MalImportPreviewV2", previewResponse{})
+	result := doc.Register("MalImportResultV2", importResponse{})
+	externalID := doc.Register("MalIdV2", externalIDResponse{})
+	request := doc.Register("MalIdRequestV2", externalIDRequest{})
+
+	upload := func(properties map[string]*openapi.Schema) *openapi.RequestBody {
+		properties["file"] = &openapi.Schema{Type: "string", Format: "binary"}
+		return &openapi.RequestBody{
+			Required: true,
+			Content: map[string]*openapi.MediaType{
+				"multipart/form-data": {Schema: &openapi.Schema{Type: "object", Required: []string{"file"}, Properties: properties}},
+			},
+		}
+	}
+	matching := "The file is the anime list exported from MyAnimeList, as XML or gzipped as downloaded. " +
+		"Entries are matched with seasons by the MyAnimeList id linked to the season, then by slug and finally by title, " +
+		"reading sequel numbers such as \"Season 2\" or \"2nd Season\" as the season number."
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	invalid := openapi.Error(http.StatusBadRequest, "Field 'file' is not a MyAnimeList anime list export")
+
+	doc.Add(http.MethodPost, "/api/v2/watchlist/import/preview", &openapi.Operation{
+		OperationID: "previewMalImportV2",
+		Summary:     "Preview the import of a MyAnimeList list",
+		Description: matching + " Nothing is saved.",
+		Tags:        []string{"watchlist"},
+		Security:    openapi.Authenticated(),
+		RequestBody: upload(map[string]*openapi.Schema{}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Matched and unmatched entries", preview, previewResponse{
+				Matched: []*matchResponse{{
+					Entry:     exampleEntry,
+					Season:    matchSeason{ID: 1, Slug: "shingeki-no-kyojin", Title: "shingeki no kyojin", Number: 1},
+					MatchedBy: MatchTitle,
+				}},
+				Unmatched: []*entryResponse{exampleUnmatched},
+			}),
+			http.StatusBadRequest:          invalid,
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to read export"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/watchlist/import", &openapi.Operation{
+		OperationID: "importMalListV2",
+		Summary:     "Import a MyAnimeList list",
+		Description: matching + " The matched entries are saved in the watchlist in a single transaction, keeping their " +
+			"dates; seasons already in the watchlist are skipped unless overwrite is true. When several entries match " +
+			"the same season, the one with the most watched episodes is kept.",
+		Tags:        []string{"watchlist"},
+		Security:    openapi.Authenticated(),
+		RequestBody: upload(map[string]*openapi.Schema{"overwrite": {Type: "boolean"}}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Import result", result, importResponse{
+				Imported: 1, Unmatched: []*entryResponse{exampleUnmatched},
+			}),
+			http.StatusBadRequest:          invalid,
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to import list"),
+		}),
+	})
+
+	adminForbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	doc.Add(http.MethodPut, "/api/v2/admin/seasons/:id/mal-id", &openapi.Operation{
+		OperationID: "setSeasonMalIdV2",
+		Summary:     "Link a season with its MyAnimeList id",
+		Description: "Imported entries with this id are matched with the season before trying slugs and titles. " +
+			"Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, externalIDRequest{MalID: 16498}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Linked id", externalID, externalIDResponse{SeasonID: 1, MalID: "16498"}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "MalID is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           adminForbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusConflict:            openapi.Error(http.StatusConflict, "The MyAnimeList id is already linked to another season"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save MyAnimeList id"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/admin/seasons/:id/mal-id", &openapi.Operation{
+		OperationID: "deleteSeasonMalIdV2",
+		Summary:     "Unlink a season from its MyAnimeList id",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           adminForbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "MyAnimeList id of season 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete MyAnimeList id"),
+		}),
+	})
+}
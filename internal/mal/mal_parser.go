@@ -0,0 +1,129 @@
+package mal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxExportSize limita el tamaño de la exportación descomprimida. Una lista
+// de miles de animes ocupa unos pocos megas.
+const maxExportSize = 32 << 20
+
+// statuses traduce los estados de MyAnimeList, que algunas exportaciones
+// escriben como número.
+var statuses = map[string]string{
+	"watching":      entities.WatchWatching,
+	"1":             entities.WatchWatching,
+	"completed":     entities.WatchCompleted,
+	"2":             entities.WatchCompleted,
+	"on-hold":       entities.WatchOnHold,
+	"3":             entities.WatchOnHold,
+	"dropped":       entities.WatchDropped,
+	"4":             entities.WatchDropped,
+	"plan to watch": entities.WatchPlanToWatch,
+	"6":             entities.WatchPlanToWatch,
+}
+
+type exportXML struct {
+	XMLName xml.Name   `xml:"myanimelist"`
+	Anime   []animeXML `xml:"anime"`
+}
+
+type animeXML struct {
+	ID              string `xml:"series_animedb_id"`
+	Title           string `xml:"series_title"`
+	Type            string `xml:"series_type"`
+	Episodes        string `xml:"series_episodes"`
+	WatchedEpisodes string `xml:"my_watched_episodes"`
+	StartDate       string `xml:"my_start_date"`
+	FinishDate      string `xml:"my_finish_date"`
+	Score           string `xml:"my_score"`
+	Status          string `xml:"my_status"`
+	Comments        string `xml:"my_comments"`
+}
+
+// parse lee una exportación de animes de MyAnimeList, en XML o comprimida
+// con gzip.
+func parse(export []byte) ([]*Entry, error) {
+	invalid := &ex.ErrValidation{Field: "file", Reason: "is not a MyAnimeList anime list export"}
+
+	if bytes.HasPrefix(export, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(export))
+		if err != nil {
+			return nil, invalid
+		}
+		export, err = io.ReadAll(io.LimitReader(reader, maxExportSize+1))
+		if err != nil {
+			return nil, invalid
+		}
+	}
+	if len(export) > maxExportSize {
+		return nil, &ex.ErrValidation{Field: "file", Reason: fmt.Sprintf("must not be larger than %d bytes uncompressed", maxExportSize)}
+	}
+
+	var list exportXML
+	if err := xml.Unmarshal(export, &list); err != nil || len(list.Anime) == 0 {
+		return nil, invalid
+	}
+
+	entries := make([]*Entry, 0, len(list.Anime))
+	for _, anime := range list.Anime {
+		status, ok := statuses[strings.ToLower(strings.TrimSpace(anime.Status))]
+		if !ok {
+			status = entities.WatchPlanToWatch
+		}
+
+		entry := &Entry{
+			MalID:      uint64(number(anime.ID)),
+			Title:      strings.TrimSpace(anime.Title),
+			Type:       strings.TrimSpace(anime.Type),
+			Episodes:   number(anime.Episodes),
+			Status:     status,
+			Score:      number(anime.Score),
+			Progress:   number(anime.WatchedEpisodes),
+			StartedOn:  date(anime.StartDate),
+			FinishedOn: date(anime.FinishDate),
+			Notes:      strings.TrimSpace(anime.Comments),
+		}
+		if entry.Score > entities.MaxScore {
+			entry.Score = 0
+		}
+		// Una fecha de fin anterior al inicio es un error de la lista
+		// original; se descarta en lugar de rechazar la entrada.
+		if entry.StartedOn != nil && entry.FinishedOn != nil && entry.FinishedOn.Before(*entry.StartedOn) {
+			entry.FinishedOn = nil
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// number devuelve 0 para los valores vacíos o no válidos.
+func number(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
+// date devuelve nil para las fechas vacías o incompletas, que MyAnimeList
+// escribe como 0000-00-00 o con el día o el mes a cero.
+func date(value string) *time.Time {
+	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
@@ -0,0 +1,171 @@
+package mal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/wicho90/anime-api/internal/entities"
+	"testing"
+	"time"
+)
+
+const export = `<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+	<myinfo><user_name>fan</user_name></myinfo>
+	<anime>
+		<series_animedb_id>16498</series_animedb_id>
+		<series_title><![CDATA[ Shingeki no Kyojin ]]></series_title>
+		<series_type>TV</series_type>
+		<series_episodes>25</series_episodes>
+		<my_watched_episodes>25</my_watched_episodes>
+		<my_start_date>2013-04-07</my_start_date>
+		<my_finish_date>2013-09-29</my_finish_date>
+		<my_score>9</my_score>
+		<my_status>Completed</my_status>
+		<my_comments><![CDATA[ rewatch ]]></my_comments>
+	</anime>
+	<anime>
+		<series_animedb_id>25777</series_animedb_id>
+		<series_title>Shingeki no Kyojin Season 2</series_title>
+		<series_episodes>12</series_episodes>
+		<my_watched_episodes>3</my_watched_episodes>
+		<my_start_date>2017-04-01</my_start_date>
+		<my_finish_date>2017-03-01</my_finish_date>
+		<my_score>11</my_score>
+		<my_status>1</my_status>
+	</anime>
+	<anime>
+		<series_animedb_id>x</series_animedb_id>
+		<series_title>Unknown</series_title>
+		<my_watched_episodes>-2</my_watched_episodes>
+		<my_start_date>0000-00-00</my_start_date>
+		<my_finish_date>2020-05-00</my_finish_date>
+		<my_score>0</my_score>
+		<my_status>rewatching</my_status>
+	</anime>
+</myanimelist>`
+
+func day(year int, month time.Month, d int) *time.Time {
+	t := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func compress(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	want := []Entry{
+		{
+			MalID: 16498, Title: "Shingeki no Kyojin", Type: "TV", Episodes: 25, Status: entities.WatchCompleted,
+			Score: 9, Progress: 25, StartedOn: day(2013, time.April, 7), FinishedOn: day(2013, time.September, 29), Notes: "rewatch",
+		},
+		{
+			MalID: 25777, Title: "Shingeki no Kyojin Season 2", Episodes: 12, Status: entities.WatchWatching,
+			Progress: 3, StartedOn: day(2017, time.April, 1),
+		},
+		{Title: "Unknown", Status: entities.WatchPlanToWatch},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		export []byte
+	}{
+		{"xml", []byte(export)},
+		{"gzip", compress(t, []byte(export))},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parse(tt.export)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(entries) != len(want) {
+				t.Fatalf("got %d entries, want %d", len(entries), len(want))
+			}
+			for i, got := range entries {
+				w := want[i]
+				if got.MalID != w.MalID || got.Title != w.Title || got.Type != w.Type || got.Episodes != w.Episodes ||
+					got.Status != w.Status || got.Score != w.Score || got.Progress != w.Progress || got.Notes != w.Notes {
+					t.Errorf("entry %d: got %+v, want %+v", i, *got, w)
+				}
+				if !sameDate(got.StartedOn, w.StartedOn) || !sameDate(got.FinishedOn, w.FinishedOn) {
+					t.Errorf("entry %d: got dates %v - %v, want %v - %v", i, got.StartedOn, got.FinishedOn, w.StartedOn, w.FinishedOn)
+				}
+			}
+		})
+	}
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestParseStatuses(t *testing.T) {
+	tests := []struct {
+		status, want string
+	}{
+		{"Watching", entities.WatchWatching},
+		{"1", entities.WatchWatching},
+		{"completed", entities.WatchCompleted},
+		{"2", entities.WatchCompleted},
+		{"On-Hold", entities.WatchOnHold},
+		{"3", entities.WatchOnHold},
+		{"Dropped", entities.WatchDropped},
+		{"4", entities.WatchDropped},
+		{" Plan to Watch ", entities.WatchPlanToWatch},
+		{"6", entities.WatchPlanToWatch},
+		{"5", entities.WatchPlanToWatch},
+		{"", entities.WatchPlanToWatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			entries, err := parse([]byte("<myanimelist><anime><my_status>" + tt.status + "</my_status></anime></myanimelist>"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entries[0].Status != tt.want {
+				t.Errorf("got %q, want %q", entries[0].Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	invalid := "Field 'file' is not a MyAnimeList anime list export"
+
+	tests := []struct {
+		name   string
+		export []byte
+		want   string
+	}{
+		{"not xml", []byte("series_title,my_status\nShingeki no Kyojin,Completed\n"), invalid},
+		{"other root", []byte("<mangalist><anime></anime></mangalist>"), invalid},
+		{"no anime", []byte("<myanimelist><myinfo></myinfo></myanimelist>"), invalid},
+		{"truncated gzip", compress(t, []byte(export))[:20], invalid},
+		{"gzip header only", []byte{0x1f, 0x8b}, invalid},
+		{"too large", compress(t, make([]byte, maxExportSize+1)), "Field 'file' must not be larger than 33554432 bytes uncompressed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.export)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package mal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+)
+
+const (
+	queryGetExternalIds = "SELECT external_id, season_id FROM season_external_ids WHERE provider = $1"
+	// Cada temporada tiene como mucho un identificador por servicio, así
+	// que se borra el anterior antes de insertar.
+	queryDeleteBySeasonId = "DELETE FROM season_external_ids WHERE provider = $1 AND season_id = $2"
+	queryInsertExternalId = "INSERT INTO season_external_ids (provider, external_id, season_id) VALUES ($1, $2, $3)"
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetExternalIDs(ctx context.Context) (map[string]uint64, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetExternalIds", queryGetExternalIds)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetExternalIds, entities.ProviderMyAnimeList)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	ids := map[string]uint64{}
+	for rows.Next() {
+		var externalID string
+		var seasonID uint64
+		if err := rows.Scan(&externalID, &seasonID); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ids[externalID] = seasonID
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *repository) SetExternalID(ctx context.Context, id *entities.ExternalID) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryInsertExternalId", queryInsertExternalId)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, queryDeleteBySeasonId, id.Provider, id.SeasonID); err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, queryInsertExternalId, id.Provider, id.ExternalID, id.SeasonID); err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, id)
+	}
+
+	return tx.Commit()
+}
+
+func (r *repository) DeleteExternalID(ctx context.Context, seasonID uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteBySeasonId", queryDeleteBySeasonId)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteBySeasonId, entities.ProviderMyAnimeList, seasonID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("MyAnimeList id of season %d %w", seasonID, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func mapError(err error, id *entities.ExternalID) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23503": // la temporada no existe
+			return fmt.Errorf("season with id %d %w", id.SeasonID, ex.ErrNotFound)
+		case "23505": // el identificador ya es de otra temporada
+			return &ex.ErrAlreadyExists{
+				Field:      "mal_id",
+				Constraint: pgErr.Constraint,
+			}
+		default:
+			log.Printf("External id write failed: %s", err)
+			return fmt.Errorf("external id write failed: %w", err)
+		}
+	}
+
+	return err
+}
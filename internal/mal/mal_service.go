@@ -0,0 +1,115 @@
+package mal
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/watchlist"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/mal")
+
+type service struct {
+	repository       Repository
+	seasonRepository season.Repository
+	watchlistService watchlist.Service
+}
+
+func NewService(repository Repository, seasonRepository season.Repository, watchlistService watchlist.Service) Service {
+	return &service{
+		repository:       repository,
+		seasonRepository: seasonRepository,
+		watchlistService: watchlistService,
+	}
+}
+
+func (s *service) Preview(ctx context.Context, export []byte) (*Preview, error) {
+	ctx, span := tracer.Start(ctx, "mal.Service.Preview")
+	defer span.End()
+
+	entries, err := parse(export)
+	if err != nil {
+		return nil, err
+	}
+
+	seasons, err := s.seasonRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	externalIDs, err := s.repository.GetExternalIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := newMatcher(seasons, externalIDs)
+	preview := &Preview{Matched: []*Match{}, Unmatched: []*Entry{}}
+	for _, entry := range entries {
+		if season, by := m.match(entry); season != nil {
+			preview.Matched = append(preview.Matched, &Match{Entry: entry, Season: season, By: by})
+		} else {
+			preview.Unmatched = append(preview.Unmatched, entry)
+		}
+	}
+
+	return preview, nil
+}
+
+func (s *service) Import(ctx context.Context, userID uint64, export []byte, overwrite bool) (*Result, error) {
+	ctx, span := tracer.Start(ctx, "mal.Service.Import")
+	defer span.End()
+
+	preview, err := s.Preview(ctx, export)
+	if err != nil {
+		return nil, err
+	}
+
+	// Si varias entradas caen en la misma temporada, como las partes de
+	// una misma temporada, se queda la de más progreso.
+	bySeason := map[uint64]*entities.WatchlistEntry{}
+	entries := make([]*entities.WatchlistEntry, 0, len(preview.Matched))
+	for _, match := range preview.Matched {
+		entry := &entities.WatchlistEntry{
+			UserID:     userID,
+			SeasonID:   match.Season.ID,
+			Status:     match.Entry.Status,
+			Score:      match.Entry.Score,
+			Progress:   match.Entry.Progress,
+			StartedOn:  match.Entry.StartedOn,
+			FinishedOn: match.Entry.FinishedOn,
+			Notes:      match.Entry.Notes,
+		}
+		if previous, ok := bySeason[entry.SeasonID]; ok {
+			if previous.Progress >= entry.Progress {
+				continue
+			}
+			*previous = *entry
+			continue
+		}
+		bySeason[entry.SeasonID] = entry
+		entries = append(entries, entry)
+	}
+
+	imported, err := s.watchlistService.Import(ctx, userID, entries, overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Preview: preview, Imported: imported, Skipped: len(entries) - len(imported)}, nil
+}
+
+func (s *service) SetExternalID(ctx context.Context, id *entities.ExternalID) error {
+	ctx, span := tracer.Start(ctx, "mal.Service.SetExternalID")
+	defer span.End()
+
+	id.Provider = entities.ProviderMyAnimeList
+
+	return s.repository.SetExternalID(ctx, id)
+}
+
+func (s *service) DeleteExternalID(ctx context.Context, seasonID uint64) error {
+	ctx, span := tracer.Start(ctx, "mal.Service.DeleteExternalID")
+	defer span.End()
+
+	return s.repository.DeleteExternalID(ctx, seasonID)
+}
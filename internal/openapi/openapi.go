@@ -0,0 +1,186 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/wicho90/anime-api/internal/response"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const Version = "3.1.0"
+
+// Document es el subconjunto de OpenAPI 3.1 que usa la API.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// PathItem agrupa las operaciones de una ruta por método en minúsculas.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema  *Schema `json:"schema,omitempty"`
+	Example any     `json:"example,omitempty"`
+}
+
+func New(title, version, description string) *Document {
+	d := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version, Description: description},
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				BearerAuth: {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+	d.Register(ErrorRef, response.HttpResponse{})
+
+	return d
+}
+
+var paramPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)\??`)
+
+// Add documenta la operación method path. path usa la sintaxis de Fiber
+// (/seasons/:id) y se convierte a la de OpenAPI (/seasons/{id}).
+func (d *Document) Add(method, path string, op *Operation) {
+	path = toOpenAPIPath(path)
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	(*item)[strings.ToLower(method)] = op
+
+	// El grupo /api autentica el token opcional y limita las peticiones, así
+	// que cualquier operación puede responder 401 o 429.
+	if strings.HasPrefix(path, "/api/") {
+		if _, ok := op.Responses["401"]; !ok {
+			op.Responses["401"] = Error(http.StatusUnauthorized, "Invalid token")
+		}
+		if _, ok := op.Responses["429"]; !ok {
+			op.Responses["429"] = Error(http.StatusTooManyRequests, "Rate limit exceeded")
+		}
+	}
+}
+
+// Operations devuelve "METHOD /path" por cada operación documentada.
+func (d *Document) Operations() []string {
+	var ops []string
+	for path, item := range d.Paths {
+		for method := range *item {
+			ops = append(ops, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(ops)
+
+	return ops
+}
+
+func toOpenAPIPath(path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return paramPattern.ReplaceAllString(path, "{$1}")
+}
+
+// Verify compara las rutas registradas en el servidor ("METHOD /path" con
+// sintaxis de Fiber) con el documento y falla si alguna ruta no está
+// documentada o si alguna operación documentada no existe.
+func (d *Document) Verify(routes []string) error {
+	documented := map[string]bool{}
+	for _, op := range d.Operations() {
+		documented[op] = true
+	}
+
+	var missing []string
+	registered := map[string]bool{}
+	for _, route := range routes {
+		method, path, _ := strings.Cut(route, " ")
+		key := method + " " + toOpenAPIPath(path)
+		registered[key] = true
+		if !documented[key] {
+			missing = append(missing, key)
+		}
+	}
+
+	var stale []string
+	for op := range documented {
+		if !registered[op] {
+			stale = append(stale, op)
+		}
+	}
+
+	if len(missing) == 0 && len(stale) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	sort.Strings(stale)
+	return fmt.Errorf("openapi document out of sync with the router: undocumented routes %v, documented but unregistered %v",
+		missing, stale)
+}
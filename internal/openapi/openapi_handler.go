@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+const swaggerUIVersion = "5.4.2"
+
+// uiScript inicializa Swagger UI. Se sirve en línea y se autoriza en la CSP
+// por su hash, así que cualquier cambio aquí actualiza el hash solo.
+const uiScript = `window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });`
+
+var uiPage = fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Anime API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui-bundle.js"></script>
+  <script>%[2]s</script>
+</body>
+</html>
+`, swaggerUIVersion, uiScript)
+
+var uiCSP = func() string {
+	sum := sha256.Sum256([]byte(uiScript))
+	return fmt.Sprintf("default-src 'none'; connect-src 'self'; img-src 'self' data: https://unpkg.com; "+
+		"style-src https://unpkg.com; script-src https://unpkg.com 'sha256-%s'; frame-ancestors 'none'",
+		base64.StdEncoding.EncodeToString(sum[:]))
+}()
+
+type Handler interface {
+	Spec(ctx *fiber.Ctx) error
+	UI(ctx *fiber.Ctx) error
+}
+
+type handler struct {
+	document *Document
+}
+
+func NewHandler(document *Document) Handler {
+	return &handler{document: document}
+}
+
+func (h *handler) Spec(ctx *fiber.Ctx) error {
+	return ctx.Status(http.StatusOK).JSON(h.document)
+}
+
+func (h *handler) UI(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderContentSecurityPolicy, uiCSP)
+	ctx.Type("html", "utf-8")
+
+	return ctx.Status(http.StatusOK).SendString(uiPage)
+}
+
+// OpenAPI documenta las propias rutas de la documentación.
+func OpenAPI(doc *Document) {
+	doc.Add(http.MethodGet, "/api/v1/openapi.json", &Operation{
+		OperationID: "getOpenAPI",
+		Summary:     "OpenAPI document for this API",
+		Tags:        []string{"docs"},
+		Responses: Responses(map[int]*Response{
+			http.StatusOK: JSON("OpenAPI 3.1 document", &Schema{Type: "object"}, nil),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v1/docs", &Operation{
+		OperationID: "getDocs",
+		Summary:     "Interactive documentation (Swagger UI)",
+		Tags:        []string{"docs"},
+		Responses: Responses(map[int]*Response{
+			http.StatusOK: {
+				Description: "HTML page",
+				Content:     map[string]*MediaType{"text/html": {Schema: &Schema{Type: "string"}}},
+			},
+		}),
+	})
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	BearerAuth = "bearerAuth"
+	ErrorRef   = "Error"
+
+	mimeJSON = "application/json"
+)
+
+// PathParam describe un parámetro de ruta obligatorio.
+func PathParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+// QueryParam describe un parámetro de consulta opcional.
+func QueryParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// IDParam es el parámetro :id numérico que usan todos los recursos.
+func IDParam() *Parameter {
+	return PathParam("id", "Numeric identifier", &Schema{Type: "integer", Format: "int64", Minimum: float(1)})
+}
+
+// JSONBody describe un cuerpo JSON obligatorio.
+func JSONBody(schema *Schema, example any) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]*MediaType{mimeJSON: {Schema: schema, Example: example}},
+	}
+}
+
+// JSON describe una respuesta JSON.
+func JSON(description string, schema *Schema, example any) *Response {
+	return &Response{
+		Description: description,
+		Content:     map[string]*MediaType{mimeJSON: {Schema: schema, Example: example}},
+	}
+}
+
+// NoContent describe una respuesta sin cuerpo.
+func NoContent(description string) *Response {
+	return &Response{Description: description}
+}
+
+// Error describe una respuesta con el cuerpo de response.HttpResponse.
+func Error(status int, message string) *Response {
+	return JSON(http.StatusText(status), Ref(ErrorRef), map[string]any{
+		"statusCode": status,
+		"message":    message,
+		"error":      http.StatusText(status),
+	})
+}
+
+// Responses construye el mapa de respuestas indexado por código.
+func Responses(pairs map[int]*Response) map[string]*Response {
+	responses := make(map[string]*Response, len(pairs))
+	for status, r := range pairs {
+		responses[strconv.Itoa(status)] = r
+	}
+
+	return responses
+}
+
+// Authenticated indica que la operación requiere un token Bearer.
+func Authenticated() []map[string][]string {
+	return []map[string][]string{{BearerAuth: {}}}
+}
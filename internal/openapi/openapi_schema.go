@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema es un JSON Schema 2020-12, el dialecto de OpenAPI 3.1.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        any                `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	ReadOnly    bool               `json:"readOnly,omitempty"`
+	Examples    []any              `json:"examples,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Register añade el esquema de v a components.schemas con el nombre dado y
+// devuelve una referencia a él.
+func (d *Document) Register(name string, v any) *Schema {
+	d.Components.Schemas[name] = SchemaOf(v)
+	return Ref(name)
+}
+
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+// SchemaOf genera el esquema de un struct a partir de sus etiquetas json y
+// validate. En los structs que usan validate, los campos sin esa etiqueta
+// los rellena el servidor y se marcan como readOnly.
+func SchemaOf(v any) *Schema {
+	return schemaOf(reflect.TypeOf(v))
+}
+
+func schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		return &Schema{Type: "integer", Format: intFormat(t)}
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer", Format: intFormat(t), Minimum: float(0)}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return ArrayOf(schemaOf(t.Elem()))
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object"}
+	case t.Kind() == reflect.Struct:
+		return structSchema(t)
+	}
+
+	return &Schema{}
+}
+
+func structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	validated := false
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("validate"); ok {
+			validated = true
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := schemaOf(field.Type)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := schemaOf(field.Type)
+		if strings.Contains(opts, "string") {
+			prop = &Schema{Type: "string"}
+		}
+
+		rules, hasRules := field.Tag.Lookup("validate")
+		if validated && !hasRules {
+			prop.ReadOnly = true
+		}
+		if applyRules(prop, rules) {
+			s.Required = append(s.Required, name)
+		}
+
+		s.Properties[name] = prop
+	}
+
+	return s
+}
+
+// applyRules traduce las reglas de go-playground/validator a restricciones
+// de JSON Schema. Devuelve true si el campo es obligatorio.
+func applyRules(s *Schema, rules string) bool {
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "min", "gte":
+			s.setMin(param)
+		case "max", "lte":
+			s.setMax(param)
+		case "len":
+			s.setMin(param)
+			s.setMax(param)
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, v)
+			}
+		case "url", "http_url":
+			s.Format = "uri"
+		case "email":
+			s.Format = "email"
+		}
+	}
+
+	return required
+}
+
+func (s *Schema) setMin(param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	if s.Type == "string" {
+		s.MinLength = integer(int(n))
+	} else if s.Type == "array" {
+		return
+	} else {
+		s.Minimum = float(n)
+	}
+}
+
+func (s *Schema) setMax(param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	if s.Type == "string" {
+		s.MaxLength = integer(int(n))
+	} else if s.Type == "array" {
+		return
+	} else {
+		s.Maximum = float(n)
+	}
+}
+
+func intFormat(t reflect.Type) string {
+	if t.Bits() > 32 {
+		return "int64"
+	}
+
+	return "int32"
+}
+
+func integer(n int) *int {
+	return &n
+}
+
+func float(n float64) *float64 {
+	return &n
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Budget describe un token bucket: se reponen Requests fichas cada Period y
+// caben como máximo Burst.
+type Budget struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// rate devuelve las fichas que se reponen por segundo.
+func (b Budget) rate() float64 {
+	return float64(b.Requests) / b.Period.Seconds()
+}
+
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset es el tiempo hasta que el bucket vuelve a estar lleno.
+	Reset time.Duration
+	// RetryAfter es el tiempo hasta la siguiente ficha cuando Allowed es false.
+	RetryAfter time.Duration
+}
+
+// Store guarda el estado de los buckets. Take consume una ficha de key y
+// Peek indica si quedan fichas sin consumir ninguna.
+type Store interface {
+	Take(ctx context.Context, key string, budget Budget) (Result, error)
+	Peek(ctx context.Context, key string, budget Budget) (Result, error)
+	Ping(ctx context.Context) error
+	Close()
+}
+
+// result calcula el Result a partir de las fichas que quedan tras Take.
+func result(allowed bool, tokens float64, budget Budget) Result {
+	rate := budget.rate()
+	r := Result{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+		Reset:     seconds((float64(budget.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	if s < 0 {
+		return 0
+	}
+
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full es el momento en que el bucket se habrá rellenado por completo.
+	full time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	stop    chan struct{}
+}
+
+// NewMemoryStore guarda los buckets en el proceso. Sólo es correcto con una
+// única réplica de la API.
+func NewMemoryStore() Store {
+	s := &memoryStore{
+		buckets: map[string]*bucket{},
+		stop:    make(chan struct{}),
+	}
+	go s.janitor(time.Minute)
+
+	return s
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, budget Budget) (Result, error) {
+	return s.take(key, budget, 1), nil
+}
+
+func (s *memoryStore) Peek(_ context.Context, key string, budget Budget) (Result, error) {
+	return s.take(key, budget, 0), nil
+}
+
+// take rellena el bucket y, si quedan fichas, consume cost.
+func (s *memoryStore) take(key string, budget Budget, cost float64) Result {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(budget.Burst), last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(budget.Burst), b.tokens+now.Sub(b.last).Seconds()*budget.rate())
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens -= cost
+	}
+
+	r := result(allowed, b.tokens, budget)
+	b.full = now.Add(r.Reset)
+
+	return r
+}
+
+func (s *memoryStore) Ping(context.Context) error {
+	return nil
+}
+
+func (s *memoryStore) Close() {
+	close(s.stop)
+}
+
+// janitor elimina periódicamente los buckets que ya se han rellenado, porque
+// son equivalentes a uno nuevo.
+func (s *memoryStore) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for key, b := range s.buckets {
+				if now.After(b.full) {
+					delete(s.buckets, key)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/response"
+	"log"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+	HeaderPolicy    = "RateLimit-Policy"
+
+	GroupRead  = "read"
+	GroupWrite = "write"
+
+	localsGroup = "ratelimit.group"
+)
+
+// Classify fija el presupuesto (GroupRead o GroupWrite) de la petición para
+// las rutas en las que el método no lo indica, como POST /graphql. Debe
+// llamarse antes de Middleware.
+func Classify(ctx *fiber.Ctx, group string) {
+	ctx.Locals(localsGroup, group)
+}
+
+// Guard cobra al bucket de la IP los intentos de autenticación fallidos,
+// para que adivinar tokens tenga el mismo límite que el tráfico anónimo.
+// Debe ir antes de auth.Middleware: si la IP ya no tiene fichas, la
+// petición con token se rechaza sin consultar la base de datos. Las
+// peticiones autenticadas no consumen fichas de la IP.
+func Guard(store Store, config *config.Config) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !config.RateLimit.Enabled || ctx.Get(fiber.HeaderAuthorization) == "" {
+			return ctx.Next()
+		}
+
+		group, policy := classify(ctx, config)
+		key, b := group+":ip:"+ctx.IP(), newBudget(policy.IP)
+		result, err := store.Peek(ctx.UserContext(), key, b)
+		if err != nil {
+			log.Printf("rate limit store failed: %s", err)
+			return ctx.Next()
+		}
+		if !result.Allowed {
+			return reject(ctx, b, result)
+		}
+
+		err = ctx.Next()
+		if _, ok := auth.CurrentUser(ctx); !ok {
+			if _, err := store.Take(ctx.UserContext(), key, b); err != nil {
+				log.Printf("rate limit store failed: %s", err)
+			}
+		}
+
+		return err
+	}
+}
+
+// Middleware limita las peticiones por usuario autenticado o, si no lo hay,
+// por IP, con presupuestos distintos para lectura y escritura. Debe ir
+// después de auth.Middleware. Si el store falla la petición se deja pasar.
+func Middleware(store Store, config *config.Config) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !config.RateLimit.Enabled {
+			return ctx.Next()
+		}
+
+		group, policy := classify(ctx, config)
+		key, b := group+":ip:"+ctx.IP(), newBudget(policy.IP)
+		if user, ok := auth.CurrentUser(ctx); ok {
+			key, b = group+":user:"+strconv.FormatUint(user.ID, 10), newBudget(policy.User)
+		}
+
+		result, err := store.Take(ctx.UserContext(), key, b)
+		if err != nil {
+			log.Printf("rate limit store failed: %s", err)
+			return ctx.Next()
+		}
+
+		if !result.Allowed {
+			return reject(ctx, b, result)
+		}
+		setHeaders(ctx, b, result)
+
+		return ctx.Next()
+	}
+}
+
+// classify devuelve el grupo de la petición y su política: el fijado con
+// Classify o, si no, el que corresponde al método.
+func classify(ctx *fiber.Ctx, config *config.Config) (string, config.RateLimitPolicy) {
+	group, ok := ctx.Locals(localsGroup).(string)
+	if !ok {
+		group = GroupWrite
+		switch ctx.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			group = GroupRead
+		}
+	}
+
+	if group == GroupRead {
+		return group, config.RateLimit.Read
+	}
+	return group, config.RateLimit.Write
+}
+
+func newBudget(budget config.RateLimitBudget) Budget {
+	return Budget{Requests: budget.Requests, Period: budget.Period, Burst: budget.Burst}
+}
+
+func setHeaders(ctx *fiber.Ctx, b Budget, result Result) {
+	ctx.Set(HeaderLimit, strconv.Itoa(b.Burst))
+	ctx.Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+	ctx.Set(HeaderReset, ceilSeconds(result.Reset))
+	ctx.Set(HeaderPolicy, fmt.Sprintf("%d;w=%s;burst=%d", b.Requests, ceilSeconds(b.Period), b.Burst))
+}
+
+func reject(ctx *fiber.Ctx, b Budget, result Result) error {
+	setHeaders(ctx, b, result)
+	ctx.Set(fiber.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+
+	return response.NewTooManyRequestsResponse("Rate limit exceeded")
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type authRepository struct {
+	calls int
+}
+
+func (r *authRepository) GetByTokenHash(_ context.Context, tokenHash string) (*entities.User, error) {
+	r.calls++
+	if tokenHash == auth.HashToken("valid") {
+		return &entities.User{ID: 1, Username: "user", Role: entities.RoleUser}, nil
+	}
+	return nil, fmt.Errorf("user %w", ex.ErrNotFound)
+}
+
+func (r *authRepository) Create(context.Context, *entities.User, string) error {
+	return nil
+}
+
+func newApp(t *testing.T, repository auth.Repository) *fiber.App {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.RateLimit.Enabled = true
+	cfg.RateLimit.Read.IP = config.RateLimitBudget{Requests: 1, Period: time.Hour, Burst: 3}
+	cfg.RateLimit.Read.User = config.RateLimitBudget{Requests: 1, Period: time.Hour, Burst: 10}
+
+	store := NewMemoryStore()
+	t.Cleanup(store.Close)
+
+	app := fiber.New(fiber.Config{ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+		if e, ok := err.(response.BaseResponse); ok {
+			return ctx.SendStatus(e.GetCode())
+		}
+		return ctx.SendStatus(http.StatusInternalServerError)
+	}})
+	app.Get("/", Guard(store, cfg), auth.Middleware(repository), Middleware(store, cfg), func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(http.StatusOK)
+	})
+
+	return app
+}
+
+func get(t *testing.T, app *fiber.App, token string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res.StatusCode
+}
+
+func TestGuardChargesFailedAuthentication(t *testing.T) {
+	repository := &authRepository{}
+	app := newApp(t, repository)
+
+	for i := 0; i < 3; i++ {
+		if status := get(t, app, "guess"); status != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status = %d, want 401", i+1, status)
+		}
+	}
+
+	if status := get(t, app, "guess"); status != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429 once the IP bucket is empty", status)
+	}
+	if status := get(t, app, ""); status != http.StatusTooManyRequests {
+		t.Fatalf("anonymous status = %d, want 429: failures share the IP bucket", status)
+	}
+	if repository.calls != 3 {
+		t.Fatalf("token lookups = %d, want 3: rejected attempts must not reach the repository", repository.calls)
+	}
+}
+
+func TestGuardDoesNotChargeAuthenticatedRequests(t *testing.T) {
+	app := newApp(t, &authRepository{})
+
+	for i := 0; i < 5; i++ {
+		if status := get(t, app, "valid"); status != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i+1, status)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if status := get(t, app, ""); status != http.StatusOK {
+			t.Fatalf("anonymous request %d: status = %d, want 200", i+1, status)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+)
+
+// takeScript implementa el token bucket de forma atómica en el servidor.
+// Usa el reloj de Redis para que todas las réplicas de la API coincidan.
+// Con cost 0 sólo consulta el bucket y no lo modifica.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - cost
+  allowed = 1
+end
+
+if cost > 0 then
+  redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+  redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+end
+
+return {allowed, tostring(tokens)}
+`)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore comparte los buckets entre réplicas usando cualquier
+// servidor compatible con el protocolo de Redis (Redis, Valkey, KeyDB...).
+func NewRedisStore(url string) (Store, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	return &redisStore{client: redis.NewClient(opts)}, nil
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, budget Budget) (Result, error) {
+	return s.take(ctx, key, budget, 1)
+}
+
+func (s *redisStore) Peek(ctx context.Context, key string, budget Budget) (Result, error) {
+	return s.take(ctx, key, budget, 0)
+}
+
+func (s *redisStore) take(ctx context.Context, key string, budget Budget, cost int) (Result, error) {
+	values, err := takeScript.Run(ctx, s.client, []string{"ratelimit:" + key},
+		budget.rate(), budget.Burst, cost).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to run rate limit script: %w", err)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokens, err := strconv.ParseFloat(fmt.Sprint(values[1]), 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit script result: %w", err)
+	}
+
+	return result(allowed == 1, tokens, budget), nil
+}
+
+func (s *redisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+func (s *redisStore) Close() {
+	_ = s.client.Close()
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/health"
+	"go.uber.org/fx"
+)
+
+// NewStore crea el store indicado en config.RateLimit.Store y lo cierra al
+// parar la aplicación.
+func NewStore(lc fx.Lifecycle, config *config.Config) (Store, error) {
+	var store Store
+	if config.RateLimit.Store == "redis" {
+		redisStore, err := NewRedisStore(config.RateLimit.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		store = redisStore
+	} else {
+		store = NewMemoryStore()
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			store.Close()
+			return nil
+		},
+	})
+
+	return store, nil
+}
+
+// NewChecker expone el store como la comprobación de la caché en la
+// readiness.
+func NewChecker(store Store) health.Checker {
+	return health.NewCacheChecker(store)
+}
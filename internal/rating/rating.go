@@ -0,0 +1,35 @@
+package rating
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type Repository interface {
+	// GetSummary devuelve el agregado de las puntuaciones; si no hay
+	// ninguna, uno vacío.
+	GetSummary(ctx context.Context, ownerType string, ownerID uint64) (*entities.RatingSummary, error)
+	// Save inserta o reemplaza la puntuación del usuario y actualiza el
+	// agregado en la misma transacción.
+	Save(ctx context.Context, rating *entities.Rating) (*entities.RatingSummary, error)
+	Delete(ctx context.Context, ownerType string, ownerID, userID uint64) error
+	// GetTopRated ordena por media bayesiana con priorVotes votos a la
+	// media global.
+	GetTopRated(ctx context.Context, ownerType string, priorVotes float64, limit int) ([]*entities.TopRated, error)
+}
+
+type Service interface {
+	Rate(ctx context.Context, rating *entities.Rating) (*entities.RatingSummary, error)
+	Unrate(ctx context.Context, ownerType string, ownerID, userID uint64) error
+	GetTopRated(ctx context.Context, ownerType string, limit int) ([]*entities.TopRated, error)
+}
+
+type Handler interface {
+	RateSeason(ctx *fiber.Ctx) error
+	UnrateSeason(ctx *fiber.Ctx) error
+	GetTopRatedSeasons(ctx *fiber.Ctx) error
+	RateEpisode(ctx *fiber.Ctx) error
+	UnrateEpisode(ctx *fiber.Ctx) error
+	GetTopRatedEpisodes(ctx *fiber.Ctx) error
+}
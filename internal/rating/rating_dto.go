@@ -0,0 +1,45 @@
+package rating
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+type ratingRequest struct {
+	Score int `json:"score" validate:"required,min=1,max=10"`
+}
+
+// ratingResponse devuelve la puntuación del usuario junto al agregado ya
+// actualizado.
+type ratingResponse struct {
+	Score     int                     `json:"score"`
+	UpdatedAt time.Time               `json:"updated_at"`
+	Rating    *entities.RatingSummary `json:"rating"`
+}
+
+type topRatedResponse struct {
+	ID     uint64                  `json:"id"`
+	Slug   string                  `json:"slug"`
+	Title  string                  `json:"title"`
+	Score  float64                 `json:"score"`
+	Rating *entities.RatingSummary `json:"rating"`
+}
+
+type topRatedListResponse struct {
+	Data []*topRatedResponse `json:"data"`
+}
+
+func newTopRatedListResponse(items []*entities.TopRated) *topRatedListResponse {
+	list := &topRatedListResponse{Data: make([]*topRatedResponse, 0, len(items))}
+	for _, item := range items {
+		list.Data = append(list.Data, &topRatedResponse{
+			ID:     item.ID,
+			Slug:   item.Slug,
+			Title:  item.Name,
+			Score:  item.Score,
+			Rating: item.Rating,
+		})
+	}
+
+	return list
+}
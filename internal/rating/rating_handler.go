@@ -0,0 +1,129 @@
+package rating
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) RateSeason(ctx *fiber.Ctx) error {
+	return h.rate(ctx, entities.RatingOwnerSeason)
+}
+
+func (h *handler) UnrateSeason(ctx *fiber.Ctx) error {
+	return h.unrate(ctx, entities.RatingOwnerSeason)
+}
+
+func (h *handler) GetTopRatedSeasons(ctx *fiber.Ctx) error {
+	return h.getTopRated(ctx, entities.RatingOwnerSeason)
+}
+
+func (h *handler) RateEpisode(ctx *fiber.Ctx) error {
+	return h.rate(ctx, entities.RatingOwnerEpisode)
+}
+
+func (h *handler) UnrateEpisode(ctx *fiber.Ctx) error {
+	return h.unrate(ctx, entities.RatingOwnerEpisode)
+}
+
+func (h *handler) GetTopRatedEpisodes(ctx *fiber.Ctx) error {
+	return h.getTopRated(ctx, entities.RatingOwnerEpisode)
+}
+
+// rate inserta o reemplaza la puntuación del usuario autenticado.
+func (h *handler) rate(ctx *fiber.Ctx, ownerType string) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request ratingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	rating := &entities.Rating{UserID: user.ID, OwnerType: ownerType, OwnerID: id, Score: request.Score}
+	summary, err := h.service.Rate(ctx.UserContext(), rating)
+	if err != nil {
+		return writeError(err, "Failed to save rating")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(&ratingResponse{Score: rating.Score, UpdatedAt: rating.UpdatedAt, Rating: summary})
+}
+
+func (h *handler) unrate(ctx *fiber.Ctx, ownerType string) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Unrate(ctx.UserContext(), ownerType, id, user.ID); err != nil {
+		return writeError(err, "Failed to delete rating")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) getTopRated(ctx *fiber.Ctx, ownerType string) error {
+	limit := defaultLimit
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLimit {
+			return response.NewBadRequestResponse("Invalid limit")
+		}
+		limit = n
+	}
+
+	top, err := h.service.GetTopRated(ctx.UserContext(), ownerType, limit)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get top rated")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newTopRatedListResponse(top))
+}
+
+func writeError(err error, message string) error {
+	var validation *ex.ErrValidation
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
@@ -0,0 +1,83 @@
+package rating
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+// ExampleSummary es el agregado de ejemplo que comparten las respuestas de
+// detalle de temporadas y episodios.
+var ExampleSummary = &entities.RatingSummary{
+	Average:   8.4,
+	Count:     5,
+	Histogram: []int{0, 0, 0, 0, 0, 0, 1, 2, 1, 1},
+}
+
+// OpenAPI documenta las puntuaciones y el ranking de mejor valorados.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("RatingV2", ratingResponse{})
+	list := doc.Register("TopRatedListV2", topRatedListResponse{})
+	request := doc.Register("RatingRequestV2", ratingRequest{})
+
+	rated := &ratingResponse{Score: 9, UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), Rating: ExampleSummary}
+
+	for _, owner := range []struct {
+		path, id, name, plural, slug, title string
+	}{
+		{"/api/v2/seasons", "Season", "season", "seasons", "shingeki-no-kyojin", "shingeki no kyojin"},
+		{"/api/v2/episodes", "Episode", "episode", "episodes", "shingeki-no-kyojin-1", "to you, in 2000 years"},
+	} {
+		notFound := openapi.Error(http.StatusNotFound, owner.name+" with id 1 not found")
+		unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+
+		doc.Add(http.MethodGet, owner.path+"/top-rated", &openapi.Operation{
+			OperationID: "listTopRated" + owner.id + "sV2",
+			Summary:     "List the top rated " + owner.plural,
+			Description: "Ranked by Bayesian average: (total + m·C) / (count + m), where C is the mean of every " +
+				owner.name + " rating and m is 10, so items with few ratings are pulled towards the mean.",
+			Tags: []string{"ratings"},
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParam("limit", "Maximum number of results (1 to 100, default 20)", &openapi.Schema{Type: "integer"}),
+			},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK: openapi.JSON("Top rated "+owner.plural, list, topRatedListResponse{Data: []*topRatedResponse{{
+					ID: 1, Slug: owner.slug, Title: owner.title, Score: 7.95, Rating: ExampleSummary,
+				}}}),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid limit"),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get top rated"),
+			}),
+		})
+		doc.Add(http.MethodPut, owner.path+"/:id/rating", &openapi.Operation{
+			OperationID: "rate" + owner.id + "V2",
+			Summary:     "Rate a " + owner.name,
+			Description: "Creates or replaces the score of the authenticated user and returns the updated aggregate.",
+			Tags:        []string{"ratings"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			RequestBody: openapi.JSONBody(request, ratingRequest{Score: 9}),
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON("Saved rating", schema, rated),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Score is required"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusNotFound:            notFound,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save rating"),
+			}),
+		})
+		doc.Add(http.MethodDelete, owner.path+"/:id/rating", &openapi.Operation{
+			OperationID: "unrate" + owner.id + "V2",
+			Summary:     "Remove the rating of a " + owner.name,
+			Tags:        []string{"ratings"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusNoContent:           openapi.NoContent("Deleted"),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusNotFound:            openapi.Error(http.StatusNotFound, "rating of "+owner.name+" 1 not found"),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete rating"),
+			}),
+		})
+	}
+}
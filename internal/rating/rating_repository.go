@@ -0,0 +1,266 @@
+package rating
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"math"
+)
+
+// Las consultas se escriben una vez con %[1]s como columna del propietario
+// (season_id o episode_id) y %[2]s como su tabla.
+const (
+	queryGetSummary = "SELECT count, total, histogram FROM rating_aggregates WHERE %[1]s = $1"
+	// El agregado se crea si no existe y se bloquea: así las puntuaciones
+	// de un mismo propietario se aplican de una en una.
+	queryEnsureAggregate = "INSERT INTO rating_aggregates (%[1]s) VALUES ($1) ON CONFLICT (%[1]s) DO NOTHING"
+	queryLockAggregate   = "SELECT count, total, histogram FROM rating_aggregates WHERE %[1]s = $1 FOR UPDATE"
+	queryUpdateAggregate = "UPDATE rating_aggregates SET count = $1, total = $2, histogram = $3 WHERE %[1]s = $4"
+	queryGetScore        = "SELECT score FROM ratings WHERE user_id = $1 AND %[1]s = $2"
+	querySave            = `INSERT INTO ratings (user_id, %[1]s, score) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, %[1]s) DO UPDATE SET score = EXCLUDED.score, updated_at = NOW()
+		RETURNING updated_at`
+	queryDelete = "DELETE FROM ratings WHERE user_id = $1 AND %[1]s = $2"
+	// score es la media bayesiana (total + m·C) / (count + m), con C la
+	// media de todas las puntuaciones del mismo tipo y m = $1.
+	queryGetTopRated = `WITH global AS (
+			SELECT COALESCE(SUM(total)::float8 / NULLIF(SUM(count), 0), 0) AS mean
+			FROM rating_aggregates WHERE %[1]s IS NOT NULL
+		)
+		SELECT o.id, o.name, o.slug, a.count, a.total, a.histogram,
+			(a.total + $1::float8 * g.mean) / (a.count + $1::float8) AS score
+		FROM rating_aggregates a
+		JOIN %[2]s o ON o.id = a.%[1]s
+		CROSS JOIN global g
+		WHERE a.count > 0
+		ORDER BY score DESC, a.count DESC, o.id
+		LIMIT $2`
+)
+
+type ownerQueries struct {
+	getSummary, ensureAggregate, lockAggregate, updateAggregate, getScore, save, delete, getTopRated string
+}
+
+var queries = map[string]ownerQueries{
+	entities.RatingOwnerSeason:  newOwnerQueries("season_id", "seasons"),
+	entities.RatingOwnerEpisode: newOwnerQueries("episode_id", "episodes"),
+}
+
+func newOwnerQueries(column, table string) ownerQueries {
+	return ownerQueries{
+		getSummary:      fmt.Sprintf(queryGetSummary, column),
+		ensureAggregate: fmt.Sprintf(queryEnsureAggregate, column),
+		lockAggregate:   fmt.Sprintf(queryLockAggregate, column),
+		updateAggregate: fmt.Sprintf(queryUpdateAggregate, column),
+		getScore:        fmt.Sprintf(queryGetScore, column),
+		save:            fmt.Sprintf(querySave, column),
+		delete:          fmt.Sprintf(queryDelete, column),
+		getTopRated:     fmt.Sprintf(queryGetTopRated, column, table),
+	}
+}
+
+// aggregate es la fila de rating_aggregates.
+type aggregate struct {
+	count, total int64
+	histogram    []int64
+}
+
+func (a *aggregate) add(score int, delta int64) {
+	a.count += delta
+	a.total += int64(score) * delta
+	a.histogram[score-entities.MinScore] += delta
+}
+
+func (a *aggregate) summary() *entities.RatingSummary {
+	summary := &entities.RatingSummary{Count: int(a.count), Histogram: make([]int, entities.MaxScore-entities.MinScore+1)}
+	for i := range summary.Histogram {
+		if i < len(a.histogram) {
+			summary.Histogram[i] = int(a.histogram[i])
+		}
+	}
+	if a.count > 0 {
+		summary.Average = math.Round(float64(a.total)/float64(a.count)*100) / 100
+	}
+
+	return summary
+}
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetSummary(ctx context.Context, ownerType string, ownerID uint64) (*entities.RatingSummary, error) {
+	query := queries[ownerType].getSummary
+	ctx, span := telemetry.StartQuery(ctx, "queryGetSummary", query)
+	defer span.End()
+
+	a := &aggregate{}
+	err := r.replica.QueryRowContext(ctx, query, ownerID).Scan(&a.count, &a.total, pq.Array(&a.histogram))
+	if err != nil && err != sql.ErrNoRows {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return a.summary(), nil
+}
+
+func (r *repository) Save(ctx context.Context, rating *entities.Rating) (*entities.RatingSummary, error) {
+	q := queries[rating.OwnerType]
+	ctx, span := telemetry.StartQuery(ctx, "querySave", q.save)
+	defer span.End()
+
+	var summary *entities.RatingSummary
+	err := r.update(ctx, rating.OwnerType, rating.OwnerID, func(tx *sql.Tx, a *aggregate) error {
+		var previous int
+		err := tx.QueryRowContext(ctx, q.getScore, rating.UserID, rating.OwnerID).Scan(&previous)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, q.save, rating.UserID, rating.OwnerID, rating.Score).Scan(&rating.UpdatedAt); err != nil {
+			return err
+		}
+
+		if previous != 0 {
+			a.add(previous, -1)
+		}
+		a.add(rating.Score, 1)
+		summary = a.summary()
+
+		return nil
+	})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, mapError(err, rating.OwnerType, rating.OwnerID)
+	}
+
+	return summary, nil
+}
+
+func (r *repository) Delete(ctx context.Context, ownerType string, ownerID, userID uint64) error {
+	q := queries[ownerType]
+	ctx, span := telemetry.StartQuery(ctx, "queryDelete", q.delete)
+	defer span.End()
+
+	err := r.update(ctx, ownerType, ownerID, func(tx *sql.Tx, a *aggregate) error {
+		var previous int
+		if err := tx.QueryRowContext(ctx, q.getScore, userID, ownerID).Scan(&previous); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("rating of %s %d %w", ownerType, ownerID, ex.ErrNotFound)
+			}
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, q.delete, userID, ownerID); err != nil {
+			return err
+		}
+
+		a.add(previous, -1)
+
+		return nil
+	})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, ownerType, ownerID)
+	}
+
+	return nil
+}
+
+// update aplica fn al agregado bloqueado del propietario y lo guarda, todo
+// en una transacción.
+func (r *repository) update(ctx context.Context, ownerType string, ownerID uint64, fn func(tx *sql.Tx, a *aggregate) error) error {
+	q := queries[ownerType]
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, q.ensureAggregate, ownerID); err != nil {
+		return err
+	}
+	a := &aggregate{}
+	if err := tx.QueryRowContext(ctx, q.lockAggregate, ownerID).Scan(&a.count, &a.total, pq.Array(&a.histogram)); err != nil {
+		return err
+	}
+	if len(a.histogram) != entities.MaxScore-entities.MinScore+1 {
+		return fmt.Errorf("invalid histogram of %s %d: %v", ownerType, ownerID, a.histogram)
+	}
+
+	if err := fn(tx, a); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, q.updateAggregate, a.count, a.total, pq.Array(a.histogram), ownerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *repository) GetTopRated(ctx context.Context, ownerType string, priorVotes float64, limit int) ([]*entities.TopRated, error) {
+	query := queries[ownerType].getTopRated
+	ctx, span := telemetry.StartQuery(ctx, "queryGetTopRated", query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query, priorVotes, limit)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	top := []*entities.TopRated{}
+	for rows.Next() {
+		item := &entities.TopRated{OwnerType: ownerType}
+		a := &aggregate{}
+		if err := rows.Scan(&item.ID, &item.Name, &item.Slug, &a.count, &a.total, pq.Array(&a.histogram), &item.Score); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		item.Score = math.Round(item.Score*100) / 100
+		item.Rating = a.summary()
+		top = append(top, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return top, nil
+}
+
+func mapError(err error, ownerType string, ownerID uint64) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23503": // el propietario o el usuario no existen
+			return fmt.Errorf("%s with id %d %w", ownerType, ownerID, ex.ErrNotFound)
+		case "23514": // puntuación fuera de rango
+			return &ex.ErrValidation{Field: "score", Reason: "must be between 1 and 10"}
+		default:
+			log.Printf("Rating write failed: %s", err)
+			return fmt.Errorf("rating write failed: %w", err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,70 @@
+package rating
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"reflect"
+	"testing"
+)
+
+// change es una modificación del agregado tal como la hacen Save (previous
+// distinto de 0 al volver a puntuar) y Delete (score 0).
+type change struct {
+	previous, score int
+}
+
+func apply(a *aggregate, c change) {
+	if c.previous != 0 {
+		a.add(c.previous, -1)
+	}
+	if c.score != 0 {
+		a.add(c.score, 1)
+	}
+}
+
+func TestAggregateUpdates(t *testing.T) {
+	tests := []struct {
+		name          string
+		changes       []change
+		wantCount     int
+		wantAverage   float64
+		wantHistogram []int
+	}{
+		{"no ratings", nil, 0, 0, []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{"first rating", []change{{0, 8}}, 1, 8, []int{0, 0, 0, 0, 0, 0, 0, 1, 0, 0}},
+		{"several ratings", []change{{0, 10}, {0, 1}, {0, 10}}, 3, 7, []int{1, 0, 0, 0, 0, 0, 0, 0, 0, 2}},
+		{"rounds the average", []change{{0, 10}, {0, 9}, {0, 9}}, 3, 9.33, []int{0, 0, 0, 0, 0, 0, 0, 0, 2, 1}},
+		{"rating again moves the vote", []change{{0, 6}, {0, 7}, {6, 9}}, 2, 8, []int{0, 0, 0, 0, 0, 0, 1, 0, 1, 0}},
+		{"rating again with the same score", []change{{0, 5}, {5, 5}}, 1, 5, []int{0, 0, 0, 0, 1, 0, 0, 0, 0, 0}},
+		{"deleting a rating", []change{{0, 4}, {0, 8}, {8, 0}}, 1, 4, []int{0, 0, 0, 1, 0, 0, 0, 0, 0, 0}},
+		{"deleting the last rating", []change{{0, 3}, {3, 0}}, 0, 0, []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &aggregate{histogram: make([]int64, entities.MaxScore-entities.MinScore+1)}
+			for _, c := range tt.changes {
+				apply(a, c)
+			}
+
+			got := a.summary()
+			if got.Count != tt.wantCount {
+				t.Errorf("got count %d, want %d", got.Count, tt.wantCount)
+			}
+			if got.Average != tt.wantAverage {
+				t.Errorf("got average %v, want %v", got.Average, tt.wantAverage)
+			}
+			if !reflect.DeepEqual(got.Histogram, tt.wantHistogram) {
+				t.Errorf("got histogram %v, want %v", got.Histogram, tt.wantHistogram)
+			}
+		})
+	}
+}
+
+func TestSummaryWithoutAggregate(t *testing.T) {
+	// GetSummary deja el agregado vacío cuando el propietario no tiene fila.
+	got := (&aggregate{}).summary()
+
+	want := &entities.RatingSummary{Histogram: make([]int, entities.MaxScore-entities.MinScore+1)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
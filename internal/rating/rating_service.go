@@ -0,0 +1,45 @@
+package rating
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/rating")
+
+// priorVotes es el peso de la media global en el ranking: una temporada o
+// un episodio necesita unos priorVotes votos para que su media empiece a
+// pesar más que la global.
+const priorVotes = 10
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) Rate(ctx context.Context, rating *entities.Rating) (*entities.RatingSummary, error) {
+	ctx, span := tracer.Start(ctx, "rating.Service.Rate")
+	defer span.End()
+
+	return s.repository.Save(ctx, rating)
+}
+
+func (s *service) Unrate(ctx context.Context, ownerType string, ownerID, userID uint64) error {
+	ctx, span := tracer.Start(ctx, "rating.Service.Unrate")
+	defer span.End()
+
+	return s.repository.Delete(ctx, ownerType, ownerID, userID)
+}
+
+func (s *service) GetTopRated(ctx context.Context, ownerType string, limit int) ([]*entities.TopRated, error) {
+	ctx, span := tracer.Start(ctx, "rating.Service.GetTopRated")
+	defer span.End()
+
+	return s.repository.GetTopRated(ctx, ownerType, priorVotes, limit)
+}
@@ -96,3 +96,113 @@ func NewInternalServerErrorResponse(message string, err ...error) *InternalServe
 
 	return internalServerError
 }
+
+// UnauthorizedResponse representa una respuesta de credenciales ausentes o inválidas (401).
+type UnauthorizedResponse struct {
+	HttpResponse
+}
+
+func NewUnauthorizedResponse(message string, err ...error) *UnauthorizedResponse {
+
+	unauthorized := &UnauthorizedResponse{
+		HttpResponse: HttpResponse{
+			Message: message,
+			Err:     "Unauthorized",
+			Code:    http.StatusUnauthorized,
+		},
+	}
+
+	if len(err) > 0 && err[0] != nil {
+		unauthorized.Err = err[0].Error()
+	}
+
+	return unauthorized
+}
+
+// TooManyRequestsResponse representa una respuesta de límite de peticiones superado (429).
+type TooManyRequestsResponse struct {
+	HttpResponse
+}
+
+func NewTooManyRequestsResponse(message string, err ...error) *TooManyRequestsResponse {
+
+	tooManyRequests := &TooManyRequestsResponse{
+		HttpResponse: HttpResponse{
+			Message: message,
+			Err:     "Too Many Requests",
+			Code:    http.StatusTooManyRequests,
+		},
+	}
+
+	if len(err) > 0 && err[0] != nil {
+		tooManyRequests.Err = err[0].Error()
+	}
+
+	return tooManyRequests
+}
+
+// NotAcceptableResponse representa una respuesta de representación no disponible (406).
+type NotAcceptableResponse struct {
+	HttpResponse
+}
+
+func NewNotAcceptableResponse(message string, err ...error) *NotAcceptableResponse {
+
+	notAcceptable := &NotAcceptableResponse{
+		HttpResponse: HttpResponse{
+			Message: message,
+			Err:     "Not Acceptable",
+			Code:    http.StatusNotAcceptable,
+		},
+	}
+
+	if len(err) > 0 && err[0] != nil {
+		notAcceptable.Err = err[0].Error()
+	}
+
+	return notAcceptable
+}
+
+// ConflictResponse representa una respuesta de conflicto con el estado actual del recurso (409).
+type ConflictResponse struct {
+	HttpResponse
+}
+
+func NewConflictResponse(message string, err ...error) *ConflictResponse {
+
+	conflict := &ConflictResponse{
+		HttpResponse: HttpResponse{
+			Message: message,
+			Err:     "Conflict",
+			Code:    http.StatusConflict,
+		},
+	}
+
+	if len(err) > 0 && err[0] != nil {
+		conflict.Err = err[0].Error()
+	}
+
+	return conflict
+}
+
+// ForbiddenResponse representa una respuesta de acceso denegado a un usuario autenticado (403).
+type ForbiddenResponse struct {
+	HttpResponse
+}
+
+func NewForbiddenResponse(message string, err ...error) *ForbiddenResponse {
+
+	forbidden := &ForbiddenResponse{
+		HttpResponse: HttpResponse{
+			Message: message,
+			Err:     "Forbidden",
+			Code:    http.StatusForbidden,
+		},
+	}
+
+	if len(err) > 0 && err[0] != nil {
+		forbidden.Err = err[0].Error()
+	}
+
+	return forbidden
+}
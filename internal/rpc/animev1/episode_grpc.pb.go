@@ -0,0 +1,393 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: anime/v1/episode.proto
+
+package animev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EpisodeService_ListEpisodes_FullMethodName       = "/anime.v1.EpisodeService/ListEpisodes"
+	EpisodeService_ListLatestEpisodes_FullMethodName = "/anime.v1.EpisodeService/ListLatestEpisodes"
+	EpisodeService_GetEpisode_FullMethodName         = "/anime.v1.EpisodeService/GetEpisode"
+	EpisodeService_GetEpisodeBySlug_FullMethodName   = "/anime.v1.EpisodeService/GetEpisodeBySlug"
+	EpisodeService_CreateEpisode_FullMethodName      = "/anime.v1.EpisodeService/CreateEpisode"
+	EpisodeService_UpdateEpisode_FullMethodName      = "/anime.v1.EpisodeService/UpdateEpisode"
+	EpisodeService_DeleteEpisode_FullMethodName      = "/anime.v1.EpisodeService/DeleteEpisode"
+)
+
+// EpisodeServiceClient is the client API for EpisodeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EpisodeServiceClient interface {
+	// ListEpisodes envía todos los episodios, los de ids o los de las
+	// temporadas season_ids (ordenados por temporada y número).
+	ListEpisodes(ctx context.Context, in *ListEpisodesRequest, opts ...grpc.CallOption) (EpisodeService_ListEpisodesClient, error)
+	ListLatestEpisodes(ctx context.Context, in *ListLatestEpisodesRequest, opts ...grpc.CallOption) (EpisodeService_ListLatestEpisodesClient, error)
+	GetEpisode(ctx context.Context, in *GetEpisodeRequest, opts ...grpc.CallOption) (*Episode, error)
+	// GetEpisodeBySlug rellena season_slug en lugar de season_id.
+	GetEpisodeBySlug(ctx context.Context, in *GetEpisodeBySlugRequest, opts ...grpc.CallOption) (*Episode, error)
+	CreateEpisode(ctx context.Context, in *CreateEpisodeRequest, opts ...grpc.CallOption) (*Episode, error)
+	UpdateEpisode(ctx context.Context, in *UpdateEpisodeRequest, opts ...grpc.CallOption) (*Episode, error)
+	DeleteEpisode(ctx context.Context, in *DeleteEpisodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type episodeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEpisodeServiceClient(cc grpc.ClientConnInterface) EpisodeServiceClient {
+	return &episodeServiceClient{cc}
+}
+
+func (c *episodeServiceClient) ListEpisodes(ctx context.Context, in *ListEpisodesRequest, opts ...grpc.CallOption) (EpisodeService_ListEpisodesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EpisodeService_ServiceDesc.Streams[0], EpisodeService_ListEpisodes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &episodeServiceListEpisodesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EpisodeService_ListEpisodesClient interface {
+	Recv() (*Episode, error)
+	grpc.ClientStream
+}
+
+type episodeServiceListEpisodesClient struct {
+	grpc.ClientStream
+}
+
+func (x *episodeServiceListEpisodesClient) Recv() (*Episode, error) {
+	m := new(Episode)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *episodeServiceClient) ListLatestEpisodes(ctx context.Context, in *ListLatestEpisodesRequest, opts ...grpc.CallOption) (EpisodeService_ListLatestEpisodesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EpisodeService_ServiceDesc.Streams[1], EpisodeService_ListLatestEpisodes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &episodeServiceListLatestEpisodesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EpisodeService_ListLatestEpisodesClient interface {
+	Recv() (*EpisodeSummary, error)
+	grpc.ClientStream
+}
+
+type episodeServiceListLatestEpisodesClient struct {
+	grpc.ClientStream
+}
+
+func (x *episodeServiceListLatestEpisodesClient) Recv() (*EpisodeSummary, error) {
+	m := new(EpisodeSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *episodeServiceClient) GetEpisode(ctx context.Context, in *GetEpisodeRequest, opts ...grpc.CallOption) (*Episode, error) {
+	out := new(Episode)
+	err := c.cc.Invoke(ctx, EpisodeService_GetEpisode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *episodeServiceClient) GetEpisodeBySlug(ctx context.Context, in *GetEpisodeBySlugRequest, opts ...grpc.CallOption) (*Episode, error) {
+	out := new(Episode)
+	err := c.cc.Invoke(ctx, EpisodeService_GetEpisodeBySlug_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *episodeServiceClient) CreateEpisode(ctx context.Context, in *CreateEpisodeRequest, opts ...grpc.CallOption) (*Episode, error) {
+	out := new(Episode)
+	err := c.cc.Invoke(ctx, EpisodeService_CreateEpisode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *episodeServiceClient) UpdateEpisode(ctx context.Context, in *UpdateEpisodeRequest, opts ...grpc.CallOption) (*Episode, error) {
+	out := new(Episode)
+	err := c.cc.Invoke(ctx, EpisodeService_UpdateEpisode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *episodeServiceClient) DeleteEpisode(ctx context.Context, in *DeleteEpisodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, EpisodeService_DeleteEpisode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EpisodeServiceServer is the server API for EpisodeService service.
+// All implementations must embed UnimplementedEpisodeServiceServer
+// for forward compatibility
+type EpisodeServiceServer interface {
+	// ListEpisodes envía todos los episodios, los de ids o los de las
+	// temporadas season_ids (ordenados por temporada y número).
+	ListEpisodes(*ListEpisodesRequest, EpisodeService_ListEpisodesServer) error
+	ListLatestEpisodes(*ListLatestEpisodesRequest, EpisodeService_ListLatestEpisodesServer) error
+	GetEpisode(context.Context, *GetEpisodeRequest) (*Episode, error)
+	// GetEpisodeBySlug rellena season_slug en lugar de season_id.
+	GetEpisodeBySlug(context.Context, *GetEpisodeBySlugRequest) (*Episode, error)
+	CreateEpisode(context.Context, *CreateEpisodeRequest) (*Episode, error)
+	UpdateEpisode(context.Context, *UpdateEpisodeRequest) (*Episode, error)
+	DeleteEpisode(context.Context, *DeleteEpisodeRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedEpisodeServiceServer()
+}
+
+// UnimplementedEpisodeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEpisodeServiceServer struct {
+}
+
+func (UnimplementedEpisodeServiceServer) ListEpisodes(*ListEpisodesRequest, EpisodeService_ListEpisodesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListEpisodes not implemented")
+}
+func (UnimplementedEpisodeServiceServer) ListLatestEpisodes(*ListLatestEpisodesRequest, EpisodeService_ListLatestEpisodesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListLatestEpisodes not implemented")
+}
+func (UnimplementedEpisodeServiceServer) GetEpisode(context.Context, *GetEpisodeRequest) (*Episode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEpisode not implemented")
+}
+func (UnimplementedEpisodeServiceServer) GetEpisodeBySlug(context.Context, *GetEpisodeBySlugRequest) (*Episode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEpisodeBySlug not implemented")
+}
+func (UnimplementedEpisodeServiceServer) CreateEpisode(context.Context, *CreateEpisodeRequest) (*Episode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEpisode not implemented")
+}
+func (UnimplementedEpisodeServiceServer) UpdateEpisode(context.Context, *UpdateEpisodeRequest) (*Episode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEpisode not implemented")
+}
+func (UnimplementedEpisodeServiceServer) DeleteEpisode(context.Context, *DeleteEpisodeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEpisode not implemented")
+}
+func (UnimplementedEpisodeServiceServer) mustEmbedUnimplementedEpisodeServiceServer() {}
+
+// UnsafeEpisodeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EpisodeServiceServer will
+// result in compilation errors.
+type UnsafeEpisodeServiceServer interface {
+	mustEmbedUnimplementedEpisodeServiceServer()
+}
+
+func RegisterEpisodeServiceServer(s grpc.ServiceRegistrar, srv EpisodeServiceServer) {
+	s.RegisterService(&EpisodeService_ServiceDesc, srv)
+}
+
+func _EpisodeService_ListEpisodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListEpisodesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EpisodeServiceServer).ListEpisodes(m, &episodeServiceListEpisodesServer{stream})
+}
+
+type EpisodeService_ListEpisodesServer interface {
+	Send(*Episode) error
+	grpc.ServerStream
+}
+
+type episodeServiceListEpisodesServer struct {
+	grpc.ServerStream
+}
+
+func (x *episodeServiceListEpisodesServer) Send(m *Episode) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EpisodeService_ListLatestEpisodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListLatestEpisodesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EpisodeServiceServer).ListLatestEpisodes(m, &episodeServiceListLatestEpisodesServer{stream})
+}
+
+type EpisodeService_ListLatestEpisodesServer interface {
+	Send(*EpisodeSummary) error
+	grpc.ServerStream
+}
+
+type episodeServiceListLatestEpisodesServer struct {
+	grpc.ServerStream
+}
+
+func (x *episodeServiceListLatestEpisodesServer) Send(m *EpisodeSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EpisodeService_GetEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EpisodeServiceServer).GetEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EpisodeService_GetEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EpisodeServiceServer).GetEpisode(ctx, req.(*GetEpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EpisodeService_GetEpisodeBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEpisodeBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EpisodeServiceServer).GetEpisodeBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EpisodeService_GetEpisodeBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EpisodeServiceServer).GetEpisodeBySlug(ctx, req.(*GetEpisodeBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EpisodeService_CreateEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EpisodeServiceServer).CreateEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EpisodeService_CreateEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EpisodeServiceServer).CreateEpisode(ctx, req.(*CreateEpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EpisodeService_UpdateEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EpisodeServiceServer).UpdateEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EpisodeService_UpdateEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EpisodeServiceServer).UpdateEpisode(ctx, req.(*UpdateEpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EpisodeService_DeleteEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EpisodeServiceServer).DeleteEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EpisodeService_DeleteEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EpisodeServiceServer).DeleteEpisode(ctx, req.(*DeleteEpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EpisodeService_ServiceDesc is the grpc.ServiceDesc for EpisodeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EpisodeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "anime.v1.EpisodeService",
+	HandlerType: (*EpisodeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEpisode",
+			Handler:    _EpisodeService_GetEpisode_Handler,
+		},
+		{
+			MethodName: "GetEpisodeBySlug",
+			Handler:    _EpisodeService_GetEpisodeBySlug_Handler,
+		},
+		{
+			MethodName: "CreateEpisode",
+			Handler:    _EpisodeService_CreateEpisode_Handler,
+		},
+		{
+			MethodName: "UpdateEpisode",
+			Handler:    _EpisodeService_UpdateEpisode_Handler,
+		},
+		{
+			MethodName: "DeleteEpisode",
+			Handler:    _EpisodeService_DeleteEpisode_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListEpisodes",
+			Handler:       _EpisodeService_ListEpisodes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListLatestEpisodes",
+			Handler:       _EpisodeService_ListLatestEpisodes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "anime/v1/episode.proto",
+}
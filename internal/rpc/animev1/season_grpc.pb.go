@@ -0,0 +1,288 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: anime/v1/season.proto
+
+package animev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SeasonService_ListSeasons_FullMethodName  = "/anime.v1.SeasonService/ListSeasons"
+	SeasonService_GetSeason_FullMethodName    = "/anime.v1.SeasonService/GetSeason"
+	SeasonService_CreateSeason_FullMethodName = "/anime.v1.SeasonService/CreateSeason"
+	SeasonService_UpdateSeason_FullMethodName = "/anime.v1.SeasonService/UpdateSeason"
+	SeasonService_DeleteSeason_FullMethodName = "/anime.v1.SeasonService/DeleteSeason"
+)
+
+// SeasonServiceClient is the client API for SeasonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SeasonServiceClient interface {
+	// ListSeasons envía todas las temporadas o, si se indican ids, sólo esas.
+	ListSeasons(ctx context.Context, in *ListSeasonsRequest, opts ...grpc.CallOption) (SeasonService_ListSeasonsClient, error)
+	GetSeason(ctx context.Context, in *GetSeasonRequest, opts ...grpc.CallOption) (*Season, error)
+	CreateSeason(ctx context.Context, in *CreateSeasonRequest, opts ...grpc.CallOption) (*Season, error)
+	UpdateSeason(ctx context.Context, in *UpdateSeasonRequest, opts ...grpc.CallOption) (*Season, error)
+	DeleteSeason(ctx context.Context, in *DeleteSeasonRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type seasonServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSeasonServiceClient(cc grpc.ClientConnInterface) SeasonServiceClient {
+	return &seasonServiceClient{cc}
+}
+
+func (c *seasonServiceClient) ListSeasons(ctx context.Context, in *ListSeasonsRequest, opts ...grpc.CallOption) (SeasonService_ListSeasonsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SeasonService_ServiceDesc.Streams[0], SeasonService_ListSeasons_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seasonServiceListSeasonsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SeasonService_ListSeasonsClient interface {
+	Recv() (*Season, error)
+	grpc.ClientStream
+}
+
+type seasonServiceListSeasonsClient struct {
+	grpc.ClientStream
+}
+
+func (x *seasonServiceListSeasonsClient) Recv() (*Season, error) {
+	m := new(Season)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seasonServiceClient) GetSeason(ctx context.Context, in *GetSeasonRequest, opts ...grpc.CallOption) (*Season, error) {
+	out := new(Season)
+	err := c.cc.Invoke(ctx, SeasonService_GetSeason_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seasonServiceClient) CreateSeason(ctx context.Context, in *CreateSeasonRequest, opts ...grpc.CallOption) (*Season, error) {
+	out := new(Season)
+	err := c.cc.Invoke(ctx, SeasonService_CreateSeason_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seasonServiceClient) UpdateSeason(ctx context.Context, in *UpdateSeasonRequest, opts ...grpc.CallOption) (*Season, error) {
+	out := new(Season)
+	err := c.cc.Invoke(ctx, SeasonService_UpdateSeason_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seasonServiceClient) DeleteSeason(ctx context.Context, in *DeleteSeasonRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SeasonService_DeleteSeason_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SeasonServiceServer is the server API for SeasonService service.
+// All implementations must embed UnimplementedSeasonServiceServer
+// for forward compatibility
+type SeasonServiceServer interface {
+	// ListSeasons envía todas las temporadas o, si se indican ids, sólo esas.
+	ListSeasons(*ListSeasonsRequest, SeasonService_ListSeasonsServer) error
+	GetSeason(context.Context, *GetSeasonRequest) (*Season, error)
+	CreateSeason(context.Context, *CreateSeasonRequest) (*Season, error)
+	UpdateSeason(context.Context, *UpdateSeasonRequest) (*Season, error)
+	DeleteSeason(context.Context, *DeleteSeasonRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSeasonServiceServer()
+}
+
+// UnimplementedSeasonServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSeasonServiceServer struct {
+}
+
+func (UnimplementedSeasonServiceServer) ListSeasons(*ListSeasonsRequest, SeasonService_ListSeasonsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSeasons not implemented")
+}
+func (UnimplementedSeasonServiceServer) GetSeason(context.Context, *GetSeasonRequest) (*Season, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeason not implemented")
+}
+func (UnimplementedSeasonServiceServer) CreateSeason(context.Context, *CreateSeasonRequest) (*Season, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeason not implemented")
+}
+func (UnimplementedSeasonServiceServer) UpdateSeason(context.Context, *UpdateSeasonRequest) (*Season, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSeason not implemented")
+}
+func (UnimplementedSeasonServiceServer) DeleteSeason(context.Context, *DeleteSeasonRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSeason not implemented")
+}
+func (UnimplementedSeasonServiceServer) mustEmbedUnimplementedSeasonServiceServer() {}
+
+// UnsafeSeasonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SeasonServiceServer will
+// result in compilation errors.
+type UnsafeSeasonServiceServer interface {
+	mustEmbedUnimplementedSeasonServiceServer()
+}
+
+func RegisterSeasonServiceServer(s grpc.ServiceRegistrar, srv SeasonServiceServer) {
+	s.RegisterService(&SeasonService_ServiceDesc, srv)
+}
+
+func _SeasonService_ListSeasons_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSeasonsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeasonServiceServer).ListSeasons(m, &seasonServiceListSeasonsServer{stream})
+}
+
+type SeasonService_ListSeasonsServer interface {
+	Send(*Season) error
+	grpc.ServerStream
+}
+
+type seasonServiceListSeasonsServer struct {
+	grpc.ServerStream
+}
+
+func (x *seasonServiceListSeasonsServer) Send(m *Season) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SeasonService_GetSeason_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeasonServiceServer).GetSeason(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeasonService_GetSeason_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeasonServiceServer).GetSeason(ctx, req.(*GetSeasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeasonService_CreateSeason_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeasonServiceServer).CreateSeason(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeasonService_CreateSeason_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeasonServiceServer).CreateSeason(ctx, req.(*CreateSeasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeasonService_UpdateSeason_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSeasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeasonServiceServer).UpdateSeason(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeasonService_UpdateSeason_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeasonServiceServer).UpdateSeason(ctx, req.(*UpdateSeasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeasonService_DeleteSeason_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSeasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeasonServiceServer).DeleteSeason(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeasonService_DeleteSeason_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeasonServiceServer).DeleteSeason(ctx, req.(*DeleteSeasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SeasonService_ServiceDesc is the grpc.ServiceDesc for SeasonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SeasonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "anime.v1.SeasonService",
+	HandlerType: (*SeasonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSeason",
+			Handler:    _SeasonService_GetSeason_Handler,
+		},
+		{
+			MethodName: "CreateSeason",
+			Handler:    _SeasonService_CreateSeason_Handler,
+		},
+		{
+			MethodName: "UpdateSeason",
+			Handler:    _SeasonService_UpdateSeason_Handler,
+		},
+		{
+			MethodName: "DeleteSeason",
+			Handler:    _SeasonService_DeleteSeason_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSeasons",
+			Handler:       _SeasonService_ListSeasons_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "anime/v1/season.proto",
+}
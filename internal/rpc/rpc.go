@@ -0,0 +1,109 @@
+// Package rpc sirve los servicios de temporadas y episodios por gRPC en un
+// puerto propio, con la misma autenticación y el mismo límite de peticiones
+// que la API HTTP. El código de internal/rpc/animev1 se genera a partir de
+// proto/anime/v1 con go generate.
+package rpc
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/wicho90/anime-api --go-grpc_out=../.. --go-grpc_opt=module=github.com/wicho90/anime-api anime/v1/season.proto anime/v1/episode.proto
+
+import (
+	"context"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/rpc/animev1"
+	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/validator"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"log"
+	"net"
+)
+
+// Server agrupa el servidor gRPC y su servicio de salud, que pasa a
+// NOT_SERVING al empezar la parada.
+type Server struct {
+	server *grpc.Server
+	health *health.Server
+}
+
+// New crea el servidor con las trazas de otelgrpc y, detrás, la
+// autenticación y el límite de peticiones de guard.
+func New(
+	seasonService season.Service,
+	episodeService episode.Service,
+	validator validator.Validator,
+	authRepository auth.Repository,
+	rateLimitStore ratelimit.Store,
+	tp trace.TracerProvider,
+	config *config.Config,
+) *Server {
+	g := &guard{repository: authRepository, store: rateLimitStore, config: config}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(otelgrpc.WithTracerProvider(tp)), g.unary),
+		grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor(otelgrpc.WithTracerProvider(tp)), g.stream),
+	)
+	animev1.RegisterSeasonServiceServer(server, &seasonServer{service: seasonService, validator: validator})
+	animev1.RegisterEpisodeServiceServer(server, &episodeServer{service: episodeService, validator: validator})
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return &Server{server: server, health: healthServer}
+}
+
+func Start(lc fx.Lifecycle, shutdowner fx.Shutdowner, s *Server, config *config.Config) {
+	if !config.GRPC.Enabled {
+		return
+	}
+
+	addr := fmt.Sprintf(":%s", config.GRPC.Port)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			log.Printf("gRPC server listening on %s", addr)
+
+			go func() {
+				if err := s.server.Serve(ln); err != nil {
+					log.Printf("gRPC server stopped: %s", err)
+					_ = shutdowner.Shutdown()
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			s.health.Shutdown()
+
+			ctx, cancel := context.WithTimeout(ctx, config.Server.ShutdownTimeout)
+			defer cancel()
+
+			stopped := make(chan struct{})
+			go func() {
+				s.server.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				log.Println("gRPC graceful stop timed out, closing connections")
+				s.server.Stop()
+			}
+
+			return nil
+		},
+	})
+}
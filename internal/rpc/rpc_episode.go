@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/rpc/animev1"
+	"github.com/wicho90/anime-api/internal/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type episodeServer struct {
+	animev1.UnimplementedEpisodeServiceServer
+	service   episode.Service
+	validator validator.Validator
+}
+
+func (s *episodeServer) ListEpisodes(req *animev1.ListEpisodesRequest, stream animev1.EpisodeService_ListEpisodesServer) error {
+	ctx := stream.Context()
+
+	var episodes []*entities.Episode
+	var err error
+	switch {
+	case len(req.Ids) > 0 && len(req.SeasonIds) > 0:
+		return status.Error(codes.InvalidArgument, "ids and season_ids cannot be combined")
+	case len(req.Ids) > 0:
+		episodes, err = s.service.GetByIDs(ctx, req.Ids)
+	case len(req.SeasonIds) > 0:
+		episodes, err = s.service.GetBySeasonIDs(ctx, req.SeasonIds)
+	default:
+		episodes, err = s.service.GetAll(ctx)
+	}
+	if err != nil {
+		return toStatus(err, "Failed to get episodes")
+	}
+
+	for _, episode := range episodes {
+		if err := stream.Send(toEpisode(episode)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *episodeServer) ListLatestEpisodes(req *animev1.ListLatestEpisodesRequest, stream animev1.EpisodeService_ListLatestEpisodesServer) error {
+	episodes, err := s.service.GetLatest(stream.Context())
+	if err != nil {
+		return toStatus(err, "Failed to get episodes")
+	}
+
+	for _, episode := range episodes {
+		summary := &animev1.EpisodeSummary{
+			Id:             episode.ID,
+			Name:           episode.Name,
+			Slug:           episode.Slug,
+			SeasonImageUrl: episode.Season.ImageUrl,
+		}
+		if err := stream.Send(summary); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *episodeServer) GetEpisode(ctx context.Context, req *animev1.GetEpisodeRequest) (*animev1.Episode, error) {
+	episode, err := s.service.GetByID(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err, "Failed to get episode")
+	}
+
+	return toEpisode(episode), nil
+}
+
+func (s *episodeServer) GetEpisodeBySlug(ctx context.Context, req *animev1.GetEpisodeBySlugRequest) (*animev1.Episode, error) {
+	episode, err := s.service.GetBySlug(ctx, req.Slug)
+	if err != nil {
+		return nil, toStatus(err, "Failed to get episode")
+	}
+
+	return &animev1.Episode{
+		Id:         episode.ID,
+		Name:       episode.Name,
+		Number:     uint32(episode.Number),
+		Duration:   episode.Duration,
+		Url:        episode.Url,
+		Slug:       episode.Slug,
+		SeasonSlug: episode.Season.Slug,
+	}, nil
+}
+
+func (s *episodeServer) CreateEpisode(ctx context.Context, req *animev1.CreateEpisodeRequest) (*animev1.Episode, error) {
+	episode := &entities.Episode{
+		Name:     req.Name,
+		Number:   uint8(req.Number),
+		Duration: req.Duration,
+		Url:      req.Url,
+		SeasonId: req.SeasonId,
+	}
+	if m, err := s.validator.Validate(episode); err != nil {
+		return nil, status.Error(codes.InvalidArgument, m)
+	}
+
+	if err := s.service.Create(ctx, episode); err != nil {
+		return nil, toStatus(err, "Failed to create episode")
+	}
+
+	return toEpisode(episode), nil
+}
+
+func (s *episodeServer) UpdateEpisode(ctx context.Context, req *animev1.UpdateEpisodeRequest) (*animev1.Episode, error) {
+	episode := &entities.Episode{
+		Name:     req.Name,
+		Number:   uint8(req.Number),
+		Duration: req.Duration,
+		Url:      req.Url,
+		SeasonId: req.SeasonId,
+	}
+	if m, err := s.validator.Validate(episode); err != nil {
+		return nil, status.Error(codes.InvalidArgument, m)
+	}
+
+	if err := s.service.Update(ctx, req.Id, episode); err != nil {
+		return nil, toStatus(err, "Failed to update episode")
+	}
+
+	return toEpisode(episode), nil
+}
+
+func (s *episodeServer) DeleteEpisode(ctx context.Context, req *animev1.DeleteEpisodeRequest) (*emptypb.Empty, error) {
+	if err := s.service.Delete(ctx, req.Id); err != nil {
+		return nil, toStatus(err, "Failed to delete episode")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toEpisode(episode *entities.Episode) *animev1.Episode {
+	return &animev1.Episode{
+		Id:       episode.ID,
+		Name:     episode.Name,
+		Number:   uint32(episode.Number),
+		Duration: episode.Duration,
+		Url:      episode.Url,
+		Slug:     episode.Slug,
+		SeasonId: episode.SeasonId,
+	}
+}
@@ -0,0 +1,28 @@
+package rpc
+
+import (
+	"errors"
+	"github.com/wicho90/anime-api/internal/ex"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus traduce los errores del servicio como los handlers REST: no
+// encontrado → NotFound (el 404) y los errores desconocidos → Internal con
+// el mismo mensaje genérico que el 500. Los errores de restricción de ex se
+// distinguen además como AlreadyExists e InvalidArgument.
+func toStatus(err error, message string) error {
+	var alreadyExists *ex.ErrAlreadyExists
+	var validation *ex.ErrValidation
+
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &alreadyExists):
+		return status.Error(codes.AlreadyExists, alreadyExists.Error())
+	case errors.As(err, &validation):
+		return status.Error(codes.InvalidArgument, validation.Error())
+	}
+
+	return status.Error(codes.Internal, message)
+}
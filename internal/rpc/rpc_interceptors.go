@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/rpc/animev1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// adminMethods son los métodos que modifican el catálogo; como las rutas
+// de escritura de /api/v2 y las mutaciones de GraphQL, sólo los pueden
+// llamar administradores.
+var adminMethods = map[string]bool{
+	animev1.SeasonService_CreateSeason_FullMethodName:   true,
+	animev1.SeasonService_UpdateSeason_FullMethodName:   true,
+	animev1.SeasonService_DeleteSeason_FullMethodName:   true,
+	animev1.EpisodeService_CreateEpisode_FullMethodName: true,
+	animev1.EpisodeService_UpdateEpisode_FullMethodName: true,
+	animev1.EpisodeService_DeleteEpisode_FullMethodName: true,
+}
+
+// guard aplica a gRPC las mismas reglas que auth.Middleware y ratelimit a
+// HTTP: identifica al usuario por el metadato "authorization: Bearer
+// <token>", cobra la llamada al bucket del usuario o, si no lo hay, al de
+// la IP, y restringe los métodos de escritura a administradores.
+type guard struct {
+	repository auth.Repository
+	store      ratelimit.Store
+	config     *config.Config
+}
+
+func (g *guard) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := g.admit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (g *guard) stream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := g.admit(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+func (g *guard) admit(ctx context.Context, method string) error {
+	group, policy := ratelimit.GroupRead, g.config.RateLimit.Read
+	if adminMethods[method] {
+		group, policy = ratelimit.GroupWrite, g.config.RateLimit.Write
+	}
+	ipKey, ipBudget := group+":ip:"+peerIP(ctx), newBudget(policy.IP)
+
+	token, hasToken, err := bearerToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var user *entities.User
+	if hasToken {
+		// Como ratelimit.Guard: sin fichas en la IP no se consulta la base
+		// de datos y cada token rechazado consume una.
+		if !g.allow(ctx, ipKey, ipBudget, false) {
+			return status.Error(codes.ResourceExhausted, "Rate limit exceeded")
+		}
+
+		user, err = g.repository.GetByTokenHash(ctx, auth.HashToken(token))
+		if err != nil {
+			if errors.Is(err, ex.ErrNotFound) {
+				g.allow(ctx, ipKey, ipBudget, true)
+				return status.Error(codes.Unauthenticated, "Invalid token")
+			}
+
+			return status.Error(codes.Internal, "Failed to authenticate")
+		}
+	}
+
+	key, budget := ipKey, ipBudget
+	if user != nil {
+		key, budget = group+":user:"+strconv.FormatUint(user.ID, 10), newBudget(policy.User)
+	}
+	if !g.allow(ctx, key, budget, true) {
+		return status.Error(codes.ResourceExhausted, "Rate limit exceeded")
+	}
+
+	if adminMethods[method] {
+		if user == nil {
+			return status.Error(codes.Unauthenticated, "Authentication required")
+		}
+		if !user.IsAdmin() {
+			return status.Error(codes.PermissionDenied, "Administrator role required")
+		}
+	}
+
+	return nil
+}
+
+// allow consulta (take=false) o consume (take=true) una ficha del bucket. Si
+// el limitador está desactivado o el store falla la llamada se deja pasar.
+func (g *guard) allow(ctx context.Context, key string, budget ratelimit.Budget, take bool) bool {
+	if !g.config.RateLimit.Enabled {
+		return true
+	}
+
+	var result ratelimit.Result
+	var err error
+	if take {
+		result, err = g.store.Take(ctx, key, budget)
+	} else {
+		result, err = g.store.Peek(ctx, key, budget)
+	}
+	if err != nil {
+		log.Printf("rate limit store failed: %s", err)
+		return true
+	}
+
+	return result.Allowed
+}
+
+// bearerToken devuelve el token del metadato authorization; sin metadato la
+// llamada continúa como anónima.
+func bearerToken(ctx context.Context) (string, bool, error) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return "", false, nil
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return "", false, status.Error(codes.Unauthenticated, "Invalid authorization metadata")
+	}
+
+	return token, true, nil
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func newBudget(budget config.RateLimitBudget) ratelimit.Budget {
+	return ratelimit.Budget{Requests: budget.Requests, Period: budget.Period, Burst: budget.Burst}
+}
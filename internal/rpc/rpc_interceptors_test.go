@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/rpc/animev1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+type authRepository struct{}
+
+func (authRepository) GetByTokenHash(_ context.Context, tokenHash string) (*entities.User, error) {
+	switch tokenHash {
+	case auth.HashToken("admin"):
+		return &entities.User{ID: 1, Username: "admin", Role: entities.RoleAdmin}, nil
+	case auth.HashToken("user"):
+		return &entities.User{ID: 2, Username: "user", Role: entities.RoleUser}, nil
+	}
+	return nil, fmt.Errorf("user %w", ex.ErrNotFound)
+}
+
+func (authRepository) Create(context.Context, *entities.User, string) error {
+	return nil
+}
+
+func newGuard(t *testing.T) *guard {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.RateLimit.Enabled = true
+	cfg.RateLimit.Read.IP = config.RateLimitBudget{Requests: 1, Period: time.Hour, Burst: 3}
+	cfg.RateLimit.Read.User = config.RateLimitBudget{Requests: 1, Period: time.Hour, Burst: 10}
+	cfg.RateLimit.Write = cfg.RateLimit.Read
+
+	store := ratelimit.NewMemoryStore()
+	t.Cleanup(store.Close)
+
+	return &guard{repository: authRepository{}, store: store, config: cfg}
+}
+
+func withToken(token string) context.Context {
+	if token == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestGuardAdmit(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		want   codes.Code
+	}{
+		{"anonymous read", animev1.SeasonService_GetSeason_FullMethodName, "", codes.OK},
+		{"user read", animev1.EpisodeService_ListEpisodes_FullMethodName, "user", codes.OK},
+		{"invalid token", animev1.SeasonService_GetSeason_FullMethodName, "nope", codes.Unauthenticated},
+		{"anonymous write", animev1.SeasonService_CreateSeason_FullMethodName, "", codes.Unauthenticated},
+		{"user write", animev1.EpisodeService_DeleteEpisode_FullMethodName, "user", codes.PermissionDenied},
+		{"admin write", animev1.EpisodeService_UpdateEpisode_FullMethodName, "admin", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newGuard(t).admit(withToken(tt.token), tt.method)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardChargesFailedAuthentication(t *testing.T) {
+	g := newGuard(t)
+	method := animev1.SeasonService_GetSeason_FullMethodName
+
+	for i := 0; i < 3; i++ {
+		if got := status.Code(g.admit(withToken("nope"), method)); got != codes.Unauthenticated {
+			t.Fatalf("attempt %d: got %s, want %s", i+1, got, codes.Unauthenticated)
+		}
+	}
+	if got := status.Code(g.admit(withToken("nope"), method)); got != codes.ResourceExhausted {
+		t.Errorf("got %s, want %s", got, codes.ResourceExhausted)
+	}
+	if got := status.Code(g.admit(withToken("user"), method)); got != codes.ResourceExhausted {
+		t.Errorf("got %s, want %s once the IP has no tokens left", got, codes.ResourceExhausted)
+	}
+}
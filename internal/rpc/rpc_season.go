@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/rpc/animev1"
+	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type seasonServer struct {
+	animev1.UnimplementedSeasonServiceServer
+	service   season.Service
+	validator validator.Validator
+}
+
+func (s *seasonServer) ListSeasons(req *animev1.ListSeasonsRequest, stream animev1.SeasonService_ListSeasonsServer) error {
+	ctx := stream.Context()
+
+	var seasons []*entities.Season
+	var err error
+	if len(req.Ids) > 0 {
+		seasons, err = s.service.GetByIds(ctx, req.Ids)
+	} else {
+		seasons, err = s.service.GetAll(ctx)
+	}
+	if err != nil {
+		return toStatus(err, "Failed to get seasons")
+	}
+
+	for _, season := range seasons {
+		if err := stream.Send(toSeason(season)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *seasonServer) GetSeason(ctx context.Context, req *animev1.GetSeasonRequest) (*animev1.Season, error) {
+	season, err := s.service.GetById(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err, "Failed to get season")
+	}
+
+	return toSeason(season), nil
+}
+
+func (s *seasonServer) CreateSeason(ctx context.Context, req *animev1.CreateSeasonRequest) (*animev1.Season, error) {
+	season := &entities.Season{Name: req.Name, Number: uint8(req.Number), ImageUrl: req.ImageUrl}
+	if m, err := s.validator.Validate(season); err != nil {
+		return nil, status.Error(codes.InvalidArgument, m)
+	}
+
+	if err := s.service.Create(ctx, season); err != nil {
+		return nil, toStatus(err, "Failed to create season")
+	}
+
+	return toSeason(season), nil
+}
+
+func (s *seasonServer) UpdateSeason(ctx context.Context, req *animev1.UpdateSeasonRequest) (*animev1.Season, error) {
+	season := &entities.Season{Name: req.Name, Number: uint8(req.Number), ImageUrl: req.ImageUrl}
+	if m, err := s.validator.Validate(season); err != nil {
+		return nil, status.Error(codes.InvalidArgument, m)
+	}
+
+	if err := s.service.Update(ctx, req.Id, season); err != nil {
+		return nil, toStatus(err, "Failed to update season")
+	}
+
+	return toSeason(season), nil
+}
+
+func (s *seasonServer) DeleteSeason(ctx context.Context, req *animev1.DeleteSeasonRequest) (*emptypb.Empty, error) {
+	if err := s.service.Delete(ctx, req.Id); err != nil {
+		return nil, toStatus(err, "Failed to delete season")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toSeason(season *entities.Season) *animev1.Season {
+	return &animev1.Season{
+		Id:       season.ID,
+		Name:     season.Name,
+		Number:   uint32(season.Number),
+		Slug:     season.Slug,
+		ImageUrl: season.ImageUrl,
+	}
+}
@@ -0,0 +1,143 @@
+// Package schedule gestiona las emisiones programadas de los episodios y el
+// calendario semanal.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"regexp"
+	"strconv"
+	"time"
+	// Las zonas horarias se embeben para no depender de las del sistema.
+	_ "time/tzdata"
+)
+
+var weekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// Week es una semana ISO, de lunes a domingo, en una zona horaria.
+type Week struct {
+	Year     int
+	Number   int
+	Location *time.Location
+}
+
+// CurrentWeek devuelve la semana a la que pertenece now en loc.
+func CurrentWeek(now time.Time, loc *time.Location) Week {
+	year, number := now.In(loc).ISOWeek()
+	return Week{Year: year, Number: number, Location: loc}
+}
+
+// ParseWeek lee una semana con el formato ISO "2026-W43".
+func ParseWeek(value string, loc *time.Location) (Week, error) {
+	match := weekPattern.FindStringSubmatch(value)
+	if match == nil {
+		return Week{}, errors.New("week must have the format YYYY-Www")
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	number, _ := strconv.Atoi(match[2])
+	week := Week{Year: year, Number: number, Location: loc}
+	// Sólo algunos años tienen semana 53; si no, el lunes cae en el año
+	// siguiente.
+	if y, n := week.Start().ISOWeek(); number < 1 || y != year || n != number {
+		return Week{}, fmt.Errorf("week %s does not exist", value)
+	}
+
+	return week, nil
+}
+
+// Start devuelve el lunes de la semana a las 00:00 en su zona horaria.
+func (w Week) Start() time.Time {
+	// El 4 de enero siempre está en la primera semana del año.
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, w.Location)
+	offset := (int(jan4.Weekday()) + 6) % 7
+
+	return jan4.AddDate(0, 0, (w.Number-1)*7-offset)
+}
+
+// End devuelve el lunes de la semana siguiente a las 00:00.
+func (w Week) End() time.Time {
+	return w.Start().AddDate(0, 0, 7)
+}
+
+func (w Week) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Number)
+}
+
+// Day son las emisiones de un día de la semana, en orden.
+type Day struct {
+	Date    time.Time
+	Airings []*entities.Airing
+}
+
+// LoadLocation carga una zona horaria IANA, como "Asia/Tokyo". "UTC" es la
+// única abreviatura aceptada; Local se rechaza porque depende del servidor.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+
+	return time.LoadLocation(name)
+}
+
+// shiftLocal mueve days días la emisión en su hora local, de modo que un
+// cambio de horario de verano entre las dos fechas no la desplaza. Una hora
+// local que no existe porque el reloj se adelanta se cuenta con el horario
+// anterior al cambio: las 02:30 pasan a ser las 03:30.
+func shiftLocal(airsAt time.Time, timeZone string, days int) (time.Time, error) {
+	loc, err := LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := airsAt.In(loc)
+	shifted := local.AddDate(0, 0, days)
+	if shifted.Hour() == local.Hour() && shifted.Minute() == local.Minute() {
+		return shifted, nil
+	}
+
+	year, month, day := time.Date(local.Year(), local.Month(), local.Day()+days, 0, 0, 0, 0, time.UTC).Date()
+	wall := time.Date(year, month, day, local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	_, offset := shifted.Zone()
+
+	return wall.Add(-time.Duration(offset) * time.Second).In(loc), nil
+}
+
+type Repository interface {
+	// GetBetween devuelve las emisiones en [from, to) por orden de emisión.
+	GetBetween(ctx context.Context, from, to time.Time) ([]*entities.Airing, error)
+	GetBetweenBySeasonID(ctx context.Context, seasonID uint64, from, to time.Time) ([]*entities.Airing, error)
+	// GetBetweenByUserID limita las emisiones a las temporadas de la lista
+	// del usuario con alguno de los estados indicados.
+	GetBetweenByUserID(ctx context.Context, userID uint64, statuses []string, from, to time.Time) ([]*entities.Airing, error)
+	// GetNext devuelve la primera emisión de la temporada posterior a after,
+	// o nil si no hay ninguna.
+	GetNext(ctx context.Context, seasonID uint64, after time.Time) (*entities.Airing, error)
+	// Save crea o reemplaza la emisión del episodio.
+	Save(ctx context.Context, airing *entities.Airing) error
+	Delete(ctx context.Context, episodeID uint64) error
+	// Shift mueve days días, en la hora local de cada emisión, las
+	// emisiones de la temporada posteriores a after y devuelve cuántas ha
+	// movido.
+	Shift(ctx context.Context, seasonID uint64, days int, after time.Time) (int64, error)
+}
+
+type Service interface {
+	// GetWeek devuelve los siete días de la semana con sus emisiones.
+	GetWeek(ctx context.Context, week Week) ([]*Day, error)
+	Save(ctx context.Context, airing *entities.Airing) error
+	Delete(ctx context.Context, episodeID uint64) error
+	// Shift retrasa (o adelanta, con days negativo) las emisiones que
+	// quedan de la temporada.
+	Shift(ctx context.Context, seasonID uint64, days int) (int64, error)
+}
+
+type Handler interface {
+	GetWeek(ctx *fiber.Ctx) error
+	Save(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	Shift(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"strings"
+	"time"
+)
+
+// localLayout es el formato de airs_at en las peticiones: la hora local de
+// la emisión, sin zona, que se indica aparte en time_zone.
+const localLayout = "2006-01-02T15:04"
+
+type airingRequest struct {
+	AirsAt   string `json:"airs_at" validate:"required"`
+	TimeZone string `json:"time_zone" validate:"required"`
+}
+
+type shiftRequest struct {
+	// Days es el número de días que se mueven las emisiones; negativo las
+	// adelanta.
+	Days int `json:"days" validate:"required,min=-365,max=365"`
+}
+
+type airingResponse struct {
+	EpisodeID uint64    `json:"episode_id"`
+	AirsAt    time.Time `json:"airs_at"`
+	TimeZone  string    `json:"time_zone"`
+}
+
+type shiftResponse struct {
+	SeasonID uint64 `json:"season_id"`
+	Days     int    `json:"days"`
+	// Shifted cuenta los episodios movidos.
+	Shifted int64 `json:"shifted"`
+}
+
+type scheduleSeason struct {
+	ID    uint64 `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+type scheduledEpisode struct {
+	ID     uint64          `json:"id"`
+	Title  string          `json:"title"`
+	Number uint8           `json:"number"`
+	Slug   string          `json:"slug"`
+	Season *scheduleSeason `json:"season"`
+	// AirsAt está en la zona pedida; TimeZone es la de la emisión.
+	AirsAt   time.Time `json:"airs_at"`
+	TimeZone string    `json:"time_zone"`
+}
+
+type dayResponse struct {
+	Date     string              `json:"date"`
+	Weekday  string              `json:"weekday"`
+	Episodes []*scheduledEpisode `json:"episodes"`
+}
+
+type weekResponse struct {
+	Week     string         `json:"week"`
+	TimeZone string         `json:"tz"`
+	Days     []*dayResponse `json:"days"`
+}
+
+func newWeekResponse(week Week, days []*Day) *weekResponse {
+	list := &weekResponse{Week: week.String(), TimeZone: week.Location.String(), Days: make([]*dayResponse, 0, len(days))}
+	for _, day := range days {
+		episodes := make([]*scheduledEpisode, 0, len(day.Airings))
+		for _, airing := range day.Airings {
+			episodes = append(episodes, &scheduledEpisode{
+				ID:       airing.EpisodeID,
+				Title:    airing.EpisodeName,
+				Number:   airing.EpisodeNumber,
+				Slug:     airing.EpisodeSlug,
+				Season:   &scheduleSeason{ID: airing.SeasonID, Title: airing.SeasonName, Slug: airing.SeasonSlug},
+				AirsAt:   airing.AirsAt.In(week.Location),
+				TimeZone: airing.TimeZone,
+			})
+		}
+		list.Days = append(list.Days, &dayResponse{
+			Date:     day.Date.Format("2006-01-02"),
+			Weekday:  strings.ToLower(day.Date.Weekday().String()),
+			Episodes: episodes,
+		})
+	}
+
+	return list
+}
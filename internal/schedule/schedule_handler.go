@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetWeek(ctx *fiber.Ctx) error {
+	loc, err := LoadLocation(ctx.Query("tz", "UTC"))
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid tz")
+	}
+
+	week := CurrentWeek(time.Now(), loc)
+	if value := ctx.Query("week"); value != "" {
+		if week, err = ParseWeek(value, loc); err != nil {
+			return response.NewBadRequestResponse("Invalid week")
+		}
+	}
+
+	days, err := h.service.GetWeek(ctx.UserContext(), week)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get schedule")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newWeekResponse(week, days))
+}
+
+func (h *handler) Save(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request airingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	loc, err := LoadLocation(request.TimeZone)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid time_zone")
+	}
+	airsAt, err := time.ParseInLocation(localLayout, request.AirsAt, loc)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid airs_at")
+	}
+
+	airing := &entities.Airing{EpisodeID: episodeID, AirsAt: airsAt, TimeZone: loc.String()}
+	if err := h.service.Save(ctx.UserContext(), airing); err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to save airing")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(&airingResponse{EpisodeID: episodeID, AirsAt: airsAt, TimeZone: airing.TimeZone})
+}
+
+func (h *handler) Delete(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), episodeID); err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to delete airing")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) Shift(ctx *fiber.Ctx) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request shiftRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	shifted, err := h.service.Shift(ctx.UserContext(), seasonID, request.Days)
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+		return response.NewInternalServerErrorResponse("Failed to shift schedule")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(&shiftResponse{SeasonID: seasonID, Days: request.Days, Shifted: shifted})
+}
@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+// OpenAPI documenta el calendario semanal de emisiones y su gestión.
+func OpenAPI(doc *openapi.Document) {
+	week := doc.Register("ScheduleWeekV2", weekResponse{})
+	airing := doc.Register("AiringV2", airingResponse{})
+	airingInput := doc.Register("AiringRequestV2", airingRequest{})
+	shift := doc.Register("ScheduleShiftV2", shiftResponse{})
+	shiftInput := doc.Register("ScheduleShiftRequestV2", shiftRequest{})
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	mexico := time.FixedZone("CST", -6*60*60)
+	airsAt := time.Date(2026, time.October, 24, 23, 0, 0, 0, tokyo)
+	days := make([]*dayResponse, 0, 7)
+	for i, weekday := range []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"} {
+		days = append(days, &dayResponse{
+			Date:     time.Date(2026, time.October, 19+i, 0, 0, 0, 0, mexico).Format("2006-01-02"),
+			Weekday:  weekday,
+			Episodes: []*scheduledEpisode{},
+		})
+	}
+	days[5].Episodes = append(days[5].Episodes, &scheduledEpisode{
+		ID: 13, Title: "the last day", Number: 13, Slug: "shingeki-no-kyojin-13",
+		Season:   &scheduleSeason{ID: 1, Title: "shingeki no kyojin", Slug: "shingeki-no-kyojin"},
+		AirsAt:   airsAt.In(mexico),
+		TimeZone: "Asia/Tokyo",
+	})
+	example := weekResponse{Week: "2026-W43", TimeZone: "America/Mexico_City", Days: days}
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	for _, version := range []struct {
+		prefix, id string
+		deprecated bool
+	}{
+		{"/api/v1", "getSchedule", true},
+		{"/api/v2", "getScheduleV2", false},
+	} {
+		doc.Add(http.MethodGet, version.prefix+"/schedule", &openapi.Operation{
+			OperationID: version.id,
+			Summary:     "Get the airing schedule of a week",
+			Description: "Returns the seven days of the week, Monday first, with the episodes airing each day. " +
+				"Days and air times are in the requested time zone; each episode also carries the time zone it airs in.",
+			Tags:       []string{"schedule"},
+			Deprecated: version.deprecated,
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParam("week", "ISO week such as 2026-W43 (default the current week)", &openapi.Schema{Type: "string"}),
+				openapi.QueryParam("tz", "IANA time zone such as America/Mexico_City (default UTC)", &openapi.Schema{Type: "string"}),
+			},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON("Schedule", week, example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid week"),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get schedule"),
+			}),
+		})
+	}
+
+	doc.Add(http.MethodPut, "/api/v2/admin/episodes/:id/airing", &openapi.Operation{
+		OperationID: "saveAiringV2",
+		Summary:     "Schedule the airing of an episode",
+		Description: "airs_at is the local date and time of the broadcast (YYYY-MM-DDTHH:MM) in time_zone, an IANA time zone. " +
+			"Replaces the previous schedule of the episode. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(airingInput, airingRequest{AirsAt: "2026-10-24T23:00", TimeZone: "Asia/Tokyo"}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Scheduled airing", airing, airingResponse{EpisodeID: 13, AirsAt: airsAt, TimeZone: "Asia/Tokyo"}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid time_zone"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 13 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save airing"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/admin/episodes/:id/airing", &openapi.Operation{
+		OperationID: "deleteAiringV2",
+		Summary:     "Unschedule the airing of an episode",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "airing of episode 13 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete airing"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/admin/seasons/:id/schedule/shift", &openapi.Operation{
+		OperationID: "shiftScheduleV2",
+		Summary:     "Shift the remaining airings of a season",
+		Description: "Moves every airing of the season that is still to come by the given number of days, keeping its " +
+			"local time; a negative number brings them forward. Requires an administrator token.",
+		Tags:        []string{"admin"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(shiftInput, shiftRequest{Days: 7}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Shifted airings", shift, shiftResponse{SeasonID: 1, Days: 7, Shifted: 4}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Days is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to shift schedule"),
+		}),
+	})
+}
@@ -0,0 +1,251 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"time"
+)
+
+const (
+	querySelectAirings = `SELECT e.id, e.name, e.number, e.slug, s.id, s.name, s.slug, a.airs_at, a.time_zone,
+			a.airs_at + e.duration, a.sequence, a.updated_at
+		FROM episode_airings a
+		JOIN episodes e ON e.id = a.episode_id
+		JOIN seasons s ON s.id = e.season_id`
+	queryGetBetween = querySelectAirings + `
+		WHERE a.airs_at >= $1 AND a.airs_at < $2
+		ORDER BY a.airs_at, s.name, e.number`
+	queryGetBetweenBySeasonID = querySelectAirings + `
+		WHERE e.season_id = $1 AND a.airs_at >= $2 AND a.airs_at < $3
+		ORDER BY a.airs_at, e.number`
+	queryGetBetweenByUserID = querySelectAirings + `
+		JOIN watchlist_entries w ON w.season_id = s.id
+		WHERE w.user_id = $1 AND w.status = ANY($2) AND a.airs_at >= $3 AND a.airs_at < $4
+		ORDER BY a.airs_at, s.name, e.number`
+	queryGetNext = querySelectAirings + `
+		WHERE e.season_id = $1 AND a.airs_at > $2
+		ORDER BY a.airs_at, e.number LIMIT 1`
+	// Guardar la misma fecha no cuenta como revisión.
+	querySave = `INSERT INTO episode_airings (episode_id, airs_at, time_zone) VALUES ($1, $2, $3)
+		ON CONFLICT (episode_id) DO UPDATE
+		SET airs_at = EXCLUDED.airs_at, time_zone = EXCLUDED.time_zone,
+			sequence = episode_airings.sequence + 1, updated_at = now()
+		WHERE (episode_airings.airs_at, episode_airings.time_zone) IS DISTINCT FROM (EXCLUDED.airs_at, EXCLUDED.time_zone)`
+	queryDelete = "DELETE FROM episode_airings WHERE episode_id = $1"
+	// La temporada se bloquea en modo compartido para distinguir una
+	// temporada inexistente de una sin emisiones pendientes.
+	queryLockSeason = "SELECT id FROM seasons WHERE id = $1 FOR KEY SHARE"
+	// Las emisiones quedan bloqueadas mientras se calculan sus nuevas fechas,
+	// que dependen de la hora local y se calculan con shiftLocal.
+	queryGetShiftable = `SELECT a.episode_id, a.airs_at, a.time_zone
+		FROM episode_airings a
+		JOIN episodes e ON e.id = a.episode_id
+		WHERE e.season_id = $1 AND a.airs_at > $2
+		FOR UPDATE OF a`
+	queryShift = `UPDATE episode_airings SET airs_at = $2, sequence = sequence + 1, updated_at = now()
+		WHERE episode_id = $1`
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetBetween(ctx context.Context, from, to time.Time) ([]*entities.Airing, error) {
+	return r.getAirings(ctx, "queryGetBetween", queryGetBetween, from, to)
+}
+
+func (r *repository) GetBetweenBySeasonID(ctx context.Context, seasonID uint64, from, to time.Time) ([]*entities.Airing, error) {
+	return r.getAirings(ctx, "queryGetBetweenBySeasonID", queryGetBetweenBySeasonID, seasonID, from, to)
+}
+
+func (r *repository) GetBetweenByUserID(ctx context.Context, userID uint64, statuses []string, from, to time.Time) ([]*entities.Airing, error) {
+	return r.getAirings(ctx, "queryGetBetweenByUserID", queryGetBetweenByUserID, userID, pq.Array(statuses), from, to)
+}
+
+func (r *repository) getAirings(ctx context.Context, name, query string, args ...any) ([]*entities.Airing, error) {
+	ctx, span := telemetry.StartQuery(ctx, name, query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query, args...)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	airings := []*entities.Airing{}
+	for rows.Next() {
+		airing := &entities.Airing{}
+		if err := scanAiring(rows, airing); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		airings = append(airings, airing)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return airings, nil
+}
+
+func (r *repository) GetNext(ctx context.Context, seasonID uint64, after time.Time) (*entities.Airing, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetNext", queryGetNext)
+	defer span.End()
+
+	airing := &entities.Airing{}
+	if err := scanAiring(r.replica.QueryRowContext(ctx, queryGetNext, seasonID, after), airing); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return airing, nil
+}
+
+func (r *repository) Save(ctx context.Context, airing *entities.Airing) error {
+	ctx, span := telemetry.StartQuery(ctx, "querySave", querySave)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, querySave, airing.EpisodeID, airing.AirsAt, airing.TimeZone); err != nil {
+		telemetry.RecordError(span, err)
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23503" { // el episodio no existe
+			return fmt.Errorf("episode with id %d %w", airing.EpisodeID, ex.ErrNotFound)
+		}
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, episodeID uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDelete", queryDelete)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDelete, episodeID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("airing of episode %d %w", episodeID, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) Shift(ctx context.Context, seasonID uint64, days int, after time.Time) (int64, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryShift", queryShift)
+	defer span.End()
+
+	shifted, err := r.shift(ctx, seasonID, days, after)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return 0, err
+	}
+
+	return shifted, nil
+}
+
+func (r *repository) shift(ctx context.Context, seasonID uint64, days int, after time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var id uint64
+	if err := tx.QueryRowContext(ctx, queryLockSeason, seasonID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("season with id %d %w", seasonID, ex.ErrNotFound)
+		}
+		return 0, err
+	}
+
+	airings, err := getShiftable(ctx, tx, seasonID, after)
+	if err != nil {
+		return 0, err
+	}
+	for _, airing := range airings {
+		airsAt, err := shiftLocal(airing.AirsAt, airing.TimeZone, days)
+		if err != nil {
+			return 0, fmt.Errorf("airing of episode %d: %w", airing.EpisodeID, err)
+		}
+		if _, err := tx.ExecContext(ctx, queryShift, airing.EpisodeID, airsAt); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(airings)), tx.Commit()
+}
+
+func getShiftable(ctx context.Context, tx *sql.Tx, seasonID uint64, after time.Time) ([]*entities.Airing, error) {
+	rows, err := tx.QueryContext(ctx, queryGetShiftable, seasonID, after)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		if err := rows.Close(); err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	airings := []*entities.Airing{}
+	for rows.Next() {
+		airing := &entities.Airing{}
+		if err := rows.Scan(&airing.EpisodeID, &airing.AirsAt, &airing.TimeZone); err != nil {
+			return nil, err
+		}
+		airings = append(airings, airing)
+	}
+
+	return airings, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAiring lee una emisión y pasa AirsAt y EndsAt a la zona horaria de la
+// emisión.
+func scanAiring(row scanner, airing *entities.Airing) error {
+	if err := row.Scan(&airing.EpisodeID, &airing.EpisodeName, &airing.EpisodeNumber, &airing.EpisodeSlug,
+		&airing.SeasonID, &airing.SeasonName, &airing.SeasonSlug, &airing.AirsAt, &airing.TimeZone,
+		&airing.EndsAt, &airing.Sequence, &airing.UpdatedAt); err != nil {
+		return err
+	}
+
+	if loc, err := LoadLocation(airing.TimeZone); err == nil {
+		airing.AirsAt = airing.AirsAt.In(loc)
+		airing.EndsAt = airing.EndsAt.In(loc)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.opentelemetry.io/otel"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/schedule")
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) GetWeek(ctx context.Context, week Week) ([]*Day, error) {
+	ctx, span := tracer.Start(ctx, "schedule.Service.GetWeek")
+	defer span.End()
+
+	airings, err := s.repository.GetBetween(ctx, week.Start(), week.End())
+	if err != nil {
+		return nil, err
+	}
+
+	// Los días se calculan en la zona de la semana, por lo que pueden durar
+	// 23 o 25 horas; las emisiones llegan ordenadas.
+	days := make([]*Day, 0, 7)
+	for i := 0; i < 7; i++ {
+		start := week.Start().AddDate(0, 0, i)
+		end := start.AddDate(0, 0, 1)
+		day := &Day{Date: start, Airings: []*entities.Airing{}}
+		for len(airings) > 0 && airings[0].AirsAt.Before(end) {
+			day.Airings = append(day.Airings, airings[0])
+			airings = airings[1:]
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func (s *service) Save(ctx context.Context, airing *entities.Airing) error {
+	ctx, span := tracer.Start(ctx, "schedule.Service.Save")
+	defer span.End()
+
+	return s.repository.Save(ctx, airing)
+}
+
+func (s *service) Delete(ctx context.Context, episodeID uint64) error {
+	ctx, span := tracer.Start(ctx, "schedule.Service.Delete")
+	defer span.End()
+
+	return s.repository.Delete(ctx, episodeID)
+}
+
+func (s *service) Shift(ctx context.Context, seasonID uint64, days int) (int64, error) {
+	ctx, span := tracer.Start(ctx, "schedule.Service.Shift")
+	defer span.End()
+
+	return s.repository.Shift(ctx, seasonID, days, time.Now())
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"testing"
+	"time"
+)
+
+type fakeRepository struct {
+	Repository
+	airings  []*entities.Airing
+	from, to time.Time
+}
+
+func (r *fakeRepository) GetBetween(_ context.Context, from, to time.Time) ([]*entities.Airing, error) {
+	r.from, r.to = from, to
+	return r.airings, nil
+}
+
+func TestGetWeekBucketsByLocalDay(t *testing.T) {
+	madrid, err := LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(value string) *entities.Airing {
+		airsAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &entities.Airing{AirsAt: airsAt}
+	}
+
+	// El domingo 25 de octubre de 2026 dura 25 horas en Madrid.
+	airings := []*entities.Airing{
+		at("2026-10-18T22:00:00Z"), // lunes 00:00 en Madrid
+		at("2026-10-19T21:59:59Z"), // lunes 23:59:59
+		at("2026-10-19T22:00:00Z"), // martes 00:00
+		at("2026-10-24T22:00:00Z"), // domingo 00:00, todavía en verano
+		at("2026-10-25T22:59:59Z"), // domingo 23:59:59, ya en invierno
+	}
+	repository := &fakeRepository{airings: airings}
+	week := Week{Year: 2026, Number: 43, Location: madrid}
+
+	days, err := NewService(repository).GetWeek(context.Background(), week)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !repository.from.Equal(week.Start()) || !repository.to.Equal(week.End()) {
+		t.Errorf("got range %s - %s, want %s - %s", repository.from, repository.to, week.Start(), week.End())
+	}
+
+	want := []struct {
+		date    string
+		airings int
+	}{
+		{"2026-10-19", 2},
+		{"2026-10-20", 1},
+		{"2026-10-21", 0},
+		{"2026-10-22", 0},
+		{"2026-10-23", 0},
+		{"2026-10-24", 0},
+		{"2026-10-25", 2},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("got %d days, want %d", len(days), len(want))
+	}
+	for i, day := range days {
+		if got := day.Date.Format("2006-01-02"); got != want[i].date {
+			t.Errorf("day %d: got date %s, want %s", i, got, want[i].date)
+		}
+		if len(day.Airings) != want[i].airings {
+			t.Errorf("day %s: got %d airings, want %d", want[i].date, len(day.Airings), want[i].airings)
+		}
+	}
+}
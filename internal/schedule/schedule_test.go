@@ -0,0 +1,147 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftLocal(t *testing.T) {
+	tests := []struct {
+		name     string
+		airsAt   string
+		timeZone string
+		days     int
+		want     string
+	}{
+		{"same offset", "2026-10-10T23:00:00+09:00", "Asia/Tokyo", 7, "2026-10-17T23:00:00+09:00"},
+		{"across the end of summer time", "2026-10-20T20:00:00+02:00", "Europe/Madrid", 7, "2026-10-27T20:00:00+01:00"},
+		{"across the start of summer time", "2026-03-05T21:30:00-05:00", "America/New_York", 7, "2026-03-12T21:30:00-04:00"},
+		{"backwards across the change", "2026-03-12T21:30:00-04:00", "America/New_York", -7, "2026-03-05T21:30:00-05:00"},
+		{"into the skipped hour", "2026-03-01T02:30:00-05:00", "America/New_York", 7, "2026-03-08T03:30:00-04:00"},
+		{"into a skipped midnight", "2026-08-30T00:30:00-04:00", "America/Santiago", 7, "2026-09-06T01:30:00-03:00"},
+		{"stored in UTC", "2026-10-20T18:00:00Z", "Europe/Madrid", 7, "2026-10-27T20:00:00+01:00"},
+		{"across the year", "2026-12-28T00:30:00+09:00", "Asia/Tokyo", 7, "2027-01-04T00:30:00+09:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			airsAt, err := time.Parse(time.RFC3339, tt.airsAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := shiftLocal(airsAt, tt.timeZone, tt.days)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Format(time.RFC3339) != tt.want {
+				t.Errorf("got %s, want %s", got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftLocalUnknownTimeZone(t *testing.T) {
+	if _, err := shiftLocal(time.Now(), "Mars/Olympus", 1); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestParseWeek(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantStart string
+		wantErr   string
+	}{
+		{"2026-W43", "2026-10-19", ""},
+		{"2026-W01", "2025-12-29", ""},
+		{"2026-W53", "2026-12-28", ""},
+		{"2027-W01", "2027-01-04", ""},
+		{"2020-W53", "2020-12-28", ""},
+		{"2025-W53", "", "week 2025-W53 does not exist"},
+		{"2026-W00", "", "week 2026-W00 does not exist"},
+		{"2026-W54", "", "week 2026-W54 does not exist"},
+		{"2026-43", "", "week must have the format YYYY-Www"},
+		{"2026-W4", "", "week must have the format YYYY-Www"},
+		{"", "", "week must have the format YYYY-Www"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			week, err := ParseWeek(tt.value, time.UTC)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := week.Start().Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("got start %s, want %s", got, tt.wantStart)
+			}
+			if week.String() != tt.value {
+				t.Errorf("got %s, want %s", week, tt.value)
+			}
+		})
+	}
+}
+
+func TestWeekBounds(t *testing.T) {
+	madrid, err := LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// La semana 43 de 2026 termina el domingo en que acaba el horario de
+	// verano, así que dura una hora más.
+	week := Week{Year: 2026, Number: 43, Location: madrid}
+	if got := week.Start().Format(time.RFC3339); got != "2026-10-19T00:00:00+02:00" {
+		t.Errorf("got start %s", got)
+	}
+	if got := week.End().Format(time.RFC3339); got != "2026-10-26T00:00:00+01:00" {
+		t.Errorf("got end %s", got)
+	}
+	if got := week.End().Sub(week.Start()); got != 7*24*time.Hour+time.Hour {
+		t.Errorf("got length %s", got)
+	}
+}
+
+func TestCurrentWeek(t *testing.T) {
+	tokyo, err := LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Domingo por la noche en UTC es ya lunes en Tokio.
+	now := time.Date(2026, time.October, 25, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		loc  *time.Location
+		want string
+	}{
+		{time.UTC, "2026-W43"},
+		{tokyo, "2026-W44"},
+	}
+	for _, tt := range tests {
+		if got := CurrentWeek(now, tt.loc).String(); got != tt.want {
+			t.Errorf("CurrentWeek in %s = %s, want %s", tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Asia/Tokyo", false},
+		{"UTC", false},
+		{"", true},
+		{"Local", true},
+		{"Mars/Olympus", true},
+	}
+	for _, tt := range tests {
+		if _, err := LoadLocation(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("LoadLocation(%q) error = %v, want error %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
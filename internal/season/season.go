@@ -1,24 +1,32 @@
 package season
 
 import (
+	"context"
 	"github.com/gofiber/fiber/v2"
 	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/taxonomy"
 )
 
 type Repository interface {
-	GetAll() ([]*entities.Season, error)
-	GetById(id uint64) (*entities.Season, error)
-	Create(season *entities.Season) error
-	Update(season *entities.Season) error
-	Delete(id uint64) error
+	GetAll(ctx context.Context) ([]*entities.Season, error)
+	GetById(ctx context.Context, id uint64) (*entities.Season, error)
+	GetByIds(ctx context.Context, ids []uint64) ([]*entities.Season, error)
+	Create(ctx context.Context, season *entities.Season) error
+	Update(ctx context.Context, season *entities.Season) error
+	Delete(ctx context.Context, id uint64) error
 }
 
 type Service interface {
-	GetAll() ([]*entities.Season, error)
-	GetById(id uint64) (*entities.Season, error)
-	Create(season *entities.Season) error
-	Update(id uint64, season *entities.Season) error
-	Delete(id uint64) error
+	GetAll(ctx context.Context) ([]*entities.Season, error)
+	// Search devuelve las temporadas que cumplen el filtro, con sus géneros
+	// y etiquetas, y cuántas de ellas tienen cada género.
+	Search(ctx context.Context, filter taxonomy.Filter) ([]*entities.Season, []*entities.Facet, error)
+	GetById(ctx context.Context, id uint64) (*entities.Season, error)
+	// GetByIds devuelve las temporadas existentes entre ids, sin orden fijo.
+	GetByIds(ctx context.Context, ids []uint64) ([]*entities.Season, error)
+	Create(ctx context.Context, season *entities.Season) error
+	Update(ctx context.Context, id uint64, season *entities.Season) error
+	Delete(ctx context.Context, id uint64) error
 }
 
 type Handler interface {
@@ -28,3 +36,8 @@ type Handler interface {
 	Update(ctx *fiber.Ctx) error
 	Delete(ctx *fiber.Ctx) error
 }
+
+// HandlerV2 sirve las mismas operaciones con las representaciones de la API v2.
+type HandlerV2 interface {
+	Handler
+}
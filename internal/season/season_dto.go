@@ -0,0 +1,147 @@
+package season
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+// Representaciones de la API v2, independientes de las columnas de la tabla.
+
+type seasonRequest struct {
+	Title    string `json:"title" validate:"required,min=3"`
+	Number   uint8  `json:"number" validate:"required,min=1"`
+	ImageUrl string `json:"image_url" validate:"required,min=6"`
+}
+
+func (r *seasonRequest) toEntity() *entities.Season {
+	return &entities.Season{
+		Name:     r.Title,
+		Number:   r.Number,
+		ImageUrl: r.ImageUrl,
+	}
+}
+
+type seasonImage struct {
+	Url string `json:"url"`
+}
+
+type seasonTerm struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type seasonResponse struct {
+	ID     uint64      `json:"id"`
+	Slug   string      `json:"slug"`
+	Title  string      `json:"title"`
+	Number uint8       `json:"number"`
+	Image  seasonImage `json:"image"`
+	// Rating sólo se incluye en el detalle.
+	Rating *entities.RatingSummary `json:"rating,omitempty"`
+	Genres []*seasonTerm           `json:"genres,omitempty"`
+	Tags   []*seasonTerm           `json:"tags,omitempty"`
+	// Studios sólo se incluye en el detalle.
+	Studios []*seasonStudio `json:"studios,omitempty"`
+	// NextEpisode es la próxima emisión; sólo en el detalle y si la hay.
+	NextEpisode *seasonNextEpisode `json:"next_episode,omitempty"`
+}
+
+func newSeasonResponse(season *entities.Season) *seasonResponse {
+	return &seasonResponse{
+		ID:          season.ID,
+		Slug:        season.Slug,
+		Title:       season.Name,
+		Number:      season.Number,
+		Image:       seasonImage{Url: season.ImageUrl},
+		Rating:      season.Rating,
+		Genres:      newSeasonTerms(season.Genres),
+		Tags:        newSeasonTerms(season.Tags),
+		Studios:     newSeasonStudios(season.Studios),
+		NextEpisode: newSeasonNextEpisode(season.NextAiring),
+	}
+}
+
+type seasonNextEpisode struct {
+	ID       uint64    `json:"id"`
+	Title    string    `json:"title"`
+	Number   uint8     `json:"number"`
+	Slug     string    `json:"slug"`
+	AirsAt   time.Time `json:"airs_at"`
+	TimeZone string    `json:"time_zone"`
+	// AirsInSeconds es la cuenta atrás hasta la emisión en el momento de
+	// la respuesta.
+	AirsInSeconds int64 `json:"airs_in_seconds"`
+}
+
+func newSeasonNextEpisode(airing *entities.Airing) *seasonNextEpisode {
+	if airing == nil {
+		return nil
+	}
+
+	return &seasonNextEpisode{
+		ID:            airing.EpisodeID,
+		Title:         airing.EpisodeName,
+		Number:        airing.EpisodeNumber,
+		Slug:          airing.EpisodeSlug,
+		AirsAt:        airing.AirsAt,
+		TimeZone:      airing.TimeZone,
+		AirsInSeconds: int64(time.Until(airing.AirsAt).Seconds()),
+	}
+}
+
+func newSeasonTerms(terms []*entities.Term) []*seasonTerm {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	list := make([]*seasonTerm, 0, len(terms))
+	for _, term := range terms {
+		list = append(list, &seasonTerm{Slug: term.Slug, Name: term.Name})
+	}
+
+	return list
+}
+
+type seasonStudio struct {
+	ID   uint64 `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+func newSeasonStudios(studios []*entities.SeasonStudio) []*seasonStudio {
+	if len(studios) == 0 {
+		return nil
+	}
+
+	list := make([]*seasonStudio, 0, len(studios))
+	for _, studio := range studios {
+		list = append(list, &seasonStudio{ID: studio.Studio.ID, Slug: studio.Studio.Slug, Name: studio.Studio.Name, Role: studio.Role})
+	}
+
+	return list
+}
+
+// seasonFacets cuenta, entre las temporadas del listado, cuántas tienen
+// cada género.
+type seasonFacets struct {
+	Genres []*entities.Facet `json:"genres"`
+}
+
+type seasonListResponse struct {
+	Data   []*seasonResponse `json:"data"`
+	Facets seasonFacets      `json:"facets"`
+}
+
+func newSeasonListResponse(seasons []*entities.Season, genres []*entities.Facet) *seasonListResponse {
+	if genres == nil {
+		genres = []*entities.Facet{}
+	}
+
+	list := &seasonListResponse{Data: make([]*seasonResponse, 0, len(seasons)), Facets: seasonFacets{Genres: genres}}
+	for _, season := range seasons {
+		list.Data = append(list.Data, newSeasonResponse(season))
+	}
+
+	return list
+}
@@ -24,7 +24,7 @@ func NewHandler(service Service, validator validator.Validator) Handler {
 }
 
 func (h *handler) GetAll(ctx *fiber.Ctx) error {
-	seasons, err := h.service.GetAll()
+	seasons, err := h.service.GetAll(ctx.UserContext())
 	if err != nil {
 		return response.NewInternalServerErrorResponse("Failed to get seasons")
 	}
@@ -39,7 +39,7 @@ func (h *handler) GetById(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse("Invalid id")
 	}
 
-	season, err := h.service.GetById(id)
+	season, err := h.service.GetById(ctx.UserContext(), id)
 	if err != nil {
 
 		if errors.Is(err, ex.ErrNotFound) {
@@ -64,7 +64,7 @@ func (h *handler) Create(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse(m)
 	}
 
-	err := h.service.Create(season)
+	err := h.service.Create(ctx.UserContext(), season)
 	if err != nil {
 		return response.NewInternalServerErrorResponse("Failed to create episode")
 	}
@@ -89,7 +89,7 @@ func (h *handler) Update(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse(m)
 	}
 
-	err = h.service.Update(id, season)
+	err = h.service.Update(ctx.UserContext(), id, season)
 	if err != nil {
 		if errors.Is(err, ex.ErrNotFound) {
 			return response.NewNotFoundResponse(err.Error())
@@ -108,7 +108,7 @@ func (h *handler) Delete(ctx *fiber.Ctx) error {
 		return response.NewBadRequestResponse("Invalid id")
 	}
 
-	err = h.service.Delete(id)
+	err = h.service.Delete(ctx.UserContext(), id)
 	if err != nil {
 
 		if errors.Is(err, ex.ErrNotFound) {
@@ -0,0 +1,142 @@
+package season
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/taxonomy"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type handlerV2 struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandlerV2(service Service, validator validator.Validator) HandlerV2 {
+	return &handlerV2{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAll admite los filtros genre y tag, repetidos o separados por comas,
+// y match=all (por defecto) o match=any para combinarlos.
+func (h *handlerV2) GetAll(ctx *fiber.Ctx) error {
+	matchAll := true
+	switch ctx.Query("match") {
+	case "", "all":
+	case "any":
+		matchAll = false
+	default:
+		return response.NewBadRequestResponse("Invalid match")
+	}
+
+	filter := taxonomy.NewFilter(queryList(ctx, "genre"), queryList(ctx, "tag"), matchAll)
+	seasons, genres, err := h.service.Search(ctx.UserContext(), filter)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get seasons")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSeasonListResponse(seasons, genres))
+}
+
+// queryList reúne los valores de un parámetro que puede repetirse y
+// separar varios valores con comas.
+func queryList(ctx *fiber.Ctx, key string) []string {
+	var values []string
+	for _, value := range ctx.Context().QueryArgs().PeekMulti(key) {
+		values = append(values, strings.Split(string(value), ",")...)
+	}
+
+	return values
+}
+
+func (h *handlerV2) GetById(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	season, err := h.service.GetById(ctx.UserContext(), id)
+	if err != nil {
+
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to get season")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSeasonResponse(season))
+}
+
+func (h *handlerV2) Create(ctx *fiber.Ctx) error {
+	var request seasonRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	season := request.toEntity()
+	err := h.service.Create(ctx.UserContext(), season)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to create season")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newSeasonResponse(season))
+}
+
+func (h *handlerV2) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request seasonRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	season := request.toEntity()
+	err = h.service.Update(ctx.UserContext(), id, season)
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to update season")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSeasonResponse(season))
+}
+
+func (h *handlerV2) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	err = h.service.Delete(ctx.UserContext(), id)
+	if err != nil {
+
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to delete season")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package season
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+var example = entities.Season{
+	ID:       1,
+	Name:     "shingeki no kyojin",
+	Number:   1,
+	Slug:     "shingeki-no-kyojin",
+	ImageUrl: "https://cdn.example.com/seasons/shingeki-no-kyojin.jpg",
+}
+
+// OpenAPI documenta las rutas de /api/v1/seasons, obsoletas, y las de
+// /api/v2/seasons.
+func OpenAPI(doc *openapi.Document) {
+	openAPIV2(doc)
+
+	schema := doc.Register("Season", entities.Season{})
+	input := map[string]any{"name": example.Name, "number": example.Number, "image_url": example.ImageUrl}
+
+	doc.Add(http.MethodGet, "/api/v1/seasons", &openapi.Operation{
+		OperationID: "listSeasons",
+		Summary:     "List seasons",
+		Tags:        []string{"seasons"},
+		Deprecated:  true,
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Seasons", openapi.ArrayOf(schema), []entities.Season{example}),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get seasons"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v1/seasons/:id", &openapi.Operation{
+		OperationID: "getSeason",
+		Summary:     "Get a season by id",
+		Tags:        []string{"seasons"},
+		Deprecated:  true,
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Season", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get season"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v1/seasons", &openapi.Operation{
+		OperationID: "createSeason",
+		Summary:     "Create a season",
+		Description: "The slug is derived from the name.",
+		Tags:        []string{"seasons"},
+		Deprecated:  true,
+		RequestBody: openapi.JSONBody(schema, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created season", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Name is required"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create season"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v1/seasons/:id", &openapi.Operation{
+		OperationID: "updateSeason",
+		Summary:     "Replace a season",
+		Tags:        []string{"seasons"},
+		Deprecated:  true,
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(schema, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Updated season", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid request body"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update season"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v1/seasons/:id", &openapi.Operation{
+		OperationID: "deleteSeason",
+		Summary:     "Delete a season",
+		Tags:        []string{"seasons"},
+		Deprecated:  true,
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete season"),
+		}),
+	})
+}
@@ -0,0 +1,121 @@
+package season
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"github.com/wicho90/anime-api/internal/rating"
+	"net/http"
+	"time"
+)
+
+// openAPIV2 documenta las rutas de /api/v2/seasons.
+func openAPIV2(doc *openapi.Document) {
+	schema := doc.Register("SeasonV2", seasonResponse{})
+	list := doc.Register("SeasonListV2", seasonListResponse{})
+	request := doc.Register("SeasonRequestV2", seasonRequest{})
+
+	v2 := newSeasonResponse(&example)
+	listed := *v2
+	listed.Genres = []*seasonTerm{{Slug: "action", Name: "Action"}, {Slug: "drama", Name: "Drama"}}
+	listed.Tags = []*seasonTerm{{Slug: "military", Name: "Military"}}
+	detail := listed
+	detail.Rating = rating.ExampleSummary
+	detail.Studios = []*seasonStudio{{ID: 1, Slug: "wit-studio", Name: "Wit Studio", Role: entities.StudioAnimation}}
+	detail.NextEpisode = &seasonNextEpisode{
+		ID: 13, Title: "the last day", Number: 13, Slug: "shingeki-no-kyojin-13",
+		AirsAt: time.Date(2026, time.October, 24, 23, 0, 0, 0, time.FixedZone("JST", 9*60*60)), TimeZone: "Asia/Tokyo",
+		AirsInSeconds: 432000,
+	}
+	input := seasonRequest{Title: example.Name, Number: example.Number, ImageUrl: example.ImageUrl}
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	doc.Add(http.MethodGet, "/api/v2/seasons", &openapi.Operation{
+		OperationID: "listSeasonsV2",
+		Summary:     "List seasons",
+		Description: "genre and tag take genre and tag slugs, repeated or separated by commas. With match=all a season " +
+			"must have every listed genre and tag; with match=any one of them is enough. facets.genres counts how many of " +
+			"the listed seasons have each genre, most common first.",
+		Tags: []string{"seasons"},
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParam("genre", "Genre slugs", &openapi.Schema{Type: "string"}),
+			openapi.QueryParam("tag", "Tag slugs", &openapi.Schema{Type: "string"}),
+			openapi.QueryParam("match", "How to combine the filters (default all)", &openapi.Schema{
+				Type: "string", Enum: []any{"all", "any"},
+			}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Seasons", list, seasonListResponse{
+				Data: []*seasonResponse{&listed},
+				Facets: seasonFacets{Genres: []*entities.Facet{
+					{Slug: "action", Name: "Action", Count: 1}, {Slug: "drama", Name: "Drama", Count: 1},
+				}},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid match"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get seasons"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/seasons/:id", &openapi.Operation{
+		OperationID: "getSeasonV2",
+		Summary:     "Get a season by id",
+		Description: "Includes the aggregated rating, the genres, the tags and the studios of the season. " +
+			"next_episode is the next scheduled airing, with airs_in_seconds counting down to it; it is omitted when nothing is scheduled.",
+		Tags:       []string{"seasons"},
+		Parameters: []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Season", schema, detail),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get season"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/seasons", &openapi.Operation{
+		OperationID: "createSeasonV2",
+		Summary:     "Create a season",
+		Description: "The slug is derived from the title. Requires an administrator token.",
+		Tags:        []string{"seasons"},
+		Security:    openapi.Authenticated(),
+		RequestBody: openapi.JSONBody(request, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created season", schema, v2),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Title is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create season"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/seasons/:id", &openapi.Operation{
+		OperationID: "updateSeasonV2",
+		Summary:     "Replace a season",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"seasons"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Updated season", schema, v2),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid request body"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update season"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/seasons/:id", &openapi.Operation{
+		OperationID: "deleteSeasonV2",
+		Summary:     "Delete a season",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"seasons"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete season"),
+		}),
+	})
+}
@@ -1,36 +1,46 @@
 package season
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
 	"github.com/wicho90/anime-api/internal/entities"
 	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
 	"log"
 )
 
 type repository struct {
-	db *sql.DB
+	db      *sql.DB
+	replica *database.Replica
 }
 
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
 	return &repository{
-		db: db,
+		db:      db,
+		replica: replica,
 	}
 }
 
 const (
-	queryGetAll  = "SELECT id, name, number, slug, image_url FROM seasons"
-	queryGetById = "SELECT id, name, number, slug, image_url FROM seasons WHERE id = $1"
-	queryCreate  = "INSERT INTO seasons (name, number, slug, image_url) VALUES ($1, $2, $3, $4) RETURNING id"
-	queryUpdate  = "UPDATE seasons SET name = $1, number = $2, slug = $3, image_url = $4 WHERE id = $5"
+	queryGetAll   = "SELECT id, name, number, slug, image_url FROM seasons"
+	queryGetById  = "SELECT id, name, number, slug, image_url FROM seasons WHERE id = $1"
+	queryGetByIds = "SELECT id, name, number, slug, image_url FROM seasons WHERE id = ANY($1)"
+	queryCreate   = "INSERT INTO seasons (name, number, slug, image_url) VALUES ($1, $2, $3, $4) RETURNING id"
+	queryUpdate   = "UPDATE seasons SET name = $1, number = $2, slug = $3, image_url = $4 WHERE id = $5"
 
 	queryDeleteById = "DELETE FROM seasons WHERE id = $1"
 )
 
-func (r *repository) GetAll() ([]*entities.Season, error) {
-	rows, err := r.db.Query(queryGetAll)
+func (r *repository) GetAll(ctx context.Context) ([]*entities.Season, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetAll", queryGetAll)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetAll)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
@@ -45,39 +55,84 @@ func (r *repository) GetAll() ([]*entities.Season, error) {
 	for rows.Next() {
 		season := &entities.Season{}
 		if err := rows.Scan(&season.ID, &season.Name, &season.Number, &season.Slug, &season.ImageUrl); err != nil {
+			telemetry.RecordError(span, err)
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		seasons = append(seasons, season)
 	}
 
 	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
 
 	return seasons, nil
 }
 
-func (r *repository) GetById(id uint64) (*entities.Season, error) {
+func (r *repository) GetById(ctx context.Context, id uint64) (*entities.Season, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetById", queryGetById)
+	defer span.End()
+
 	season := &entities.Season{}
 
-	err := r.db.QueryRow(queryGetById, id).
+	err := r.db.QueryRowContext(ctx, queryGetById, id).
 		Scan(&season.ID, &season.Name, &season.Number, &season.Slug, &season.ImageUrl)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("season with id %d %v", id, ex.ErrNotFound)
 			return nil, fmt.Errorf("season with id %d %w", id, ex.ErrNotFound)
 		}
+		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	return season, nil
 }
 
-func (r *repository) Create(season *entities.Season) error {
-	err := r.db.QueryRow(queryCreate, season.Name,
+func (r *repository) GetByIds(ctx context.Context, ids []uint64) ([]*entities.Season, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByIds", queryGetByIds)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetByIds, pq.Array(ids))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	var seasons []*entities.Season
+	for rows.Next() {
+		season := &entities.Season{}
+		if err := rows.Scan(&season.ID, &season.Name, &season.Number, &season.Slug, &season.ImageUrl); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return seasons, nil
+}
+
+func (r *repository) Create(ctx context.Context, season *entities.Season) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", queryCreate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreate, season.Name,
 		season.Number, season.Slug, season.ImageUrl).Scan(&season.ID)
 
 	if err != nil {
+		telemetry.RecordError(span, err)
 
 		if pgErr, ok := err.(*pq.Error); ok {
 			switch pgErr.Code {
@@ -103,10 +158,14 @@ func (r *repository) Create(season *entities.Season) error {
 	return nil
 }
 
-func (r *repository) Update(season *entities.Season) error {
-	result, err := r.db.Exec(queryUpdate, season.Name, season.Number, season.Slug, season.ImageUrl, season.ID)
+func (r *repository) Update(ctx context.Context, season *entities.Season) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdate", queryUpdate)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryUpdate, season.Name, season.Number, season.Slug, season.ImageUrl, season.ID)
 
 	if err != nil {
+		telemetry.RecordError(span, err)
 
 		if pgErr, ok := err.(*pq.Error); ok {
 
@@ -141,9 +200,13 @@ func (r *repository) Update(season *entities.Season) error {
 	return nil
 }
 
-func (r *repository) Delete(id uint64) error {
-	result, err := r.db.Exec(queryDeleteById, id)
+func (r *repository) Delete(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteById", queryDeleteById)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteById, id)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return err
 	}
 
@@ -1,23 +1,45 @@
 package season
 
 import (
+	"context"
 	"fmt"
+	"github.com/wicho90/anime-api/internal/credit"
 	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/rating"
+	"github.com/wicho90/anime-api/internal/schedule"
+	"github.com/wicho90/anime-api/internal/taxonomy"
+	"go.opentelemetry.io/otel"
+	"sort"
 	"strings"
+	"time"
 )
 
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/season")
+
 type service struct {
-	repository Repository
+	repository         Repository
+	ratingRepository   rating.Repository
+	taxonomyRepository taxonomy.Repository
+	creditRepository   credit.Repository
+	scheduleRepository schedule.Repository
 }
 
-func NewService(repository Repository) Service {
+func NewService(repository Repository, ratingRepository rating.Repository, taxonomyRepository taxonomy.Repository,
+	creditRepository credit.Repository, scheduleRepository schedule.Repository) Service {
 	return &service{
-		repository: repository,
+		repository:         repository,
+		ratingRepository:   ratingRepository,
+		taxonomyRepository: taxonomyRepository,
+		creditRepository:   creditRepository,
+		scheduleRepository: scheduleRepository,
 	}
 }
 
-func (s *service) GetAll() ([]*entities.Season, error) {
-	seasons, err := s.repository.GetAll()
+func (s *service) GetAll(ctx context.Context) ([]*entities.Season, error) {
+	ctx, span := tracer.Start(ctx, "season.Service.GetAll")
+	defer span.End()
+
+	seasons, err := s.repository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -25,20 +47,108 @@ func (s *service) GetAll() ([]*entities.Season, error) {
 	return seasons, nil
 }
 
-func (s *service) GetById(id uint64) (*entities.Season, error) {
-	season, err := s.repository.GetById(id)
+func (s *service) Search(ctx context.Context, filter taxonomy.Filter) ([]*entities.Season, []*entities.Facet, error) {
+	ctx, span := tracer.Start(ctx, "season.Service.Search")
+	defer span.End()
+
+	var seasons []*entities.Season
+	var err error
+	if filter.Empty() {
+		seasons, err = s.repository.GetAll(ctx)
+	} else {
+		var ids []uint64
+		ids, err = s.taxonomyRepository.FindSeasonIDs(ctx, filter)
+		if err == nil && len(ids) > 0 {
+			seasons, err = s.repository.GetByIds(ctx, ids)
+			sort.Slice(seasons, func(i, j int) bool { return seasons[i].ID < seasons[j].ID })
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]uint64, 0, len(seasons))
+	for _, season := range seasons {
+		ids = append(ids, season.ID)
+	}
+	if err := s.attachTerms(ctx, seasons, ids); err != nil {
+		return nil, nil, err
+	}
+
+	facets, err := s.taxonomyRepository.GetFacets(ctx, entities.TermGenre, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return seasons, facets, nil
+}
+
+func (s *service) GetById(ctx context.Context, id uint64) (*entities.Season, error) {
+	ctx, span := tracer.Start(ctx, "season.Service.GetById")
+	defer span.End()
+
+	season, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	season.Rating, err = s.ratingRepository.GetSummary(ctx, entities.RatingOwnerSeason, season.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTerms(ctx, []*entities.Season{season}, []uint64{season.ID}); err != nil {
+		return nil, err
+	}
+
+	studios, err := s.creditRepository.GetStudiosBySeasonIDs(ctx, []uint64{season.ID})
+	if err != nil {
+		return nil, err
+	}
+	season.Studios = studios[season.ID]
+
+	season.NextAiring, err = s.scheduleRepository.GetNext(ctx, season.ID, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	return season, err
+	return season, nil
+}
+
+// attachTerms añade a cada temporada sus géneros y etiquetas.
+func (s *service) attachTerms(ctx context.Context, seasons []*entities.Season, ids []uint64) error {
+	genres, err := s.taxonomyRepository.GetBySeasonIDs(ctx, entities.TermGenre, ids)
+	if err != nil {
+		return err
+	}
+	tags, err := s.taxonomyRepository.GetBySeasonIDs(ctx, entities.TermTag, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, season := range seasons {
+		season.Genres = genres[season.ID]
+		season.Tags = tags[season.ID]
+	}
+
+	return nil
+}
+
+func (s *service) GetByIds(ctx context.Context, ids []uint64) ([]*entities.Season, error) {
+	ctx, span := tracer.Start(ctx, "season.Service.GetByIds")
+	defer span.End()
+
+	return s.repository.GetByIds(ctx, ids)
 }
 
-func (s *service) Create(season *entities.Season) error {
+func (s *service) Create(ctx context.Context, season *entities.Season) error {
+	ctx, span := tracer.Start(ctx, "season.Service.Create")
+	defer span.End()
+
 	season.Name = strings.TrimSpace(strings.ToLower(season.Name))
 	season.Slug = strings.ReplaceAll(season.Name, " ", "-")
 
-	err := s.repository.Create(season)
+	err := s.repository.Create(ctx, season)
 	if err != nil {
 		return fmt.Errorf("failed to create season: %w", err)
 	}
@@ -46,8 +156,11 @@ func (s *service) Create(season *entities.Season) error {
 	return err
 }
 
-func (s *service) Update(id uint64, season *entities.Season) error {
-	_, err := s.repository.GetById(id)
+func (s *service) Update(ctx context.Context, id uint64, season *entities.Season) error {
+	ctx, span := tracer.Start(ctx, "season.Service.Update")
+	defer span.End()
+
+	_, err := s.repository.GetById(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -56,7 +169,7 @@ func (s *service) Update(id uint64, season *entities.Season) error {
 	season.Name = strings.TrimSpace(strings.ToLower(season.Name))
 	season.Slug = strings.ReplaceAll(season.Name, " ", "-")
 
-	err = s.repository.Update(season)
+	err = s.repository.Update(ctx, season)
 	if err != nil {
 		return err
 	}
@@ -64,8 +177,11 @@ func (s *service) Update(id uint64, season *entities.Season) error {
 	return nil
 }
 
-func (s *service) Delete(id uint64) error {
-	if err := s.repository.Delete(id); err != nil {
+func (s *service) Delete(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "season.Service.Delete")
+	defer span.End()
+
+	if err := s.repository.Delete(ctx, id); err != nil {
 		return err
 	}
 
@@ -0,0 +1,67 @@
+package security
+
+import (
+	"github.com/wicho90/anime-api/config"
+	"time"
+)
+
+const (
+	// apiCSP se envía con las respuestas JSON, que nunca deben ejecutar nada.
+	apiCSP = "default-src 'none'; frame-ancestors 'none'"
+
+	strictCSP = "default-src 'self'; base-uri 'self'; object-src 'none'; frame-ancestors 'none'; " +
+		"form-action 'self'; img-src 'self' data: https:; style-src 'self'; script-src 'self'"
+	devCSP = "default-src 'self'; base-uri 'self'; object-src 'none'; img-src 'self' data: http: https:; " +
+		"style-src 'self' 'unsafe-inline'; script-src 'self' 'unsafe-inline'; connect-src 'self' http: ws:"
+)
+
+// Policy son las cabeceras de seguridad ya resueltas para un entorno.
+type Policy struct {
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	ContentSecurityPolicy string
+	// CSPReportOnly envía la CSP sin aplicarla, para no romper el desarrollo.
+	CSPReportOnly  bool
+	ReferrerPolicy string
+}
+
+var presets = map[string]Policy{
+	"development": {
+		ContentSecurityPolicy: devCSP,
+		CSPReportOnly:         true,
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	},
+	"staging": {
+		HSTSMaxAge:            24 * time.Hour,
+		ContentSecurityPolicy: strictCSP,
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	},
+	"production": {
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: strictCSP,
+		ReferrerPolicy:        "same-origin",
+	},
+}
+
+// NewPolicy parte del preset configurado (o del entorno) y aplica encima los
+// valores explícitos de config.Server.Security.
+func NewPolicy(config *config.Config) Policy {
+	preset := config.Server.Security.Preset
+	if preset == "" {
+		preset = config.Environment
+	}
+	policy := presets[preset]
+
+	if config.Server.Security.HSTSMaxAge > 0 {
+		policy.HSTSMaxAge = config.Server.Security.HSTSMaxAge
+	}
+	if config.Server.Security.ContentSecurityPolicy != "" {
+		policy.ContentSecurityPolicy = config.Server.Security.ContentSecurityPolicy
+	}
+	if config.Server.Security.ReferrerPolicy != "" {
+		policy.ReferrerPolicy = config.Server.Security.ReferrerPolicy
+	}
+
+	return policy
+}
@@ -0,0 +1,24 @@
+package security
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/wicho90/anime-api/config"
+	"strings"
+)
+
+// CORS construye el middleware a partir de config.Server.CORS. Los orígenes
+// con comodín ("https://*.example.com") aceptan cualquier subdominio de ese
+// dominio con el mismo esquema, pero no el propio dominio.
+func CORS(config *config.Config) fiber.Handler {
+	c := config.Server.CORS
+
+	return cors.New(cors.Config{
+		AllowOrigins:     strings.Join(c.AllowOrigins, ","),
+		AllowMethods:     strings.Join(c.AllowMethods, ","),
+		AllowHeaders:     strings.Join(c.AllowHeaders, ","),
+		ExposeHeaders:    strings.Join(c.ExposeHeaders, ","),
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           int(c.MaxAge.Seconds()),
+	})
+}
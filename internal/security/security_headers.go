@@ -0,0 +1,42 @@
+package security
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+// Headers añade las cabeceras de seguridad a todas las respuestas. La CSP de
+// la política se aplica a los archivos estáticos; bajo /api y en /graphql se
+// usa una CSP que no permite cargar nada.
+func Headers(policy Policy) fiber.Handler {
+	hsts := fmt.Sprintf("max-age=%d", int(policy.HSTSMaxAge.Seconds()))
+	if policy.HSTSIncludeSubdomains {
+		hsts += "; includeSubDomains"
+	}
+
+	cspHeader := fiber.HeaderContentSecurityPolicy
+	if policy.CSPReportOnly {
+		cspHeader = fiber.HeaderContentSecurityPolicyReportOnly
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		ctx.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		ctx.Set(fiber.HeaderXFrameOptions, "DENY")
+		ctx.Set(fiber.HeaderReferrerPolicy, policy.ReferrerPolicy)
+		ctx.Set("Cross-Origin-Opener-Policy", "same-origin")
+
+		// HSTS sólo tiene efecto (y sólo es correcto) sobre HTTPS.
+		if policy.HSTSMaxAge > 0 && ctx.Protocol() == "https" {
+			ctx.Set(fiber.HeaderStrictTransportSecurity, hsts)
+		}
+
+		if path := ctx.Path(); strings.HasPrefix(path, "/api/") || path == "/graphql" {
+			ctx.Set(fiber.HeaderContentSecurityPolicy, apiCSP)
+		} else if policy.ContentSecurityPolicy != "" {
+			ctx.Set(cspHeader, policy.ContentSecurityPolicy)
+		}
+
+		return ctx.Next()
+	}
+}
@@ -1,15 +1,45 @@
 package server
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/apiversion"
+	"github.com/wicho90/anime-api/internal/artwork"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/calendar"
+	"github.com/wicho90/anime-api/internal/cast"
+	"github.com/wicho90/anime-api/internal/comment"
+	"github.com/wicho90/anime-api/internal/credit"
 	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/feed"
+	"github.com/wicho90/anime-api/internal/graph"
+	"github.com/wicho90/anime-api/internal/health"
+	"github.com/wicho90/anime-api/internal/links"
+	"github.com/wicho90/anime-api/internal/mal"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/rating"
 	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/schedule"
 	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/security"
+	"github.com/wicho90/anime-api/internal/source"
+	"github.com/wicho90/anime-api/internal/subtitle"
+	"github.com/wicho90/anime-api/internal/taxonomy"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"github.com/wicho90/anime-api/internal/watchlist"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
 	"log"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type Server struct {
@@ -29,21 +59,67 @@ func errorHandler(ctx *fiber.Ctx, err error) error {
 
 func New(
 	seasonHandler season.Handler,
+	seasonHandlerV2 season.HandlerV2,
 	episodeHandler episode.Handler,
-) *Server {
-	app := fiber.New(fiber.Config{ErrorHandler: errorHandler})
-	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "http://127.0.0.1:5173",
-		AllowMethods:     "GET,POST,PUT,DELETE",
-		AllowHeaders:     "Content-Type",
-		AllowCredentials: false,
-	}))
-
-	app.Static("/", "./public")
-
-	v1 := app.Group("/api/v1")
+	episodeHandlerV2 episode.HandlerV2,
+	sourceHandler source.Handler,
+	subtitleHandler subtitle.Handler,
+	artworkHandler artwork.Handler,
+	linksHandler links.Handler,
+	ratingHandler rating.Handler,
+	commentHandler comment.Handler,
+	watchlistHandler watchlist.Handler,
+	malHandler mal.Handler,
+	taxonomyHandler taxonomy.Handler,
+	castHandler cast.Handler,
+	creditHandler credit.Handler,
+	scheduleHandler schedule.Handler,
+	calendarHandler calendar.Handler,
+	feedHandler feed.Handler,
+	healthHandler health.Handler,
+	graphHandler graph.Handler,
+	authRepository auth.Repository,
+	rateLimitStore ratelimit.Store,
+	tracerProvider trace.TracerProvider,
+	config *config.Config,
+) (*Server, error) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler:            errorHandler,
+		ReadTimeout:             config.Server.ReadTimeout,
+		WriteTimeout:            config.Server.WriteTimeout,
+		IdleTimeout:             config.Server.IdleTimeout,
+		BodyLimit:               config.Server.BodyLimit,
+		ProxyHeader:             config.Server.ProxyHeader,
+		EnableTrustedProxyCheck: len(config.Server.TrustedProxies) > 0,
+		TrustedProxies:          config.Server.TrustedProxies,
+	})
+	app.Use(apiversion.Negotiate("seasons", "episodes", "schedule"))
+	app.Use(telemetry.Middleware(tracerProvider))
+	if config.Log.Level == "debug" {
+		app.Use(logger.New())
+	}
+	app.Use(security.Headers(security.NewPolicy(config)))
+	app.Use(security.CORS(config))
+
+	doc := newDocument()
+	docsHandler := openapi.NewHandler(doc)
+
+	app.Get("/healthz", healthHandler.Liveness)
+	app.Get("/readyz", healthHandler.Readiness)
+
+	app.Static("/", config.Server.StaticDir)
+
+	authMiddleware := auth.Middleware(authRepository)
+	rateLimitGuard := ratelimit.Guard(rateLimitStore, config)
+	rateLimitMiddleware := ratelimit.Middleware(rateLimitStore, config)
+	requireUser := auth.RequireUser()
+	requireAdmin := auth.RequireAdmin()
+
+	v1 := app.Group("/api/v1", rateLimitGuard, authMiddleware, rateLimitMiddleware)
 	{
-		seasons := v1.Group("seasons")
+		deprecated := apiversion.Deprecate("v1", "v2", config)
+
+		seasons := v1.Group("seasons", deprecated)
 		{
 			seasons.Get("/", seasonHandler.GetAll)
 			seasons.Get("/:id", seasonHandler.GetById)
@@ -51,7 +127,7 @@ func New(
 			seasons.Put("/:id", seasonHandler.Update)
 			seasons.Delete("/:id", seasonHandler.Delete)
 		}
-		episodes := v1.Group("/episodes")
+		episodes := v1.Group("/episodes", deprecated)
 		{
 			episodes.Get("/", episodeHandler.GetAll)
 			episodes.Get("/latest", episodeHandler.GetLatest)
@@ -61,18 +137,242 @@ func New(
 			episodes.Put("/:id", episodeHandler.Update)
 			episodes.Delete("/:id", episodeHandler.Delete)
 		}
+		v1.Get("/schedule", deprecated, scheduleHandler.GetWeek)
+		admin := v1.Group("/admin", requireAdmin)
+		{
+			admin.Get("/broken-links", linksHandler.GetBroken)
+		}
+		v1.Get("/openapi.json", docsHandler.Spec)
+		v1.Get("/docs", docsHandler.UI)
+	}
+
+	app.Get("/graphql", rateLimitGuard, authMiddleware, graphHandler.Prepare, rateLimitMiddleware, graphHandler.Serve)
+	app.Post("/graphql", rateLimitGuard, authMiddleware, graphHandler.Prepare, rateLimitMiddleware, graphHandler.Serve)
+
+	v2 := app.Group("/api/v2", rateLimitGuard, authMiddleware, rateLimitMiddleware)
+	{
+		seasons := v2.Group("/seasons")
+		{
+			seasons.Get("/", seasonHandlerV2.GetAll)
+			seasons.Get("/top-rated", ratingHandler.GetTopRatedSeasons)
+			seasons.Get("/:id", seasonHandlerV2.GetById)
+			seasons.Post("/", requireAdmin, seasonHandlerV2.Create)
+			seasons.Put("/:id", requireAdmin, seasonHandlerV2.Update)
+			seasons.Delete("/:id", requireAdmin, seasonHandlerV2.Delete)
+			seasons.Get("/:id/image", artworkHandler.GetSeasonImage)
+			seasons.Put("/:id/image", requireAdmin, artworkHandler.UploadSeasonImage)
+			seasons.Put("/:id/rating", requireUser, ratingHandler.RateSeason)
+			seasons.Delete("/:id/rating", requireUser, ratingHandler.UnrateSeason)
+			seasons.Get("/:id/characters", castHandler.GetSeasonCharacters)
+			seasons.Get("/:id/calendar.ics", calendarHandler.GetBySeason)
+			seasons.Get("/slug/:slug/feed.rss", feedHandler.GetSeasonRSS)
+			seasons.Get("/slug/:slug/feed.atom", feedHandler.GetSeasonAtom)
+		}
+		episodes := v2.Group("/episodes")
+		{
+			episodes.Get("/", episodeHandlerV2.GetAll)
+			episodes.Get("/latest", episodeHandlerV2.GetLatest)
+			episodes.Get("/top-rated", ratingHandler.GetTopRatedEpisodes)
+			episodes.Get("/feed.rss", feedHandler.GetLatestRSS)
+			episodes.Get("/feed.atom", feedHandler.GetLatestAtom)
+			episodes.Get("/:id", episodeHandlerV2.GetById)
+			episodes.Get("/slug/:slug", episodeHandlerV2.GetBySlug)
+			episodes.Post("/", requireAdmin, episodeHandlerV2.Create)
+			episodes.Put("/:id", requireAdmin, episodeHandlerV2.Update)
+			episodes.Delete("/:id", requireAdmin, episodeHandlerV2.Delete)
+			episodes.Get("/:id/sources", sourceHandler.GetByEpisodeID)
+			episodes.Post("/:id/sources", requireAdmin, sourceHandler.Create)
+			episodes.Get("/:id/subtitles", subtitleHandler.GetByEpisodeID)
+			episodes.Get("/:id/subtitles/:language", subtitleHandler.GetTrack)
+			episodes.Put("/:id/subtitles/:language", requireAdmin, subtitleHandler.Upload)
+			episodes.Delete("/:id/subtitles/:language", requireAdmin, subtitleHandler.Delete)
+			episodes.Get("/:id/thumbnail", artworkHandler.GetEpisodeThumbnail)
+			episodes.Put("/:id/thumbnail", requireAdmin, artworkHandler.UploadEpisodeThumbnail)
+			episodes.Put("/:id/rating", requireUser, ratingHandler.RateEpisode)
+			episodes.Delete("/:id/rating", requireUser, ratingHandler.UnrateEpisode)
+			episodes.Get("/:id/comments", commentHandler.GetByEpisodeID)
+			episodes.Post("/:id/comments", requireUser, commentHandler.Create)
+		}
+		sources := v2.Group("/sources")
+		{
+			sources.Put("/:id", requireAdmin, sourceHandler.Update)
+			sources.Delete("/:id", requireAdmin, sourceHandler.Delete)
+		}
+		comments := v2.Group("/comments")
+		{
+			comments.Get("/:id/replies", commentHandler.GetReplies)
+			comments.Put("/:id", requireUser, commentHandler.Update)
+			comments.Delete("/:id", requireUser, commentHandler.Delete)
+			comments.Post("/:id/reports", requireUser, commentHandler.Report)
+		}
+		v2.Get("/genres", taxonomyHandler.GetGenres)
+		v2.Get("/tags", taxonomyHandler.GetTags)
+		v2.Get("/characters/:id", castHandler.GetCharacter)
+		v2.Get("/people/:id", castHandler.GetPerson)
+		v2.Get("/people/:id/roles", castHandler.GetPersonRoles)
+		v2.Get("/people/:id/credits", castHandler.GetPersonCredits)
+		v2.Get("/studios", creditHandler.GetStudios)
+		v2.Get("/studios/:id/seasons", creditHandler.GetStudioSeasons)
+		v2.Get("/schedule", scheduleHandler.GetWeek)
+		v2.Get("/calendar.ics", calendarHandler.GetAll)
+		v2.Get("/calendar/:token.ics", calendarHandler.GetPersonal)
+		v2.Post("/calendar/token", requireUser, calendarHandler.CreateToken)
+		v2.Delete("/calendar/token", requireUser, calendarHandler.DeleteToken)
+		watchlists := v2.Group("/watchlist", requireUser)
+		{
+			watchlists.Get("/", watchlistHandler.GetAll)
+			watchlists.Post("/import/preview", malHandler.Preview)
+			watchlists.Post("/import", malHandler.Import)
+			watchlists.Get("/:id", watchlistHandler.GetBySeasonID)
+			watchlists.Put("/:id", watchlistHandler.Save)
+			watchlists.Delete("/:id", watchlistHandler.Delete)
+			watchlists.Post("/:id/progress", watchlistHandler.Advance)
+		}
+		admin := v2.Group("/admin", requireAdmin)
+		{
+			admin.Get("/comments", commentHandler.GetQueue)
+			admin.Put("/comments/:id/moderation", commentHandler.Moderate)
+			admin.Put("/seasons/:id/mal-id", malHandler.SetExternalID)
+			admin.Delete("/seasons/:id/mal-id", malHandler.DeleteExternalID)
+			admin.Put("/seasons/:id/genres", taxonomyHandler.SetSeasonGenres)
+			admin.Put("/seasons/:id/tags", taxonomyHandler.SetSeasonTags)
+			admin.Post("/genres", taxonomyHandler.CreateGenre)
+			admin.Put("/genres/:id", taxonomyHandler.UpdateGenre)
+			admin.Delete("/genres/:id", taxonomyHandler.DeleteGenre)
+			admin.Post("/tags", taxonomyHandler.CreateTag)
+			admin.Put("/tags/:id", taxonomyHandler.UpdateTag)
+			admin.Delete("/tags/:id", taxonomyHandler.DeleteTag)
+			admin.Post("/characters", castHandler.CreateCharacter)
+			admin.Put("/characters/:id", castHandler.UpdateCharacter)
+			admin.Delete("/characters/:id", castHandler.DeleteCharacter)
+			admin.Post("/people", castHandler.CreatePerson)
+			admin.Put("/people/:id", castHandler.UpdatePerson)
+			admin.Delete("/people/:id", castHandler.DeletePerson)
+			admin.Post("/roles", castHandler.CreateRole)
+			admin.Delete("/roles/:id", castHandler.DeleteRole)
+			admin.Post("/studios", creditHandler.CreateStudio)
+			admin.Put("/studios/:id", creditHandler.UpdateStudio)
+			admin.Delete("/studios/:id", creditHandler.DeleteStudio)
+			admin.Put("/seasons/:id/studios", creditHandler.SetSeasonStudios)
+			admin.Post("/episodes/:id/credits", creditHandler.Create)
+			admin.Delete("/credits/:id", creditHandler.Delete)
+			admin.Put("/episodes/:id/airing", scheduleHandler.Save)
+			admin.Delete("/episodes/:id/airing", scheduleHandler.Delete)
+			admin.Post("/seasons/:id/schedule/shift", scheduleHandler.Shift)
+		}
+	}
+
+	if err := doc.Verify(routes(app)); err != nil {
+		return nil, err
 	}
 
 	return &Server{
 		app: app,
+	}, nil
+}
+
+// newDocument reúne la documentación de cada paquete. Debe cubrir todas las
+// rutas que registra New; si no, el servidor no arranca.
+func newDocument() *openapi.Document {
+	doc := openapi.New("Anime API", "2.0.0", "Catalog of anime seasons and episodes.\n\n"+
+		"/api/v1 is deprecated in favour of /api/v2. The version can also be chosen with "+
+		"`Accept: application/vnd.anime-api.v2+json` on any /api/v1 resource.")
+	health.OpenAPI(doc)
+	season.OpenAPI(doc)
+	episode.OpenAPI(doc)
+	source.OpenAPI(doc)
+	subtitle.OpenAPI(doc)
+	artwork.OpenAPI(doc)
+	links.OpenAPI(doc)
+	rating.OpenAPI(doc)
+	comment.OpenAPI(doc)
+	watchlist.OpenAPI(doc)
+	mal.OpenAPI(doc)
+	taxonomy.OpenAPI(doc)
+	cast.OpenAPI(doc)
+	credit.OpenAPI(doc)
+	schedule.OpenAPI(doc)
+	calendar.OpenAPI(doc)
+	feed.OpenAPI(doc)
+	graph.OpenAPI(doc)
+	openapi.OpenAPI(doc)
+
+	return doc
+}
+
+// routes lista las rutas de la aplicación como "METHOD /path", sin los
+// middlewares, los HEAD que Fiber añade a cada GET ni los archivos estáticos.
+func routes(app *fiber.App) []string {
+	var list []string
+	seen := map[string]bool{}
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || strings.Contains(route.Path, "*") {
+			continue
+		}
+
+		key := route.Method + " " + route.Path
+		if !seen[key] {
+			seen[key] = true
+			list = append(list, key)
+		}
 	}
+
+	return list
 }
-func Start(s *Server, config *config.Config) {
+
+func Start(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	s *Server,
+	healthHandler health.Handler,
+	db *sql.DB,
+	replica *database.Replica,
+	config *config.Config,
+) {
 	addr := fmt.Sprintf(":%s", config.Server.Port)
-	log.Printf("Server listening on %s", addr)
 
-	err := s.app.Listen(addr)
-	if err != nil {
-		panic(err)
-	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			log.Printf("Server listening on %s", addr)
+
+			go func() {
+				if err := s.app.Listener(ln); err != nil {
+					log.Printf("Server stopped: %s", err)
+					_ = shutdowner.Shutdown()
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			healthHandler.Drain()
+			if config.Server.DrainDelay > 0 {
+				log.Printf("Draining for %s", config.Server.DrainDelay)
+				// Si fx agota el plazo de parada se deja de esperar para que
+				// aún dé tiempo a cerrar el servidor y las bases de datos.
+				select {
+				case <-time.After(config.Server.DrainDelay):
+				case <-ctx.Done():
+					log.Printf("Drain interrupted: %s", ctx.Err())
+				}
+			}
+
+			log.Println("Shutting down server")
+			if err := s.app.ShutdownWithTimeout(config.Server.ShutdownTimeout); err != nil {
+				log.Printf("Server shutdown failed: %s", err)
+			}
+
+			if replica.DB != db {
+				if err := replica.Close(); err != nil {
+					log.Printf("Failed to close replica: %s", err)
+				}
+			}
+
+			return db.Close()
+		},
+	})
 }
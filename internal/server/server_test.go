@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/config"
+	"github.com/wicho90/anime-api/internal/artwork"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/calendar"
+	"github.com/wicho90/anime-api/internal/cast"
+	"github.com/wicho90/anime-api/internal/comment"
+	"github.com/wicho90/anime-api/internal/credit"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/episode"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/feed"
+	"github.com/wicho90/anime-api/internal/graph"
+	"github.com/wicho90/anime-api/internal/health"
+	"github.com/wicho90/anime-api/internal/links"
+	"github.com/wicho90/anime-api/internal/mal"
+	"github.com/wicho90/anime-api/internal/ratelimit"
+	"github.com/wicho90/anime-api/internal/rating"
+	"github.com/wicho90/anime-api/internal/schedule"
+	"github.com/wicho90/anime-api/internal/season"
+	"github.com/wicho90/anime-api/internal/source"
+	"github.com/wicho90/anime-api/internal/subtitle"
+	"github.com/wicho90/anime-api/internal/taxonomy"
+	"github.com/wicho90/anime-api/internal/validator"
+	"github.com/wicho90/anime-api/internal/watchlist"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// authRepository reconoce el token "user", de un usuario sin rol de
+// administrador.
+type authRepository struct{}
+
+func (authRepository) GetByTokenHash(_ context.Context, tokenHash string) (*entities.User, error) {
+	if tokenHash == auth.HashToken("user") {
+		return &entities.User{ID: 1, Username: "user", Role: entities.RoleUser}, nil
+	}
+	return nil, fmt.Errorf("user %w", ex.ErrNotFound)
+}
+
+func (authRepository) Create(context.Context, *entities.User, string) error {
+	return nil
+}
+
+// newTestServer monta la aplicación con servicios sin repositorios: basta
+// para registrar las rutas y atender las peticiones que se rechazan antes
+// de llegar a la base de datos.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	t.Setenv("DB_PASSWORD", "test")
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Las pruebas repiten peticiones de escritura desde la misma IP; el
+	// limitador tiene sus propias pruebas.
+	cfg.RateLimit.Enabled = false
+
+	store := ratelimit.NewMemoryStore()
+	t.Cleanup(store.Close)
+
+	v := validator.NewCustomValidator()
+	policy := links.NewPolicy(cfg)
+	seasonService := season.NewService(nil, nil, nil, nil, nil)
+	episodeService := episode.NewService(nil, nil, nil, nil, nil, policy)
+	graphHandler, err := graph.NewHandler(seasonService, episodeService, v, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(
+		season.NewHandler(seasonService, v),
+		season.NewHandlerV2(seasonService, v),
+		episode.NewHandler(episodeService, v),
+		episode.NewHandlerV2(episodeService, v),
+		source.NewHandler(source.NewService(nil, policy), v),
+		subtitle.NewHandler(subtitle.NewService(nil, nil), v),
+		artwork.NewHandler(nil, cfg),
+		links.NewHandler(links.NewService(nil)),
+		rating.NewHandler(rating.NewService(nil), v),
+		comment.NewHandler(comment.NewService(nil, nil, cfg), v),
+		watchlist.NewHandler(watchlist.NewService(nil), v),
+		mal.NewHandler(mal.NewService(nil, nil, nil), v),
+		taxonomy.NewHandler(taxonomy.NewService(nil), v),
+		cast.NewHandler(cast.NewService(nil, nil, nil), v),
+		credit.NewHandler(credit.NewService(nil), v),
+		schedule.NewHandler(schedule.NewService(nil), v),
+		calendar.NewHandler(calendar.NewService(nil, nil, nil)),
+		feed.NewHandler(feed.NewService(nil, cfg)),
+		health.NewHandler(health.Params{Config: cfg}),
+		graphHandler,
+		authRepository{},
+		store,
+		trace.NewNoopTracerProvider(),
+		cfg,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := newDocument().Verify(routes(s.app)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyDetectsUndocumentedRoutes(t *testing.T) {
+	s := newTestServer(t)
+	s.app.Get("/api/v2/undocumented", func(ctx *fiber.Ctx) error {
+		return nil
+	})
+
+	if err := newDocument().Verify(routes(s.app)); err == nil {
+		t.Fatal("Verify accepted a route without an OpenAPI operation")
+	}
+}
+
+func TestAdminRoutesRequireAdministrator(t *testing.T) {
+	s := newTestServer(t)
+
+	routes := []struct {
+		method, path string
+	}{
+		{http.MethodPost, "/api/v2/seasons"},
+		{http.MethodPut, "/api/v2/seasons/1"},
+		{http.MethodDelete, "/api/v2/seasons/1"},
+		{http.MethodPost, "/api/v2/episodes"},
+		{http.MethodPut, "/api/v2/episodes/1"},
+		{http.MethodDelete, "/api/v2/episodes/1"},
+		{http.MethodPost, "/api/v2/episodes/1/sources"},
+		{http.MethodPut, "/api/v2/sources/1"},
+		{http.MethodDelete, "/api/v2/sources/1"},
+		{http.MethodPut, "/api/v2/episodes/1/subtitles/en"},
+		{http.MethodDelete, "/api/v2/episodes/1/subtitles/en"},
+		{http.MethodPut, "/api/v2/seasons/1/image"},
+		{http.MethodPut, "/api/v2/episodes/1/thumbnail"},
+	}
+	for _, route := range routes {
+		for _, tt := range []struct {
+			token string
+			want  int
+		}{
+			{"", http.StatusUnauthorized},
+			{"user", http.StatusForbidden},
+		} {
+			t.Run(route.method+" "+route.path+" "+tt.token, func(t *testing.T) {
+				req := httptest.NewRequest(route.method, route.path, nil)
+				if tt.token != "" {
+					req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+				}
+
+				res, err := s.app.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if res.StatusCode != tt.want {
+					t.Errorf("got %d, want %d", res.StatusCode, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestGraphQLMutationsRequireAdministrator(t *testing.T) {
+	s := newTestServer(t)
+
+	body := `{"query":"mutation { deleteSeason(id: 1) }"}`
+	for _, tt := range []struct {
+		token string
+		want  int
+	}{
+		{"", http.StatusUnauthorized},
+		{"user", http.StatusForbidden},
+	} {
+		t.Run(tt.token, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.token != "" {
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+			}
+
+			res, err := s.app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != tt.want {
+				t.Errorf("got %d, want %d", res.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package source
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+// Filter restringe las fuentes devueltas. Los campos vacíos no filtran.
+type Filter struct {
+	Language        string
+	AudioType       string
+	IncludeInactive bool
+}
+
+type Repository interface {
+	// GetByEpisodeID devuelve las fuentes ordenadas por ranking: prioridad,
+	// calidad y antigüedad.
+	GetByEpisodeID(ctx context.Context, episodeID uint64, filter Filter) ([]*entities.EpisodeSource, error)
+	Create(ctx context.Context, source *entities.EpisodeSource) error
+	Update(ctx context.Context, source *entities.EpisodeSource) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+type Service interface {
+	GetByEpisodeID(ctx context.Context, episodeID uint64, filter Filter) ([]*entities.EpisodeSource, error)
+	Create(ctx context.Context, source *entities.EpisodeSource) error
+	Update(ctx context.Context, id uint64, source *entities.EpisodeSource) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+type Handler interface {
+	GetByEpisodeID(ctx *fiber.Ctx) error
+	Create(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,73 @@
+package source
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type sourceRequest struct {
+	// Host se deduce de la URL si se omite.
+	Host      string `json:"host" validate:"omitempty,max=100"`
+	Quality   string `json:"quality" validate:"required,oneof=480p 720p 1080p"`
+	Language  string `json:"language" validate:"required,min=2,max=10"`
+	AudioType string `json:"audio_type" validate:"required,oneof=sub dub raw"`
+	Url       string `json:"url" validate:"required,http_url,max=2048"`
+	Priority  int16  `json:"priority" validate:"min=0,max=100"`
+	// Active es true si se omite.
+	Active *bool `json:"active"`
+}
+
+func (r *sourceRequest) toEntity() *entities.EpisodeSource {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+
+	return &entities.EpisodeSource{
+		Host:      r.Host,
+		Quality:   r.Quality,
+		Language:  r.Language,
+		AudioType: r.AudioType,
+		Url:       r.Url,
+		Priority:  r.Priority,
+		Active:    active,
+	}
+}
+
+type sourceResponse struct {
+	ID        uint64 `json:"id"`
+	EpisodeID uint64 `json:"episode_id"`
+	Host      string `json:"host"`
+	Quality   string `json:"quality"`
+	Language  string `json:"language"`
+	AudioType string `json:"audio_type"`
+	Url       string `json:"url"`
+	Priority  int16  `json:"priority"`
+	Active    bool   `json:"active"`
+}
+
+func newSourceResponse(source *entities.EpisodeSource) *sourceResponse {
+	return &sourceResponse{
+		ID:        source.ID,
+		EpisodeID: source.EpisodeID,
+		Host:      source.Host,
+		Quality:   source.Quality,
+		Language:  source.Language,
+		AudioType: source.AudioType,
+		Url:       source.Url,
+		Priority:  source.Priority,
+		Active:    source.Active,
+	}
+}
+
+type sourceListResponse struct {
+	Data []*sourceResponse `json:"data"`
+}
+
+func newSourceListResponse(sources []*entities.EpisodeSource) *sourceListResponse {
+	list := &sourceListResponse{Data: make([]*sourceResponse, 0, len(sources))}
+	for _, source := range sources {
+		list.Data = append(list.Data, newSourceResponse(source))
+	}
+
+	return list
+}
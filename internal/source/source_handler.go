@@ -0,0 +1,119 @@
+package source
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetByEpisodeID(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	filter := Filter{
+		Language:        ctx.Query("language"),
+		AudioType:       ctx.Query("audio_type"),
+		IncludeInactive: ctx.QueryBool("include_inactive"),
+	}
+	sources, err := h.service.GetByEpisodeID(ctx.UserContext(), episodeID, filter)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get sources")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSourceListResponse(sources))
+}
+
+func (h *handler) Create(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request sourceRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	source := request.toEntity()
+	source.EpisodeID = episodeID
+	if err := h.service.Create(ctx.UserContext(), source); err != nil {
+		return writeError(err, "Failed to create source")
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newSourceResponse(source))
+}
+
+func (h *handler) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request sourceRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	source := request.toEntity()
+	if err := h.service.Update(ctx.UserContext(), id, source); err != nil {
+		return writeError(err, "Failed to update source")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSourceResponse(source))
+}
+
+func (h *handler) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), id); err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to delete source")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func writeError(err error, message string) error {
+	var alreadyExists *ex.ErrAlreadyExists
+	var validation *ex.ErrValidation
+
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &alreadyExists):
+		return response.NewConflictResponse("The episode already has a source with this url")
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
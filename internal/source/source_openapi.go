@@ -0,0 +1,109 @@
+package source
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+var example = entities.EpisodeSource{
+	ID:        1,
+	EpisodeID: 1,
+	Host:      "video.example.com",
+	Quality:   entities.Quality1080p,
+	Language:  "ja",
+	AudioType: entities.AudioSub,
+	Url:       "https://video.example.com/snk/1/1080p",
+	Priority:  10,
+	Active:    true,
+}
+
+// OpenAPI documenta las rutas de las fuentes de los episodios.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("EpisodeSourceV2", sourceResponse{})
+	list := doc.Register("EpisodeSourceListV2", sourceListResponse{})
+	request := doc.Register("EpisodeSourceRequestV2", sourceRequest{})
+
+	response := newSourceResponse(&example)
+	active := true
+	input := sourceRequest{
+		Quality: example.Quality, Language: example.Language, AudioType: example.AudioType,
+		Url: example.Url, Priority: example.Priority, Active: &active,
+	}
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	doc.Add(http.MethodGet, "/api/v2/episodes/:id/sources", &openapi.Operation{
+		OperationID: "listEpisodeSourcesV2",
+		Summary:     "List the sources of an episode",
+		Description: "Sources are ranked by priority, then quality, then age. Inactive sources are hidden unless include_inactive is true.",
+		Tags:        []string{"sources"},
+		Parameters: []*openapi.Parameter{
+			openapi.IDParam(),
+			openapi.QueryParam("language", "Only sources in this language", &openapi.Schema{Type: "string"}),
+			openapi.QueryParam("audio_type", "Only sources with this audio type", &openapi.Schema{
+				Type: "string", Enum: []any{entities.AudioSub, entities.AudioDub, entities.AudioRaw},
+			}),
+			openapi.QueryParam("include_inactive", "Include inactive sources", &openapi.Schema{Type: "boolean"}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Sources", list, sourceListResponse{Data: []*sourceResponse{response}}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get sources"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/episodes/:id/sources", &openapi.Operation{
+		OperationID: "createEpisodeSourceV2",
+		Summary:     "Add a source to an episode",
+		Description: "The host is derived from the url when omitted. The url host must be listed in links.allowed_hosts, when set. " +
+			"Requires an administrator token.",
+		Tags:        []string{"sources"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusCreated:             openapi.JSON("Created source", schema, response),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Url is required"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusConflict:            openapi.Error(http.StatusConflict, "The episode already has a source with this url"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create source"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/sources/:id", &openapi.Operation{
+		OperationID: "updateEpisodeSourceV2",
+		Summary:     "Replace a source",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"sources"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, input),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Updated source", schema, response),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid request body"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "source with id 1 not found"),
+			http.StatusConflict:            openapi.Error(http.StatusConflict, "The episode already has a source with this url"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update source"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/sources/:id", &openapi.Operation{
+		OperationID: "deleteEpisodeSourceV2",
+		Summary:     "Delete a source",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"sources"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "source with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete source"),
+		}),
+	})
+}
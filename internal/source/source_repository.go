@@ -0,0 +1,152 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"strings"
+)
+
+const (
+	queryGetByEpisodeId = `SELECT id, episode_id, host, quality, language, audio_type, url, priority, active
+		FROM episode_sources
+		WHERE episode_id = $1 AND (active OR $2) AND ($3 = '' OR language = $3) AND ($4 = '' OR audio_type = $4)
+		ORDER BY priority DESC,
+			CASE quality WHEN '1080p' THEN 3 WHEN '720p' THEN 2 WHEN '480p' THEN 1 ELSE 0 END DESC,
+			id`
+	queryCreate = `INSERT INTO episode_sources (episode_id, host, quality, language, audio_type, url, priority, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	queryUpdate = `UPDATE episode_sources
+		SET host = $1, quality = $2, language = $3, audio_type = $4, url = $5, priority = $6, active = $7
+		WHERE id = $8 RETURNING episode_id`
+	queryDeleteById = "DELETE FROM episode_sources WHERE id = $1"
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetByEpisodeID(ctx context.Context, episodeID uint64, filter Filter) ([]*entities.EpisodeSource, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByEpisodeId", queryGetByEpisodeId)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetByEpisodeId, episodeID, filter.IncludeInactive, filter.Language, filter.AudioType)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	sources := []*entities.EpisodeSource{}
+	for rows.Next() {
+		source := &entities.EpisodeSource{}
+		if err := rows.Scan(&source.ID, &source.EpisodeID, &source.Host, &source.Quality, &source.Language,
+			&source.AudioType, &source.Url, &source.Priority, &source.Active); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		sources = append(sources, source)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return sources, nil
+}
+
+func (r *repository) Create(ctx context.Context, source *entities.EpisodeSource) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", queryCreate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryCreate, source.EpisodeID, source.Host, source.Quality, source.Language,
+		source.AudioType, source.Url, source.Priority, source.Active).Scan(&source.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, source)
+	}
+
+	return nil
+}
+
+func (r *repository) Update(ctx context.Context, source *entities.EpisodeSource) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdate", queryUpdate)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, queryUpdate, source.Host, source.Quality, source.Language,
+		source.AudioType, source.Url, source.Priority, source.Active, source.ID).Scan(&source.EpisodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("source with id %d %w", source.ID, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return mapError(err, source)
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteById", queryDeleteById)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDeleteById, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("source with id %d %w", id, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func mapError(err error, source *entities.EpisodeSource) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23505": // duplicados
+			return &ex.ErrAlreadyExists{
+				Field:      "url",
+				Constraint: pgErr.Constraint,
+			}
+		case "23503": // el episodio no existe
+			return fmt.Errorf("episode with id %d %w", source.EpisodeID, ex.ErrNotFound)
+		case "23514": // check de calidad o tipo de audio
+			// Postgres no rellena la columna en los CHECK; el nombre del
+			// constraint es episode_sources_<columna>_check.
+			field := strings.TrimSuffix(strings.TrimPrefix(pgErr.Constraint, "episode_sources_"), "_check")
+			return &ex.ErrValidation{
+				Field:  field,
+				Reason: "has an unsupported value",
+			}
+		default:
+			log.Printf("Source write failed: %s", err)
+			return fmt.Errorf("source write failed: %w", err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,32 @@
+package source
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"testing"
+)
+
+func TestMapErrorCheckViolation(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"episode_sources_quality_check", "quality"},
+		{"episode_sources_audio_type_check", "audio_type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			err := mapError(&pq.Error{Code: "23514", Constraint: tt.constraint}, &entities.EpisodeSource{})
+
+			var validation *ex.ErrValidation
+			if !errors.As(err, &validation) {
+				t.Fatalf("got %v, want a validation error", err)
+			}
+			if validation.Field != tt.want {
+				t.Errorf("got field %q, want %q", validation.Field, tt.want)
+			}
+		})
+	}
+}
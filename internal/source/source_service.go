@@ -0,0 +1,113 @@
+package source
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/links"
+	"go.opentelemetry.io/otel"
+	"net/url"
+	"strings"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/source")
+
+type service struct {
+	repository Repository
+	policy     *links.Policy
+}
+
+func NewService(repository Repository, policy *links.Policy) Service {
+	return &service{
+		repository: repository,
+		policy:     policy,
+	}
+}
+
+func (s *service) GetByEpisodeID(ctx context.Context, episodeID uint64, filter Filter) ([]*entities.EpisodeSource, error) {
+	ctx, span := tracer.Start(ctx, "source.Service.GetByEpisodeID")
+	defer span.End()
+
+	filter.Language = strings.ToLower(filter.Language)
+
+	return s.repository.GetByEpisodeID(ctx, episodeID, filter)
+}
+
+func (s *service) Create(ctx context.Context, source *entities.EpisodeSource) error {
+	ctx, span := tracer.Start(ctx, "source.Service.Create")
+	defer span.End()
+
+	if err := s.policy.Check("url", source.Url); err != nil {
+		return err
+	}
+	normalize(source)
+
+	return s.repository.Create(ctx, source)
+}
+
+func (s *service) Update(ctx context.Context, id uint64, source *entities.EpisodeSource) error {
+	ctx, span := tracer.Start(ctx, "source.Service.Update")
+	defer span.End()
+
+	if err := s.policy.Check("url", source.Url); err != nil {
+		return err
+	}
+	source.ID = id
+	normalize(source)
+
+	return s.repository.Update(ctx, source)
+}
+
+func (s *service) Delete(ctx context.Context, id uint64) error {
+	ctx, span := tracer.Start(ctx, "source.Service.Delete")
+	defer span.End()
+
+	return s.repository.Delete(ctx, id)
+}
+
+func normalize(source *entities.EpisodeSource) {
+	source.Language = strings.ToLower(strings.TrimSpace(source.Language))
+	source.Host = strings.ToLower(strings.TrimSpace(source.Host))
+	if source.Host == "" {
+		source.Host = hostOf(source.Url)
+	}
+}
+
+// WithPrimary antepone a las fuentes ya ordenadas la fuente principal
+// construida con la URL heredada de Episode.Url, para que los clientes que
+// sólo conocen esa URL sigan recibiéndola en primer lugar. Si alguna fuente
+// ya usa esa URL se marca como principal en lugar de duplicarla.
+func WithPrimary(episodeID uint64, legacyUrl string, sources []*entities.EpisodeSource) []*entities.EpisodeSource {
+	ranked := make([]*entities.EpisodeSource, 0, len(sources)+1)
+
+	var primary *entities.EpisodeSource
+	for _, source := range sources {
+		if source.Url == legacyUrl && primary == nil {
+			primary = source
+			continue
+		}
+		ranked = append(ranked, source)
+	}
+	if primary == nil {
+		if legacyUrl == "" {
+			return ranked
+		}
+		primary = &entities.EpisodeSource{
+			EpisodeID: episodeID,
+			Host:      hostOf(legacyUrl),
+			Url:       legacyUrl,
+			Active:    true,
+		}
+	}
+	primary.Primary = true
+
+	return append([]*entities.EpisodeSource{primary}, ranked...)
+}
+
+func hostOf(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
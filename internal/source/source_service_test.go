@@ -0,0 +1,74 @@
+package source
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"testing"
+)
+
+func TestWithPrimary(t *testing.T) {
+	const legacy = "https://video.example.com/snk/1"
+	mirror := func() *entities.EpisodeSource {
+		return &entities.EpisodeSource{ID: 2, Url: "https://mirror.example.com/snk/1", Active: true}
+	}
+	same := func() *entities.EpisodeSource {
+		return &entities.EpisodeSource{ID: 3, Url: legacy, Active: true}
+	}
+
+	tests := []struct {
+		name      string
+		legacyUrl string
+		sources   []*entities.EpisodeSource
+		wantIDs   []uint64
+		wantHost  string
+	}{
+		{"builds the primary from the episode url", legacy, []*entities.EpisodeSource{mirror()}, []uint64{0, 2}, "video.example.com"},
+		{"promotes the source with the episode url", legacy, []*entities.EpisodeSource{mirror(), same()}, []uint64{3, 2}, ""},
+		{"no episode url", "", []*entities.EpisodeSource{mirror()}, []uint64{2}, ""},
+		{"no sources", legacy, nil, []uint64{0}, "video.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithPrimary(1, tt.legacyUrl, tt.sources)
+
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("got %d sources, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, source := range got {
+				if source.ID != tt.wantIDs[i] {
+					t.Errorf("source %d: got id %d, want %d", i, source.ID, tt.wantIDs[i])
+				}
+				if wantPrimary := i == 0 && tt.legacyUrl != ""; source.Primary != wantPrimary {
+					t.Errorf("source %d: got primary %t, want %t", i, source.Primary, wantPrimary)
+				}
+			}
+			if tt.wantHost != "" && got[0].Host != tt.wantHost {
+				t.Errorf("got host %q, want %q", got[0].Host, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name                   string
+		source                 entities.EpisodeSource
+		wantHost, wantLanguage string
+	}{
+		{"derives the host from the url", entities.EpisodeSource{Url: "https://Mirror.Example.com:8443/snk/1", Language: " JA "}, "mirror.example.com", "ja"},
+		{"keeps an explicit host", entities.EpisodeSource{Url: "https://cdn.example.com/snk/1", Host: " Video.Example.com", Language: "es"}, "video.example.com", "es"},
+		{"invalid url", entities.EpisodeSource{Url: "://", Language: "en"}, "", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := tt.source
+			normalize(&source)
+
+			if source.Host != tt.wantHost {
+				t.Errorf("got host %q, want %q", source.Host, tt.wantHost)
+			}
+			if source.Language != tt.wantLanguage {
+				t.Errorf("got language %q, want %q", source.Language, tt.wantLanguage)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github.com/wicho90/anime-api/config"
+)
+
+// ErrInvalidKey indica una clave vacía o que intenta salir del almacenamiento.
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage guarda objetos por clave ("seasons/1/3f2a/card.jpg") y los publica
+// en URL(key). Las claves usan "/" como separador en todos los backends.
+type Storage interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Delete no falla si el objeto no existe.
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// New devuelve el backend indicado en config.Storage.Backend.
+func New(config *config.Config) (Storage, error) {
+	if config.Storage.Backend == "s3" {
+		return NewS3Storage(S3Options{
+			Endpoint:        config.Storage.S3.Endpoint,
+			Region:          config.Storage.S3.Region,
+			Bucket:          config.Storage.S3.Bucket,
+			AccessKeyID:     config.Storage.S3.AccessKeyID,
+			SecretAccessKey: config.Storage.S3.SecretAccessKey,
+			UsePathStyle:    config.Storage.S3.UsePathStyle,
+			PublicURL:       config.Storage.PublicURL,
+		})
+	}
+
+	return NewLocalStorage(config.Storage.LocalDir, config.Storage.PublicURL), nil
+}
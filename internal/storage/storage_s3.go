@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat = "20060102T150405Z"
+	signAlgorithm = "AWS4-HMAC-SHA256"
+)
+
+type S3Options struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool
+	// PublicURL es la URL base pública de los objetos; vacía usa la URL del
+	// objeto en el endpoint.
+	PublicURL string
+	// Client permite sustituir el cliente HTTP; nil usa uno con timeout.
+	Client *http.Client
+}
+
+// s3Storage habla directamente el API REST de S3 (PutObject y DeleteObject)
+// firmando las peticiones con Signature Version 4, lo que basta para AWS y
+// para servicios compatibles como MinIO.
+type s3Storage struct {
+	options  S3Options
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3Storage(options S3Options) (Storage, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(options.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", options.Endpoint)
+	}
+
+	client := options.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	options.PublicURL = strings.TrimSuffix(options.PublicURL, "/")
+
+	return &s3Storage{
+		options:  options,
+		endpoint: endpoint,
+		client:   client,
+		now:      time.Now,
+	}, nil
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	// Las claves no se reutilizan, así que el objeto nunca cambia.
+	req.Header.Set("Cache-Control", "public, max-age=31536000, immutable")
+
+	return s.do(req, body, http.StatusOK)
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.do(req, nil, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *s3Storage) URL(key string) string {
+	if s.options.PublicURL != "" {
+		return s.options.PublicURL + "/" + escapePath(key)
+	}
+
+	return s.objectURL(key)
+}
+
+func (s *s3Storage) objectURL(key string) string {
+	u := *s.endpoint
+	if s.options.UsePathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.options.Bucket + "/" + key
+	} else {
+		u.Host = s.options.Bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	u.RawPath = escapePath(u.Path)
+
+	return u.String()
+}
+
+func (s *s3Storage) do(req *http.Request, body []byte, expected ...int) error {
+	sign(req, body, s.options.Region, s.options.AccessKeyID, s.options.SecretAccessKey, s.now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("s3 %s failed: %w", req.Method, err)
+	}
+	defer res.Body.Close()
+
+	for _, status := range expected {
+		if res.StatusCode == status {
+			_, _ = io.Copy(io.Discard, res.Body)
+			return nil
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 %s %s returned %d: %s", req.Method, req.URL.Path, res.StatusCode, bytes.TrimSpace(message))
+}
+
+// sign añade a req las cabeceras x-amz-date, x-amz-content-sha256 y
+// Authorization de Signature Version 4. Se firman Host y todas las
+// cabeceras ya presentes en req.
+func sign(req *http.Request, body []byte, region, accessKeyID, secretAccessKey string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{signAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signAlgorithm, accessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		for _, value := range values[key] {
+			pairs = append(pairs, escape(key)+"="+escape(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// escapePath codifica cada segmento de la ruta como exige SigV4: todo salvo
+// los caracteres no reservados de RFC 3986.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
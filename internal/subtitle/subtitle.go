@@ -0,0 +1,35 @@
+package subtitle
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type Repository interface {
+	// GetByEpisodeID devuelve las pistas de un episodio sin su contenido.
+	GetByEpisodeID(ctx context.Context, episodeID uint64) ([]*entities.Subtitle, error)
+	GetTrack(ctx context.Context, episodeID uint64, language string) (*entities.Subtitle, error)
+	// Upsert crea la pista o reemplaza la del mismo idioma. Devuelve true si
+	// la pista es nueva.
+	Upsert(ctx context.Context, subtitle *entities.Subtitle) (bool, error)
+	Delete(ctx context.Context, episodeID uint64, language string) error
+}
+
+type Service interface {
+	GetByEpisodeID(ctx context.Context, episodeID uint64) ([]*entities.Subtitle, error)
+	// GetTrack devuelve la pista con su contenido WebVTT.
+	GetTrack(ctx context.Context, episodeID uint64, language string) (*entities.Subtitle, error)
+	// Upload valida el archivo contra la duración del episodio, lo convierte
+	// a WebVTT si es SRT y lo guarda. Si subtitle.Format está vacío el
+	// formato se deduce del contenido. Devuelve true si la pista es nueva.
+	Upload(ctx context.Context, subtitle *entities.Subtitle, content []byte) (bool, error)
+	Delete(ctx context.Context, episodeID uint64, language string) error
+}
+
+type Handler interface {
+	GetByEpisodeID(ctx *fiber.Ctx) error
+	GetTrack(ctx *fiber.Ctx) error
+	Upload(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+}
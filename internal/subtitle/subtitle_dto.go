@@ -0,0 +1,51 @@
+package subtitle
+
+import (
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+// trackRequest reúne los datos de la pista que no vienen en el archivo.
+type trackRequest struct {
+	Language string `json:"language" validate:"required,bcp47_language_tag,max=10"`
+	Label    string `json:"label" validate:"max=100"`
+}
+
+type subtitleResponse struct {
+	Language  string    `json:"language"`
+	Label     string    `json:"label"`
+	Format    string    `json:"format"`
+	CueCount  int       `json:"cue_count"`
+	Url       string    `json:"url"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newSubtitleResponse(subtitle *entities.Subtitle) *subtitleResponse {
+	return &subtitleResponse{
+		Language:  subtitle.Language,
+		Label:     subtitle.Label,
+		Format:    subtitle.Format,
+		CueCount:  subtitle.CueCount,
+		Url:       trackUrl(subtitle.EpisodeID, subtitle.Language),
+		UpdatedAt: subtitle.UpdatedAt,
+	}
+}
+
+type subtitleListResponse struct {
+	Data []*subtitleResponse `json:"data"`
+}
+
+func newSubtitleListResponse(subtitles []*entities.Subtitle) *subtitleListResponse {
+	list := &subtitleListResponse{Data: make([]*subtitleResponse, 0, len(subtitles))}
+	for _, subtitle := range subtitles {
+		list.Data = append(list.Data, newSubtitleResponse(subtitle))
+	}
+
+	return list
+}
+
+// trackUrl es la ruta desde la que el reproductor carga la pista.
+func trackUrl(episodeID uint64, language string) string {
+	return fmt.Sprintf("/api/v2/episodes/%d/subtitles/%s", episodeID, language)
+}
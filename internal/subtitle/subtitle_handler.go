@@ -0,0 +1,183 @@
+package subtitle
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxTrackSize es el tamaño máximo de un archivo de subtítulos.
+	MaxTrackSize = 1 << 20
+
+	mimeWebVTT = "text/vtt"
+)
+
+// formats asocia los tipos de contenido y las extensiones de archivo con el
+// formato de la pista.
+var formats = map[string]string{
+	mimeWebVTT:             entities.SubtitleFormatVTT,
+	"application/x-subrip": entities.SubtitleFormatSRT,
+	"text/srt":             entities.SubtitleFormatSRT,
+	".vtt":                 entities.SubtitleFormatVTT,
+	".srt":                 entities.SubtitleFormatSRT,
+}
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetByEpisodeID(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	subtitles, err := h.service.GetByEpisodeID(ctx.UserContext(), episodeID)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get subtitles")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSubtitleListResponse(subtitles))
+}
+
+// GetTrack sirve la pista como WebVTT para que el reproductor la cargue
+// directamente con <track src>.
+func (h *handler) GetTrack(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	subtitle, err := h.service.GetTrack(ctx.UserContext(), episodeID, ctx.Params("language"))
+	if err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to get subtitle")
+	}
+
+	ctx.Set(fiber.HeaderContentType, mimeWebVTT+"; charset=utf-8")
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	ctx.Set(fiber.HeaderLastModified, subtitle.UpdatedAt.UTC().Format(http.TimeFormat))
+
+	return ctx.Status(http.StatusOK).SendString(subtitle.Content)
+}
+
+// Upload acepta el archivo como cuerpo de la petición (text/vtt o
+// application/x-subrip) o como el campo "file" de un formulario multipart.
+func (h *handler) Upload(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	request := trackRequest{Language: ctx.Params("language"), Label: ctx.Query("label")}
+	content, format, err := h.readFile(ctx, &request)
+	if err != nil {
+		return err
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	subtitle := &entities.Subtitle{
+		EpisodeID: episodeID,
+		Language:  request.Language,
+		Label:     request.Label,
+		Format:    format,
+	}
+	created, err := h.service.Upload(ctx.UserContext(), subtitle, content)
+	if err != nil {
+		var validation *ex.ErrValidation
+		switch {
+		case errors.Is(err, ex.ErrNotFound):
+			return response.NewNotFoundResponse(err.Error())
+		case errors.As(err, &validation):
+			return response.NewBadRequestResponse(validation.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to upload subtitle")
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	return ctx.Status(status).JSON(newSubtitleResponse(subtitle))
+}
+
+// readFile devuelve el archivo subido y su formato, vacío si hay que
+// deducirlo del contenido.
+func (h *handler) readFile(ctx *fiber.Ctx, request *trackRequest) ([]byte, string, error) {
+	if !strings.HasPrefix(ctx.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		content := ctx.Body()
+		if len(content) == 0 {
+			return nil, "", response.NewBadRequestResponse("The subtitle file is empty")
+		}
+		if len(content) > MaxTrackSize {
+			return nil, "", response.NewBadRequestResponse("The subtitle file is larger than 1 MiB")
+		}
+
+		mediaType, _, _ := strings.Cut(ctx.Get(fiber.HeaderContentType), ";")
+
+		return content, formats[strings.TrimSpace(strings.ToLower(mediaType))], nil
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, "", response.NewBadRequestResponse("The file field is required")
+	}
+	if header.Size > MaxTrackSize {
+		return nil, "", response.NewBadRequestResponse("The subtitle file is larger than 1 MiB")
+	}
+	if label := ctx.FormValue("label"); label != "" {
+		request.Label = label
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, "", response.NewInternalServerErrorResponse("Failed to read subtitle")
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, "", response.NewInternalServerErrorResponse("Failed to read subtitle")
+	}
+
+	return content, formats[strings.ToLower(filepath.Ext(header.Filename))], nil
+}
+
+func (h *handler) Delete(ctx *fiber.Ctx) error {
+	episodeID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), episodeID, ctx.Params("language")); err != nil {
+		if errors.Is(err, ex.ErrNotFound) {
+			return response.NewNotFoundResponse(err.Error())
+		}
+
+		return response.NewInternalServerErrorResponse("Failed to delete subtitle")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package subtitle
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+const exampleTrack = `WEBVTT
+
+1
+00:00:01.000 --> 00:00:03.500
+On that day, humanity received a grim reminder.
+`
+
+var example = entities.Subtitle{
+	ID:        1,
+	EpisodeID: 1,
+	Language:  "en",
+	Label:     "English",
+	Format:    entities.SubtitleFormatSRT,
+	CueCount:  312,
+	UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+}
+
+// OpenAPI documenta las rutas de los subtítulos de los episodios.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("SubtitleV2", subtitleResponse{})
+	list := doc.Register("SubtitleListV2", subtitleListResponse{})
+
+	language := openapi.PathParam("language", "BCP 47 language tag", &openapi.Schema{Type: "string", MaxLength: integer(10)})
+	file := &openapi.Schema{Type: "string"}
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	doc.Add(http.MethodGet, "/api/v2/episodes/:id/subtitles", &openapi.Operation{
+		OperationID: "listSubtitlesV2",
+		Summary:     "List the subtitle tracks of an episode",
+		Tags:        []string{"subtitles"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Subtitle tracks", list, subtitleListResponse{Data: []*subtitleResponse{newSubtitleResponse(&example)}}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get subtitles"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/episodes/:id/subtitles/:language", &openapi.Operation{
+		OperationID: "getSubtitleV2",
+		Summary:     "Get a subtitle track as WebVTT",
+		Description: "Meant to be used directly as the src of a <track> element.",
+		Tags:        []string{"subtitles"},
+		Parameters:  []*openapi.Parameter{openapi.IDParam(), language},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: {
+				Description: "WebVTT track",
+				Content:     map[string]*openapi.MediaType{mimeWebVTT: {Schema: &openapi.Schema{Type: "string"}, Example: exampleTrack}},
+			},
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "subtitle en of episode 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get subtitle"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/episodes/:id/subtitles/:language", &openapi.Operation{
+		OperationID: "uploadSubtitleV2",
+		Summary:     "Upload a subtitle track",
+		Description: "Creates or replaces the track of the language. SRT files are converted to WebVTT. " +
+			"Every cue must end after it starts, start no earlier than the previous cue and end within the episode duration. " +
+			"The format is taken from the Content-Type or the file extension, or detected from the content. Files are limited to 1 MiB. " +
+			"Requires an administrator token.",
+		Tags:     []string{"subtitles"},
+		Security: openapi.Authenticated(),
+		Parameters: []*openapi.Parameter{
+			openapi.IDParam(), language,
+			openapi.QueryParam("label", "Label shown by the player, defaults to the language", &openapi.Schema{Type: "string", MaxLength: integer(100)}),
+		},
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content: map[string]*openapi.MediaType{
+				mimeWebVTT:             {Schema: file, Example: exampleTrack},
+				"application/x-subrip": {Schema: file},
+				"multipart/form-data": {Schema: &openapi.Schema{
+					Type:     "object",
+					Required: []string{"file"},
+					Properties: map[string]*openapi.Schema{
+						"file":  {Type: "string", Format: "binary"},
+						"label": {Type: "string", MaxLength: integer(100)},
+					},
+				}},
+			},
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Replaced track", schema, newSubtitleResponse(&example)),
+			http.StatusCreated:             openapi.JSON("Created track", schema, newSubtitleResponse(&example)),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'cue 312' ends at 00:24:03.000, after the end of the episode (00:24:00.000)"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "episode with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to upload subtitle"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/episodes/:id/subtitles/:language", &openapi.Operation{
+		OperationID: "deleteSubtitleV2",
+		Summary:     "Delete a subtitle track",
+		Description: "Requires an administrator token.",
+		Tags:        []string{"subtitles"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam(), language},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusForbidden:           forbidden,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "subtitle en of episode 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete subtitle"),
+		}),
+	})
+}
+
+func integer(n int) *int {
+	return &n
+}
@@ -0,0 +1,144 @@
+package subtitle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+)
+
+const (
+	queryGetByEpisodeId = `SELECT id, episode_id, language, label, format, cue_count, updated_at
+		FROM subtitles WHERE episode_id = $1 ORDER BY language`
+	queryGetTrack = `SELECT id, episode_id, language, label, format, content, cue_count, updated_at
+		FROM subtitles WHERE episode_id = $1 AND language = $2`
+	// xmax es 0 en las filas recién insertadas y distinto de 0 en las que
+	// ha actualizado ON CONFLICT.
+	queryUpsert = `INSERT INTO subtitles (episode_id, language, label, format, content, cue_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (episode_id, language) DO UPDATE
+		SET label = EXCLUDED.label, format = EXCLUDED.format, content = EXCLUDED.content,
+			cue_count = EXCLUDED.cue_count, updated_at = NOW()
+		RETURNING id, updated_at, xmax = 0`
+	queryDelete = "DELETE FROM subtitles WHERE episode_id = $1 AND language = $2"
+)
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetByEpisodeID(ctx context.Context, episodeID uint64) ([]*entities.Subtitle, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByEpisodeId", queryGetByEpisodeId)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryGetByEpisodeId, episodeID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	subtitles := []*entities.Subtitle{}
+	for rows.Next() {
+		subtitle := &entities.Subtitle{}
+		if err := rows.Scan(&subtitle.ID, &subtitle.EpisodeID, &subtitle.Language, &subtitle.Label,
+			&subtitle.Format, &subtitle.CueCount, &subtitle.UpdatedAt); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		subtitles = append(subtitles, subtitle)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return subtitles, nil
+}
+
+func (r *repository) GetTrack(ctx context.Context, episodeID uint64, language string) (*entities.Subtitle, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetTrack", queryGetTrack)
+	defer span.End()
+
+	subtitle := &entities.Subtitle{}
+	err := r.replica.QueryRowContext(ctx, queryGetTrack, episodeID, language).
+		Scan(&subtitle.ID, &subtitle.EpisodeID, &subtitle.Language, &subtitle.Label, &subtitle.Format,
+			&subtitle.Content, &subtitle.CueCount, &subtitle.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("subtitle %s of episode %d %w", language, episodeID, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return subtitle, nil
+}
+
+func (r *repository) Upsert(ctx context.Context, subtitle *entities.Subtitle) (bool, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryUpsert", queryUpsert)
+	defer span.End()
+
+	var created bool
+	err := r.db.QueryRowContext(ctx, queryUpsert, subtitle.EpisodeID, subtitle.Language, subtitle.Label,
+		subtitle.Format, subtitle.Content, subtitle.CueCount).Scan(&subtitle.ID, &subtitle.UpdatedAt, &created)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code {
+			case "23503": // el episodio no existe
+				return false, fmt.Errorf("episode with id %d %w", subtitle.EpisodeID, ex.ErrNotFound)
+			case "23514", "22001": // formato no admitido o valor demasiado largo
+				return false, &ex.ErrValidation{
+					Field:  pgErr.Column,
+					Reason: "has an unsupported value",
+				}
+			default:
+				log.Printf("Subtitle upload failed: %s", err)
+				return false, fmt.Errorf("subtitle upload failed: %w", err)
+			}
+		}
+
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (r *repository) Delete(ctx context.Context, episodeID uint64, language string) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDelete", queryDelete)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDelete, episodeID, language)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("subtitle %s of episode %d %w", language, episodeID, ex.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package subtitle
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/episode"
+	"go.opentelemetry.io/otel"
+	"strings"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/subtitle")
+
+type service struct {
+	repository        Repository
+	episodeRepository episode.Repository
+}
+
+func NewService(repository Repository, episodeRepository episode.Repository) Service {
+	return &service{
+		repository:        repository,
+		episodeRepository: episodeRepository,
+	}
+}
+
+func (s *service) GetByEpisodeID(ctx context.Context, episodeID uint64) ([]*entities.Subtitle, error) {
+	ctx, span := tracer.Start(ctx, "subtitle.Service.GetByEpisodeID")
+	defer span.End()
+
+	return s.repository.GetByEpisodeID(ctx, episodeID)
+}
+
+func (s *service) GetTrack(ctx context.Context, episodeID uint64, language string) (*entities.Subtitle, error) {
+	ctx, span := tracer.Start(ctx, "subtitle.Service.GetTrack")
+	defer span.End()
+
+	return s.repository.GetTrack(ctx, episodeID, strings.ToLower(language))
+}
+
+func (s *service) Upload(ctx context.Context, subtitle *entities.Subtitle, content []byte) (bool, error) {
+	ctx, span := tracer.Start(ctx, "subtitle.Service.Upload")
+	defer span.End()
+
+	parent, err := s.episodeRepository.GetByID(ctx, subtitle.EpisodeID)
+	if err != nil {
+		return false, err
+	}
+
+	if subtitle.Format == "" {
+		subtitle.Format = detectFormat(string(content))
+	}
+
+	var cues []cue
+	switch subtitle.Format {
+	case entities.SubtitleFormatVTT:
+		subtitle.Content, cues, err = parseWebVTT(string(content))
+	default:
+		if cues, err = parseSRT(string(content)); err == nil {
+			subtitle.Content = toWebVTT(cues)
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	duration := time.Duration(episode.IntervalSeconds(parent.Duration)) * time.Second
+	if err := validateCues(cues, duration); err != nil {
+		return false, err
+	}
+
+	subtitle.Language = strings.ToLower(subtitle.Language)
+	subtitle.Label = strings.TrimSpace(subtitle.Label)
+	if subtitle.Label == "" {
+		subtitle.Label = subtitle.Language
+	}
+	subtitle.CueCount = len(cues)
+
+	return s.repository.Upsert(ctx, subtitle)
+}
+
+func (s *service) Delete(ctx context.Context, episodeID uint64, language string) error {
+	ctx, span := tracer.Start(ctx, "subtitle.Service.Delete")
+	defer span.End()
+
+	return s.repository.Delete(ctx, episodeID, strings.ToLower(language))
+}
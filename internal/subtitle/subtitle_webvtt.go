@@ -0,0 +1,291 @@
+package subtitle
+
+import (
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// cue es un bloque de texto con el intervalo en que se muestra.
+type cue struct {
+	ID       string
+	Start    time.Duration
+	End      time.Duration
+	Settings string
+	Text     []string
+}
+
+var (
+	// entityPattern reconoce un & suelto o una referencia de carácter.
+	entityPattern = regexp.MustCompile(`&([a-zA-Z]+;|#[0-9]+;|#x[0-9a-fA-F]+;)?`)
+	// tagPattern reconoce las etiquetas que SRT y WebVTT comparten o un <
+	// suelto.
+	tagPattern = regexp.MustCompile(`<(/?[biu])>|<`)
+	// srtOnlyPattern reconoce las etiquetas <font> y las marcas de estilo de
+	// ASS ({\an8}) que algunos SRT incluyen y WebVTT no admite.
+	srtOnlyPattern = regexp.MustCompile(`(?i)</?font[^>]*>|\{\\[^}]*\}`)
+)
+
+// detectFormat distingue un WebVTT, que siempre empieza por la cabecera
+// WEBVTT, de un SRT.
+func detectFormat(content string) string {
+	if strings.HasPrefix(normalize(content), "WEBVTT") {
+		return entities.SubtitleFormatVTT
+	}
+
+	return entities.SubtitleFormatSRT
+}
+
+// normalize quita el BOM y unifica los saltos de línea.
+func normalize(content string) string {
+	content = strings.TrimPrefix(content, "\uFEFF")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	return strings.ReplaceAll(content, "\r", "\n")
+}
+
+// blocks separa el archivo en bloques delimitados por líneas en blanco.
+func blocks(content string) [][]string {
+	var list [][]string
+	var current []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				list = append(list, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		list = append(list, current)
+	}
+
+	return list
+}
+
+// parseWebVTT valida un WebVTT y devuelve sus cues junto con el archivo
+// normalizado, que se guarda tal cual para conservar STYLE y REGION.
+func parseWebVTT(content string) (string, []cue, error) {
+	if !utf8.ValidString(content) {
+		return "", nil, invalidFile("must be UTF-8")
+	}
+	content = normalize(content)
+
+	header, _, _ := strings.Cut(content, "\n")
+	if header != "WEBVTT" && !strings.HasPrefix(header, "WEBVTT ") && !strings.HasPrefix(header, "WEBVTT\t") {
+		return "", nil, invalidFile("is not a WebVTT file: it must start with WEBVTT")
+	}
+
+	var cues []cue
+	for i, block := range blocks(content) {
+		if i == 0 || isMetadata(block[0]) {
+			continue
+		}
+
+		c := cue{}
+		timing := block[0]
+		text := block[1:]
+		if !strings.Contains(timing, "-->") {
+			if len(block) < 2 || !strings.Contains(block[1], "-->") {
+				return "", nil, invalidCue(len(cues)+1, "has no timing line")
+			}
+			c.ID, timing, text = block[0], block[1], block[2:]
+		}
+
+		var err error
+		if c.Start, c.End, c.Settings, err = parseTiming(timing); err != nil {
+			return "", nil, invalidCue(len(cues)+1, err.Error())
+		}
+		c.Text = text
+		cues = append(cues, c)
+	}
+
+	return content, cues, nil
+}
+
+func isMetadata(line string) bool {
+	if strings.Contains(line, "-->") {
+		return false
+	}
+	for _, keyword := range []string{"NOTE", "STYLE", "REGION"} {
+		if line == keyword || strings.HasPrefix(line, keyword+" ") || strings.HasPrefix(line, keyword+"\t") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSRT lee un SRT. El número de cada bloque es opcional y se conserva
+// como identificador de la cue.
+func parseSRT(content string) ([]cue, error) {
+	if !utf8.ValidString(content) {
+		return nil, invalidFile("must be UTF-8")
+	}
+
+	var cues []cue
+	for _, block := range blocks(normalize(content)) {
+		c := cue{}
+		if !strings.Contains(block[0], "-->") {
+			if len(block) < 2 {
+				return nil, invalidCue(len(cues)+1, "has no timing line")
+			}
+			c.ID, block = strings.TrimSpace(block[0]), block[1:]
+		}
+
+		var err error
+		if c.Start, c.End, _, err = parseTiming(block[0]); err != nil {
+			return nil, invalidCue(len(cues)+1, err.Error())
+		}
+		for _, line := range block[1:] {
+			c.Text = append(c.Text, srtText(line))
+		}
+		cues = append(cues, c)
+	}
+
+	return cues, nil
+}
+
+// srtText adapta una línea de SRT a WebVTT: quita las etiquetas que WebVTT
+// no admite y escapa los caracteres reservados.
+func srtText(line string) string {
+	line = srtOnlyPattern.ReplaceAllString(line, "")
+	line = entityPattern.ReplaceAllStringFunc(line, func(match string) string {
+		if match == "&" {
+			return "&amp;"
+		}
+		return match
+	})
+	line = tagPattern.ReplaceAllStringFunc(line, func(match string) string {
+		if match == "<" {
+			return "&lt;"
+		}
+		return match
+	})
+
+	return strings.ReplaceAll(line, "-->", "--&gt;")
+}
+
+// toWebVTT escribe las cues como un archivo WebVTT.
+func toWebVTT(cues []cue) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+	for _, c := range cues {
+		b.WriteString("\n")
+		if c.ID != "" {
+			b.WriteString(c.ID + "\n")
+		}
+		b.WriteString(formatTimestamp(c.Start) + " --> " + formatTimestamp(c.End))
+		if c.Settings != "" {
+			b.WriteString(" " + c.Settings)
+		}
+		b.WriteString("\n")
+		for _, line := range c.Text {
+			b.WriteString(line + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// parseTiming lee "inicio --> fin [ajustes]". Acepta la coma decimal de
+// SRT además del punto de WebVTT.
+func parseTiming(line string) (time.Duration, time.Duration, string, error) {
+	left, right, found := strings.Cut(line, "-->")
+	if !found {
+		return 0, 0, "", fmt.Errorf("has no timing line")
+	}
+
+	start, err := parseTimestamp(strings.TrimSpace(left))
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	fields := strings.Fields(right)
+	if len(fields) == 0 {
+		return 0, 0, "", fmt.Errorf("has no end time")
+	}
+	end, err := parseTimestamp(fields[0])
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	return start, end, strings.Join(fields[1:], " "), nil
+}
+
+// parseTimestamp lee "hh:mm:ss.ttt" o "mm:ss.ttt".
+func parseTimestamp(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("has an invalid timestamp %q", value)
+
+	clock, millis, found := strings.Cut(strings.Replace(value, ",", ".", 1), ".")
+	if !found || len(millis) != 3 {
+		return 0, invalid
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+	if len(parts) != 3 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return 0, invalid
+	}
+
+	var values [4]int
+	for i, part := range append(parts, millis) {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, invalid
+		}
+		values[i] = n
+	}
+	if values[1] > 59 || values[2] > 59 {
+		return 0, invalid
+	}
+
+	return time.Duration(values[0])*time.Hour + time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second + time.Duration(values[3])*time.Millisecond, nil
+}
+
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// validateCues comprueba que las cues están ordenadas, que cada una termina
+// después de empezar y que ninguna termina después del episodio. Una
+// duración de 0 (desconocida) no limita el final.
+func validateCues(cues []cue, duration time.Duration) error {
+	if len(cues) == 0 {
+		return invalidFile("has no cues")
+	}
+
+	for i, c := range cues {
+		switch {
+		case c.End <= c.Start:
+			return invalidCue(i+1, "must end after it starts")
+		case i > 0 && c.Start < cues[i-1].Start:
+			return invalidCue(i+1, "starts before the previous cue")
+		case duration > 0 && c.End > duration:
+			return invalidCue(i+1, fmt.Sprintf("ends at %s, after the end of the episode (%s)",
+				formatTimestamp(c.End), formatTimestamp(duration)))
+		}
+	}
+
+	return nil
+}
+
+func invalidFile(reason string) error {
+	return &ex.ErrValidation{Field: "file", Reason: reason}
+}
+
+func invalidCue(number int, reason string) error {
+	return &ex.ErrValidation{Field: fmt.Sprintf("cue %d", number), Reason: reason}
+}
@@ -0,0 +1,185 @@
+package subtitle
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSRTToWebVTT(t *testing.T) {
+	tests := []struct {
+		name string
+		srt  string
+		want string
+	}{
+		{
+			name: "numbered cues with comma decimals",
+			srt:  "1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nTwo\r\nlines\r\n",
+			want: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.500\nHello\n\n2\n00:00:03.000 --> 00:00:04.000\nTwo\nlines\n",
+		},
+		{
+			name: "byte order mark and missing numbers",
+			srt:  "\uFEFF00:01.000 --> 00:02.000\nNo number\n",
+			want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nNo number\n",
+		},
+		{
+			name: "extra blank lines between blocks",
+			srt:  "\n\n1\n00:00:01,000 --> 00:00:02,000\nOne\n\n\n\n2\n00:00:02,000 --> 00:00:03,000\nTwo\n\n",
+			want: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nOne\n\n2\n00:00:02.000 --> 00:00:03.000\nTwo\n",
+		},
+		{
+			name: "hours above 99 minutes",
+			srt:  "1\n01:40:00,000 --> 01:40:01,001\nLate\n",
+			want: "WEBVTT\n\n1\n01:40:00.000 --> 01:40:01.001\nLate\n",
+		},
+		{
+			name: "keeps shared tags and escapes reserved characters",
+			srt:  "1\n00:00:01,000 --> 00:00:02,000\n<i>Tom & Jerry</i> &amp; 1 < 2 --> 3\n",
+			want: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\n<i>Tom &amp; Jerry</i> &amp; 1 &lt; 2 --&gt; 3\n",
+		},
+		{
+			name: "drops font tags and ASS overrides",
+			srt:  "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}<font color=\"#ffff00\">Top</font>\n",
+			want: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nTop\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cues, err := parseSRT(tt.srt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := toWebVTT(cues); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSRTErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		srt  string
+		want string
+	}{
+		{"invalid UTF-8", "1\n00:00:01,000 --> 00:00:02,000\n\xff\n", "Field 'file' must be UTF-8"},
+		{"number without timing", "1\n", "Field 'cue 1' has no timing line"},
+		{"text instead of timing", "1\nHello\n", "Field 'cue 1' has no timing line"},
+		{"missing end", "1\n00:00:01,000 -->\nHello\n", "Field 'cue 1' has no end time"},
+		{"short milliseconds", "1\n00:00:01,00 --> 00:00:02,000\n", `Field 'cue 1' has an invalid timestamp "00:00:01,00"`},
+		{"minutes out of range", "1\n00:00:01,000 --> 00:00:02,000\nOk\n\n2\n00:60:00,000 --> 01:00:01,000\n", `Field 'cue 2' has an invalid timestamp "00:60:00,000"`},
+		{"negative value", "1\n00:-1:00,000 --> 00:00:02,000\n", `Field 'cue 1' has an invalid timestamp "00:-1:00,000"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSRT(tt.srt)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWebVTT(t *testing.T) {
+	content := "WEBVTT - Episode 1\r\n\r\nNOTE translated by fans\r\n\r\nSTYLE\r\n::cue { color: yellow }\r\n\r\n" +
+		"intro\r\n00:01.000 --> 00:02.000 line:0 align:start\r\nHello\r\n\r\n00:00:03.000 --> 00:00:04.000\r\nBye\r\n"
+
+	normalized, cues, err := parseWebVTT(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := strings.ReplaceAll(content, "\r\n", "\n"); normalized != want {
+		t.Errorf("got normalized content %q, want %q", normalized, want)
+	}
+
+	want := []cue{
+		{ID: "intro", Start: time.Second, End: 2 * time.Second, Settings: "line:0 align:start", Text: []string{"Hello"}},
+		{Start: 3 * time.Second, End: 4 * time.Second, Text: []string{"Bye"}},
+	}
+	if len(cues) != len(want) {
+		t.Fatalf("got %d cues, want %d", len(cues), len(want))
+	}
+	for i, c := range cues {
+		w := want[i]
+		if c.ID != w.ID || c.Start != w.Start || c.End != w.End || c.Settings != w.Settings || len(c.Text) != 1 || c.Text[0] != w.Text[0] {
+			t.Errorf("cue %d: got %+v, want %+v", i+1, c, w)
+		}
+	}
+}
+
+func TestParseWebVTTErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"missing header", "00:01.000 --> 00:02.000\nHello\n", "Field 'file' is not a WebVTT file: it must start with WEBVTT"},
+		{"header prefix", "WEBVTTX\n", "Field 'file' is not a WebVTT file: it must start with WEBVTT"},
+		{"identifier without timing", "WEBVTT\n\nintro\nHello\n", "Field 'cue 1' has no timing line"},
+		{"invalid timestamp", "WEBVTT\n\n00:01 --> 00:02.000\n", `Field 'cue 1' has an invalid timestamp "00:01"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseWebVTT(tt.content)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		content, want string
+	}{
+		{"WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n", entities.SubtitleFormatVTT},
+		{"\uFEFFWEBVTT\n", entities.SubtitleFormatVTT},
+		{"1\n00:00:01,000 --> 00:00:02,000\nHi\n", entities.SubtitleFormatSRT},
+	}
+	for _, tt := range tests {
+		if got := detectFormat(tt.content); got != tt.want {
+			t.Errorf("detectFormat(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCues(t *testing.T) {
+	at := func(start, end time.Duration) cue {
+		return cue{Start: start * time.Second, End: end * time.Second}
+	}
+
+	tests := []struct {
+		name     string
+		cues     []cue
+		duration time.Duration
+		want     string
+	}{
+		{"valid", []cue{at(1, 2), at(2, 3), at(2, 4)}, 5 * time.Second, ""},
+		{"unknown duration", []cue{at(1, 3600)}, 0, ""},
+		{"no cues", nil, 0, "Field 'file' has no cues"},
+		{"ends before it starts", []cue{at(2, 1)}, 0, "Field 'cue 1' must end after it starts"},
+		{"empty cue", []cue{at(2, 2)}, 0, "Field 'cue 1' must end after it starts"},
+		{"out of order", []cue{at(3, 4), at(1, 2)}, 0, "Field 'cue 2' starts before the previous cue"},
+		{"after the episode", []cue{at(1, 2), at(4, 6)}, 5 * time.Second,
+			"Field 'cue 2' ends at 00:00:06.000, after the end of the episode (00:00:05.000)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCues(tt.cues, tt.duration)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package taxonomy gestiona los géneros y las etiquetas de las temporadas.
+package taxonomy
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"strings"
+)
+
+// Filter selecciona temporadas por los slugs de sus géneros y etiquetas.
+// Con MatchAll la temporada debe tenerlos todos; si no, basta con uno.
+type Filter struct {
+	Genres   []string
+	Tags     []string
+	MatchAll bool
+}
+
+// NewFilter normaliza los slugs del filtro, quitando los vacíos y los
+// repetidos.
+func NewFilter(genres, tags []string, matchAll bool) Filter {
+	return Filter{Genres: uniqueSlugs(genres), Tags: uniqueSlugs(tags), MatchAll: matchAll}
+}
+
+// Empty indica si el filtro no selecciona nada, es decir, incluye todas las
+// temporadas.
+func (f Filter) Empty() bool {
+	return len(f.Genres) == 0 && len(f.Tags) == 0
+}
+
+type Repository interface {
+	// GetAll devuelve los términos del tipo con el número de temporadas de
+	// cada uno.
+	GetAll(ctx context.Context, kind string) ([]*entities.Term, error)
+	GetByID(ctx context.Context, kind string, id uint64) (*entities.Term, error)
+	Create(ctx context.Context, term *entities.Term) error
+	Update(ctx context.Context, term *entities.Term) error
+	Delete(ctx context.Context, kind string, id uint64) error
+	// SetSeasonTerms reemplaza los términos del tipo de la temporada por los
+	// de slugs y los devuelve.
+	SetSeasonTerms(ctx context.Context, kind string, seasonID uint64, slugs []string) ([]*entities.Term, error)
+	// GetBySeasonIDs devuelve los términos del tipo de cada temporada.
+	GetBySeasonIDs(ctx context.Context, kind string, seasonIDs []uint64) (map[uint64][]*entities.Term, error)
+	// FindSeasonIDs devuelve las temporadas que cumplen el filtro.
+	FindSeasonIDs(ctx context.Context, filter Filter) ([]uint64, error)
+	// GetFacets cuenta cuántas de las temporadas tienen cada término del
+	// tipo, de más a menos.
+	GetFacets(ctx context.Context, kind string, seasonIDs []uint64) ([]*entities.Facet, error)
+}
+
+type Service interface {
+	GetAll(ctx context.Context, kind string) ([]*entities.Term, error)
+	Create(ctx context.Context, term *entities.Term) error
+	Update(ctx context.Context, id uint64, term *entities.Term) error
+	Delete(ctx context.Context, kind string, id uint64) error
+	SetSeasonTerms(ctx context.Context, kind string, seasonID uint64, slugs []string) ([]*entities.Term, error)
+}
+
+type Handler interface {
+	GetGenres(ctx *fiber.Ctx) error
+	CreateGenre(ctx *fiber.Ctx) error
+	UpdateGenre(ctx *fiber.Ctx) error
+	DeleteGenre(ctx *fiber.Ctx) error
+	SetSeasonGenres(ctx *fiber.Ctx) error
+	GetTags(ctx *fiber.Ctx) error
+	CreateTag(ctx *fiber.Ctx) error
+	UpdateTag(ctx *fiber.Ctx) error
+	DeleteTag(ctx *fiber.Ctx) error
+	SetSeasonTags(ctx *fiber.Ctx) error
+}
+
+// uniqueSlugs pasa los slugs a minúsculas y quita los vacíos y los
+// repetidos, conservando el orden.
+func uniqueSlugs(slugs []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug != "" && !seen[slug] {
+			seen[slug] = true
+			unique = append(unique, slug)
+		}
+	}
+
+	return unique
+}
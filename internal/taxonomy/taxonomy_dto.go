@@ -0,0 +1,59 @@
+package taxonomy
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+type termRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=50"`
+}
+
+type seasonTermsRequest struct {
+	// Slugs reemplaza los términos de la temporada; vacío los quita todos.
+	Slugs []string `json:"slugs"`
+}
+
+type termResponse struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// Seasons cuenta las temporadas con el término.
+	Seasons int `json:"seasons"`
+}
+
+func newTermResponse(term *entities.Term) *termResponse {
+	return &termResponse{ID: term.ID, Name: term.Name, Slug: term.Slug, Seasons: term.Seasons}
+}
+
+type termListResponse struct {
+	Data []*termResponse `json:"data"`
+}
+
+func newTermListResponse(terms []*entities.Term) *termListResponse {
+	list := &termListResponse{Data: make([]*termResponse, 0, len(terms))}
+	for _, term := range terms {
+		list.Data = append(list.Data, newTermResponse(term))
+	}
+
+	return list
+}
+
+type seasonTerm struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type seasonTermsResponse struct {
+	SeasonID uint64        `json:"season_id"`
+	Data     []*seasonTerm `json:"data"`
+}
+
+func newSeasonTermsResponse(seasonID uint64, terms []*entities.Term) *seasonTermsResponse {
+	list := &seasonTermsResponse{SeasonID: seasonID, Data: make([]*seasonTerm, 0, len(terms))}
+	for _, term := range terms {
+		list.Data = append(list.Data, &seasonTerm{ID: term.ID, Name: term.Name, Slug: term.Slug})
+	}
+
+	return list
+}
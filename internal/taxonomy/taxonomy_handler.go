@@ -0,0 +1,159 @@
+package taxonomy
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetGenres(ctx *fiber.Ctx) error {
+	return h.getAll(ctx, entities.TermGenre)
+}
+
+func (h *handler) CreateGenre(ctx *fiber.Ctx) error {
+	return h.create(ctx, entities.TermGenre)
+}
+
+func (h *handler) UpdateGenre(ctx *fiber.Ctx) error {
+	return h.update(ctx, entities.TermGenre)
+}
+
+func (h *handler) DeleteGenre(ctx *fiber.Ctx) error {
+	return h.delete(ctx, entities.TermGenre)
+}
+
+func (h *handler) SetSeasonGenres(ctx *fiber.Ctx) error {
+	return h.setSeasonTerms(ctx, entities.TermGenre)
+}
+
+func (h *handler) GetTags(ctx *fiber.Ctx) error {
+	return h.getAll(ctx, entities.TermTag)
+}
+
+func (h *handler) CreateTag(ctx *fiber.Ctx) error {
+	return h.create(ctx, entities.TermTag)
+}
+
+func (h *handler) UpdateTag(ctx *fiber.Ctx) error {
+	return h.update(ctx, entities.TermTag)
+}
+
+func (h *handler) DeleteTag(ctx *fiber.Ctx) error {
+	return h.delete(ctx, entities.TermTag)
+}
+
+func (h *handler) SetSeasonTags(ctx *fiber.Ctx) error {
+	return h.setSeasonTerms(ctx, entities.TermTag)
+}
+
+func (h *handler) getAll(ctx *fiber.Ctx, kind string) error {
+	terms, err := h.service.GetAll(ctx.UserContext(), kind)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get " + kind + "s")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newTermListResponse(terms))
+}
+
+func (h *handler) create(ctx *fiber.Ctx, kind string) error {
+	var request termRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	term := &entities.Term{Kind: kind, Name: request.Name}
+	if err := h.service.Create(ctx.UserContext(), term); err != nil {
+		return writeError(err, kind, "Failed to create "+kind)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newTermResponse(term))
+}
+
+func (h *handler) update(ctx *fiber.Ctx, kind string) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request termRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	term := &entities.Term{Kind: kind, Name: request.Name}
+	if err := h.service.Update(ctx.UserContext(), id, term); err != nil {
+		return writeError(err, kind, "Failed to update "+kind)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newTermResponse(term))
+}
+
+func (h *handler) delete(ctx *fiber.Ctx, kind string) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), kind, id); err != nil {
+		return writeError(err, kind, "Failed to delete "+kind)
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+func (h *handler) setSeasonTerms(ctx *fiber.Ctx, kind string) error {
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request seasonTermsRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	terms, err := h.service.SetSeasonTerms(ctx.UserContext(), kind, seasonID, request.Slugs)
+	if err != nil {
+		return writeError(err, kind, "Failed to save "+kind+"s of season")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newSeasonTermsResponse(seasonID, terms))
+}
+
+func writeError(err error, kind, message string) error {
+	var alreadyExists *ex.ErrAlreadyExists
+	var validation *ex.ErrValidation
+
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &alreadyExists):
+		return response.NewConflictResponse("A " + kind + " with this " + alreadyExists.Field + " already exists")
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
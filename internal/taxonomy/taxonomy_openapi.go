@@ -0,0 +1,112 @@
+package taxonomy
+
+import (
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+)
+
+// OpenAPI documenta los géneros y las etiquetas y su asignación a las
+// temporadas.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("TermV2", termResponse{})
+	list := doc.Register("TermListV2", termListResponse{})
+	request := doc.Register("TermRequestV2", termRequest{})
+	seasonTerms := doc.Register("SeasonTermsV2", seasonTermsResponse{})
+	seasonRequest := doc.Register("SeasonTermsRequestV2", seasonTermsRequest{})
+
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	forbidden := openapi.Error(http.StatusForbidden, "Administrator role required")
+
+	for _, kind := range []struct {
+		name, plural, id, title string
+		example                 termResponse
+	}{
+		{"genre", "genres", "Genre", "Genres", termResponse{ID: 1, Name: "Action", Slug: "action", Seasons: 12}},
+		{"tag", "tags", "Tag", "Tags", termResponse{ID: 1, Name: "Military", Slug: "military", Seasons: 3}},
+	} {
+		notFound := openapi.Error(http.StatusNotFound, kind.name+" with id 1 not found")
+		conflict := openapi.Error(http.StatusConflict, "A "+kind.name+" with this name already exists")
+		input := termRequest{Name: kind.example.Name}
+		example := kind.example
+
+		doc.Add(http.MethodGet, "/api/v2/"+kind.plural, &openapi.Operation{
+			OperationID: "list" + kind.title + "V2",
+			Summary:     "List " + kind.plural,
+			Description: "Sorted by name, with the number of seasons of each one.",
+			Tags:        []string{"seasons"},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON(kind.title, list, termListResponse{Data: []*termResponse{&example}}),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get "+kind.plural),
+			}),
+		})
+		doc.Add(http.MethodPost, "/api/v2/admin/"+kind.plural, &openapi.Operation{
+			OperationID: "create" + kind.id + "V2",
+			Summary:     "Create a " + kind.name,
+			Description: "The slug is derived from the name. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			RequestBody: openapi.JSONBody(request, input),
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusCreated:             openapi.JSON("Created "+kind.name, schema, termResponse{ID: 1, Name: kind.example.Name, Slug: kind.example.Slug}),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Name is required"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusConflict:            conflict,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to create "+kind.name),
+			}),
+		})
+		doc.Add(http.MethodPut, "/api/v2/admin/"+kind.plural+"/:id", &openapi.Operation{
+			OperationID: "update" + kind.id + "V2",
+			Summary:     "Rename a " + kind.name,
+			Description: "The slug changes with the name. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			RequestBody: openapi.JSONBody(request, input),
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK:                  openapi.JSON("Updated "+kind.name, schema, kind.example),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusNotFound:            notFound,
+				http.StatusConflict:            conflict,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to update "+kind.name),
+			}),
+		})
+		doc.Add(http.MethodDelete, "/api/v2/admin/"+kind.plural+"/:id", &openapi.Operation{
+			OperationID: "delete" + kind.id + "V2",
+			Summary:     "Delete a " + kind.name,
+			Description: "It is also removed from every season. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusNoContent:           openapi.NoContent("Deleted"),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusNotFound:            notFound,
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete "+kind.name),
+			}),
+		})
+		doc.Add(http.MethodPut, "/api/v2/admin/seasons/:id/"+kind.plural, &openapi.Operation{
+			OperationID: "setSeason" + kind.title + "V2",
+			Summary:     "Replace the " + kind.plural + " of a season",
+			Description: "Takes the slugs of the " + kind.plural + "; an empty list removes them all. Requires an administrator token.",
+			Tags:        []string{"admin"},
+			Security:    openapi.Authenticated(),
+			Parameters:  []*openapi.Parameter{openapi.IDParam()},
+			RequestBody: openapi.JSONBody(seasonRequest, seasonTermsRequest{Slugs: []string{kind.example.Slug}}),
+			Responses: openapi.Responses(map[int]*openapi.Response{
+				http.StatusOK: openapi.JSON("Season "+kind.plural, seasonTerms, seasonTermsResponse{
+					SeasonID: 1, Data: []*seasonTerm{{ID: 1, Name: kind.example.Name, Slug: kind.example.Slug}},
+				}),
+				http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field '"+kind.plural+"' contains unknown slugs: unknown"),
+				http.StatusUnauthorized:        unauthorized,
+				http.StatusForbidden:           forbidden,
+				http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+				http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save "+kind.plural+" of season"),
+			}),
+		})
+	}
+}
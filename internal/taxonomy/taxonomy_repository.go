@@ -0,0 +1,381 @@
+package taxonomy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"strings"
+)
+
+// Las consultas de términos se escriben una vez con %[1]s como su tabla
+// (genres o tags), %[2]s como la tabla que los enlaza con las temporadas y
+// %[3]s como su columna en ella.
+const (
+	queryGetAll = `SELECT t.id, t.name, t.slug, COUNT(l.season_id)
+		FROM %[1]s t LEFT JOIN %[2]s l ON l.%[3]s = t.id
+		GROUP BY t.id ORDER BY t.name`
+	queryGetById = `SELECT t.id, t.name, t.slug, COUNT(l.season_id)
+		FROM %[1]s t LEFT JOIN %[2]s l ON l.%[3]s = t.id
+		WHERE t.id = $1 GROUP BY t.id`
+	queryCreate     = "INSERT INTO %[1]s (name, slug) VALUES ($1, $2) RETURNING id"
+	queryUpdate     = "UPDATE %[1]s SET name = $1, slug = $2 WHERE id = $3"
+	queryDeleteById = "DELETE FROM %[1]s WHERE id = $1"
+	queryGetBySlugs = "SELECT id, name, slug FROM %[1]s WHERE slug = ANY($1) ORDER BY name"
+	// La temporada se bloquea en modo compartido para que no desaparezca
+	// mientras se enlazan sus términos.
+	queryLockSeason        = "SELECT id FROM seasons WHERE id = $1 FOR KEY SHARE"
+	queryDeleteSeasonTerms = "DELETE FROM %[2]s WHERE season_id = $1"
+	queryInsertSeasonTerms = "INSERT INTO %[2]s (season_id, %[3]s) SELECT $1, unnest($2::int[])"
+	queryGetBySeasonIds    = `SELECT l.season_id, t.id, t.name, t.slug
+		FROM %[2]s l JOIN %[1]s t ON t.id = l.%[3]s
+		WHERE l.season_id = ANY($1) ORDER BY t.name`
+	queryGetFacets = `SELECT t.slug, t.name, COUNT(*)
+		FROM %[2]s l JOIN %[1]s t ON t.id = l.%[3]s
+		WHERE l.season_id = ANY($1)
+		GROUP BY t.id ORDER BY COUNT(*) DESC, t.name`
+	// Con $3 la temporada debe tener todos los géneros de $1 y todas las
+	// etiquetas de $2; sin él, alguno de ellos.
+	queryFindSeasonIds = `SELECT s.id FROM seasons s
+		WHERE CASE WHEN $3 THEN
+			(SELECT COUNT(*) FROM season_genres l JOIN genres g ON g.id = l.genre_id
+				WHERE l.season_id = s.id AND g.slug = ANY($1)) = cardinality($1::text[])
+			AND (SELECT COUNT(*) FROM season_tags l JOIN tags t ON t.id = l.tag_id
+				WHERE l.season_id = s.id AND t.slug = ANY($2)) = cardinality($2::text[])
+		ELSE
+			EXISTS (SELECT 1 FROM season_genres l JOIN genres g ON g.id = l.genre_id
+				WHERE l.season_id = s.id AND g.slug = ANY($1))
+			OR EXISTS (SELECT 1 FROM season_tags l JOIN tags t ON t.id = l.tag_id
+				WHERE l.season_id = s.id AND t.slug = ANY($2))
+		END
+		ORDER BY s.id`
+)
+
+type kindQueries struct {
+	getAll, getById, create, update, deleteById, getBySlugs, deleteSeasonTerms, insertSeasonTerms, getBySeasonIds, getFacets string
+}
+
+var queries = map[string]kindQueries{
+	entities.TermGenre: newKindQueries("genres", "season_genres", "genre_id"),
+	entities.TermTag:   newKindQueries("tags", "season_tags", "tag_id"),
+}
+
+func newKindQueries(table, linkTable, column string) kindQueries {
+	return kindQueries{
+		getAll:            fmt.Sprintf(queryGetAll, table, linkTable, column),
+		getById:           fmt.Sprintf(queryGetById, table, linkTable, column),
+		create:            fmt.Sprintf(queryCreate, table),
+		update:            fmt.Sprintf(queryUpdate, table),
+		deleteById:        fmt.Sprintf(queryDeleteById, table),
+		getBySlugs:        fmt.Sprintf(queryGetBySlugs, table),
+		deleteSeasonTerms: fmt.Sprintf(queryDeleteSeasonTerms, table, linkTable),
+		insertSeasonTerms: fmt.Sprintf(queryInsertSeasonTerms, table, linkTable, column),
+		getBySeasonIds:    fmt.Sprintf(queryGetBySeasonIds, table, linkTable, column),
+		getFacets:         fmt.Sprintf(queryGetFacets, table, linkTable, column),
+	}
+}
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetAll(ctx context.Context, kind string) ([]*entities.Term, error) {
+	query := queries[kind].getAll
+	ctx, span := telemetry.StartQuery(ctx, "queryGetAll", query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	terms := []*entities.Term{}
+	for rows.Next() {
+		term := &entities.Term{Kind: kind}
+		if err := rows.Scan(&term.ID, &term.Name, &term.Slug, &term.Seasons); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		terms = append(terms, term)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return terms, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, kind string, id uint64) (*entities.Term, error) {
+	query := queries[kind].getById
+	ctx, span := telemetry.StartQuery(ctx, "queryGetById", query)
+	defer span.End()
+
+	term := &entities.Term{Kind: kind}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&term.ID, &term.Name, &term.Slug, &term.Seasons)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s with id %d %w", kind, id, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return term, nil
+}
+
+func (r *repository) Create(ctx context.Context, term *entities.Term) error {
+	query := queries[term.Kind].create
+	ctx, span := telemetry.StartQuery(ctx, "queryCreate", query)
+	defer span.End()
+
+	if err := r.db.QueryRowContext(ctx, query, term.Name, term.Slug).Scan(&term.ID); err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, term.Kind)
+	}
+
+	return nil
+}
+
+func (r *repository) Update(ctx context.Context, term *entities.Term) error {
+	query := queries[term.Kind].update
+	ctx, span := telemetry.StartQuery(ctx, "queryUpdate", query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, term.Name, term.Slug, term.ID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return mapError(err, term.Kind)
+	}
+
+	return checkAffected(result, term.Kind, term.ID)
+}
+
+func (r *repository) Delete(ctx context.Context, kind string, id uint64) error {
+	query := queries[kind].deleteById
+	ctx, span := telemetry.StartQuery(ctx, "queryDeleteById", query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return checkAffected(result, kind, id)
+}
+
+func (r *repository) SetSeasonTerms(ctx context.Context, kind string, seasonID uint64, slugs []string) ([]*entities.Term, error) {
+	q := queries[kind]
+	ctx, span := telemetry.StartQuery(ctx, "queryInsertSeasonTerms", q.insertSeasonTerms)
+	defer span.End()
+
+	terms, err := r.setSeasonTerms(ctx, q, kind, seasonID, slugs)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return terms, nil
+}
+
+func (r *repository) setSeasonTerms(ctx context.Context, q kindQueries, kind string, seasonID uint64, slugs []string) ([]*entities.Term, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var id uint64
+	if err := tx.QueryRowContext(ctx, queryLockSeason, seasonID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("season with id %d %w", seasonID, ex.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, q.getBySlugs, pq.Array(slugs))
+	if err != nil {
+		return nil, err
+	}
+	terms := []*entities.Term{}
+	ids := []int64{}
+	found := map[string]bool{}
+	for rows.Next() {
+		term := &entities.Term{Kind: kind}
+		if err := rows.Scan(&term.ID, &term.Name, &term.Slug); err != nil {
+			closeRows(rows)
+			return nil, err
+		}
+		terms = append(terms, term)
+		ids = append(ids, int64(term.ID))
+		found[term.Slug] = true
+	}
+	closeRows(rows)
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, slug := range slugs {
+		if !found[slug] {
+			unknown = append(unknown, slug)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &ex.ErrValidation{Field: kind + "s", Reason: "contains unknown slugs: " + strings.Join(unknown, ", ")}
+	}
+
+	if _, err := tx.ExecContext(ctx, q.deleteSeasonTerms, seasonID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q.insertSeasonTerms, seasonID, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	return terms, tx.Commit()
+}
+
+func (r *repository) GetBySeasonIDs(ctx context.Context, kind string, seasonIDs []uint64) (map[uint64][]*entities.Term, error) {
+	query := queries[kind].getBySeasonIds
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBySeasonIds", query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query, pq.Array(seasonIDs))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	terms := map[uint64][]*entities.Term{}
+	for rows.Next() {
+		var seasonID uint64
+		term := &entities.Term{Kind: kind}
+		if err := rows.Scan(&seasonID, &term.ID, &term.Name, &term.Slug); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		terms[seasonID] = append(terms[seasonID], term)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return terms, nil
+}
+
+func (r *repository) FindSeasonIDs(ctx context.Context, filter Filter) ([]uint64, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryFindSeasonIds", queryFindSeasonIds)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryFindSeasonIds, pq.Array(filter.Genres), pq.Array(filter.Tags), filter.MatchAll)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	ids := []uint64{}
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *repository) GetFacets(ctx context.Context, kind string, seasonIDs []uint64) ([]*entities.Facet, error) {
+	query := queries[kind].getFacets
+	ctx, span := telemetry.StartQuery(ctx, "queryGetFacets", query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query, pq.Array(seasonIDs))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeRows(rows)
+
+	facets := []*entities.Facet{}
+	for rows.Next() {
+		facet := &entities.Facet{}
+		if err := rows.Scan(&facet.Slug, &facet.Name, &facet.Count); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		facets = append(facets, facet)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return facets, nil
+}
+
+func closeRows(rows *sql.Rows) {
+	if err := rows.Close(); err != nil {
+		log.Printf("failed to close rows: %s", err)
+	}
+}
+
+func checkAffected(result sql.Result, kind string, id uint64) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s with id %d %w", kind, id, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+func mapError(err error, kind string) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23505": // nombre o slug duplicados
+			field := "name"
+			if strings.HasSuffix(pgErr.Constraint, "_slug_key") {
+				field = "slug"
+			}
+			return &ex.ErrAlreadyExists{
+				Field:      field,
+				Constraint: pgErr.Constraint,
+			}
+		default:
+			log.Printf("%s write failed: %s", kind, err)
+			return fmt.Errorf("%s write failed: %w", kind, err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,74 @@
+package taxonomy
+
+import (
+	"context"
+	"github.com/wicho90/anime-api/internal/entities"
+	"go.opentelemetry.io/otel"
+	"strings"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/taxonomy")
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) GetAll(ctx context.Context, kind string) ([]*entities.Term, error) {
+	ctx, span := tracer.Start(ctx, "taxonomy.Service.GetAll")
+	defer span.End()
+
+	return s.repository.GetAll(ctx, kind)
+}
+
+func (s *service) Create(ctx context.Context, term *entities.Term) error {
+	ctx, span := tracer.Start(ctx, "taxonomy.Service.Create")
+	defer span.End()
+
+	term.Name = strings.TrimSpace(term.Name)
+	term.Slug = slugify(term.Name)
+
+	return s.repository.Create(ctx, term)
+}
+
+func (s *service) Update(ctx context.Context, id uint64, term *entities.Term) error {
+	ctx, span := tracer.Start(ctx, "taxonomy.Service.Update")
+	defer span.End()
+
+	term.ID = id
+	term.Name = strings.TrimSpace(term.Name)
+	term.Slug = slugify(term.Name)
+	if err := s.repository.Update(ctx, term); err != nil {
+		return err
+	}
+
+	updated, err := s.repository.GetByID(ctx, term.Kind, id)
+	if err != nil {
+		return err
+	}
+	*term = *updated
+
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, kind string, id uint64) error {
+	ctx, span := tracer.Start(ctx, "taxonomy.Service.Delete")
+	defer span.End()
+
+	return s.repository.Delete(ctx, kind, id)
+}
+
+func (s *service) SetSeasonTerms(ctx context.Context, kind string, seasonID uint64, slugs []string) ([]*entities.Term, error) {
+	ctx, span := tracer.Start(ctx, "taxonomy.Service.SetSeasonTerms")
+	defer span.End()
+
+	return s.repository.SetSeasonTerms(ctx, kind, seasonID, uniqueSlugs(slugs))
+}
+
+// slugify pasa el nombre a minúsculas y une sus palabras con guiones.
+func slugify(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/response"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// Middleware crea un span de servidor por petición, continuando la traza
+// recibida en las cabeceras traceparent/tracestate. El contexto resultante
+// queda disponible para los handlers en ctx.UserContext().
+func Middleware(tp trace.TracerProvider) fiber.Handler {
+	tracer := tp.Tracer(instrumentationName)
+
+	return func(ctx *fiber.Ctx) error {
+		carrier := propagation.HeaderCarrier{}
+		ctx.Request().Header.VisitAll(func(key, value []byte) {
+			carrier.Set(string(key), string(value))
+		})
+		parent := otel.GetTextMapPropagator().Extract(ctx.UserContext(), carrier)
+
+		spanCtx, span := tracer.Start(parent, ctx.Method()+" "+ctx.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(ctx.Method()),
+				semconv.HTTPTarget(ctx.OriginalURL()),
+			),
+		)
+		defer span.End()
+
+		ctx.SetUserContext(spanCtx)
+		err := ctx.Next()
+
+		route := ctx.Route().Path
+		span.SetName(ctx.Method() + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
+
+		status := ctx.Response().StatusCode()
+		if err != nil {
+			status = statusFromError(err)
+		}
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
+
+// statusFromError replica la lógica del errorHandler del servidor, que se
+// ejecuta después de que el middleware haya terminado.
+func statusFromError(err error) int {
+	if seer, ok := err.(response.BaseResponse); ok {
+		return seer.GetCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	return http.StatusInternalServerError
+}
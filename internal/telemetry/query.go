@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/wicho90/anime-api/internal/ex"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryNameKey identifica la constante del repositorio que contiene la sentencia.
+const QueryNameKey = attribute.Key("db.query.name")
+
+// StartQuery abre un span de cliente para una sentencia SQL. name es el
+// nombre de la constante de la consulta (p. ej. "queryGetBySlug").
+func StartQuery(ctx context.Context, name, statement string) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, "sql "+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(statement),
+			QueryNameKey.String(name),
+		),
+	)
+}
+
+// RecordError marca el span como fallido. Los "no encontrado" se ignoran
+// porque no son fallos de la base de datos.
+func RecordError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, sql.ErrNoRows) || errors.Is(err, ex.ErrNotFound) {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
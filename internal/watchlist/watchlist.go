@@ -0,0 +1,68 @@
+// Package watchlist gestiona la lista de seguimiento de temporadas de cada
+// usuario.
+package watchlist
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/entities"
+)
+
+const (
+	SortUpdated = "updated"
+	SortTitle   = "title"
+	SortScore   = "score"
+)
+
+// Filter selecciona una página de la lista. Status vacío incluye todos los
+// estados.
+type Filter struct {
+	Status string
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+type Repository interface {
+	// GetByUserID devuelve una página de la lista del usuario y el total de
+	// entradas que cumplen el filtro.
+	GetByUserID(ctx context.Context, userID uint64, filter Filter) ([]*entities.WatchlistEntry, int, error)
+	// CountByStatus devuelve cuántas entradas tiene el usuario en cada
+	// estado.
+	CountByStatus(ctx context.Context, userID uint64) (map[string]int, error)
+	GetBySeasonID(ctx context.Context, userID, seasonID uint64) (*entities.WatchlistEntry, error)
+	// Save bloquea la entrada, o prepara una nueva si la temporada no está
+	// en la lista, le aplica fn y la guarda en la misma transacción. fn
+	// recibe la entrada con el número de episodios de la temporada.
+	Save(ctx context.Context, userID, seasonID uint64, fn func(entry *entities.WatchlistEntry, exists bool) error) (*entities.WatchlistEntry, error)
+	// SaveAll hace lo mismo que Save con varias temporadas en una sola
+	// transacción. Las entradas para las que fn devuelve false no se
+	// guardan; devuelve las guardadas.
+	SaveAll(ctx context.Context, userID uint64, seasonIDs []uint64, fn func(entry *entities.WatchlistEntry, exists bool) (bool, error)) ([]*entities.WatchlistEntry, error)
+	Delete(ctx context.Context, userID, seasonID uint64) error
+}
+
+type Service interface {
+	GetByUserID(ctx context.Context, userID uint64, filter Filter) ([]*entities.WatchlistEntry, int, error)
+	CountByStatus(ctx context.Context, userID uint64) (map[string]int, error)
+	GetBySeasonID(ctx context.Context, userID, seasonID uint64) (*entities.WatchlistEntry, error)
+	// Save crea o reemplaza la entrada. Con Status vacío se conserva el
+	// estado anterior y el progreso decide las transiciones automáticas.
+	Save(ctx context.Context, entry *entities.WatchlistEntry) (*entities.WatchlistEntry, error)
+	// Advance suma un episodio visto, añadiendo la temporada a la lista si
+	// no estaba.
+	Advance(ctx context.Context, userID, seasonID uint64) (*entities.WatchlistEntry, error)
+	// Import guarda en una transacción entradas traídas de otro servicio,
+	// conservando sus fechas. Las temporadas que ya están en la lista sólo
+	// se reemplazan con overwrite. Devuelve las entradas guardadas.
+	Import(ctx context.Context, userID uint64, entries []*entities.WatchlistEntry, overwrite bool) ([]*entities.WatchlistEntry, error)
+	Delete(ctx context.Context, userID, seasonID uint64) error
+}
+
+type Handler interface {
+	GetAll(ctx *fiber.Ctx) error
+	GetBySeasonID(ctx *fiber.Ctx) error
+	Save(ctx *fiber.Ctx) error
+	Advance(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+}
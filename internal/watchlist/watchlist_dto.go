@@ -0,0 +1,88 @@
+package watchlist
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"time"
+)
+
+// dateLayout es el formato de started_on y finished_on.
+const dateLayout = "2006-01-02"
+
+type entryRequest struct {
+	// Status vacío conserva el estado actual.
+	Status     string `json:"status"`
+	Score      int    `json:"score" validate:"min=0,max=10"`
+	Progress   int    `json:"progress" validate:"min=0"`
+	StartedOn  string `json:"started_on"`
+	FinishedOn string `json:"finished_on"`
+	Notes      string `json:"notes" validate:"max=2000"`
+}
+
+type entrySeason struct {
+	ID       uint64 `json:"id"`
+	Slug     string `json:"slug"`
+	Title    string `json:"title"`
+	Episodes int    `json:"episodes"`
+}
+
+type entryResponse struct {
+	Season     entrySeason `json:"season"`
+	Status     string      `json:"status"`
+	Score      int         `json:"score,omitempty"`
+	Progress   int         `json:"progress"`
+	StartedOn  *string     `json:"started_on"`
+	FinishedOn *string     `json:"finished_on"`
+	Notes      string      `json:"notes"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+func newEntryResponse(entry *entities.WatchlistEntry) *entryResponse {
+	return &entryResponse{
+		Season: entrySeason{
+			ID:       entry.SeasonID,
+			Slug:     entry.SeasonSlug,
+			Title:    entry.SeasonName,
+			Episodes: entry.Episodes,
+		},
+		Status:     entry.Status,
+		Score:      entry.Score,
+		Progress:   entry.Progress,
+		StartedOn:  formatDate(entry.StartedOn),
+		FinishedOn: formatDate(entry.FinishedOn),
+		Notes:      entry.Notes,
+		CreatedAt:  entry.CreatedAt,
+		UpdatedAt:  entry.UpdatedAt,
+	}
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(dateLayout)
+
+	return &formatted
+}
+
+type pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+type listResponse struct {
+	Data       []*entryResponse `json:"data"`
+	Pagination pagination       `json:"pagination"`
+	// Counts cuenta las entradas de cada estado, sin aplicar el filtro.
+	Counts map[string]int `json:"counts"`
+}
+
+func newListResponse(entries []*entities.WatchlistEntry, page pagination, counts map[string]int) *listResponse {
+	list := &listResponse{Data: make([]*entryResponse, 0, len(entries)), Pagination: page, Counts: counts}
+	for _, entry := range entries {
+		list.Data = append(list.Data, newEntryResponse(entry))
+	}
+
+	return list
+}
@@ -0,0 +1,202 @@
+package watchlist
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wicho90/anime-api/internal/auth"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/response"
+	"github.com/wicho90/anime-api/internal/validator"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type handler struct {
+	service   Service
+	validator validator.Validator
+}
+
+func NewHandler(service Service, validator validator.Validator) Handler {
+	return &handler{service: service, validator: validator}
+}
+
+func (h *handler) GetAll(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	filter := Filter{Status: ctx.Query("status"), Sort: ctx.Query("sort", SortUpdated), Limit: defaultLimit}
+	if filter.Status != "" && !validStatus(filter.Status) {
+		return response.NewBadRequestResponse("Invalid status")
+	}
+	if _, ok := orders[filter.Sort]; !ok {
+		return response.NewBadRequestResponse("Invalid sort")
+	}
+
+	page := 1
+	if value := ctx.Query("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return response.NewBadRequestResponse("Invalid page")
+		}
+		page = n
+	}
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLimit {
+			return response.NewBadRequestResponse("Invalid limit")
+		}
+		filter.Limit = n
+	}
+	filter.Offset = (page - 1) * filter.Limit
+
+	entries, total, err := h.service.GetByUserID(ctx.UserContext(), user.ID, filter)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get watchlist")
+	}
+
+	counts, err := h.service.CountByStatus(ctx.UserContext(), user.ID)
+	if err != nil {
+		return response.NewInternalServerErrorResponse("Failed to get watchlist")
+	}
+
+	meta := pagination{Page: page, Limit: filter.Limit, Total: total}
+
+	return ctx.Status(http.StatusOK).JSON(newListResponse(entries, meta, counts))
+}
+
+func (h *handler) GetBySeasonID(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	entry, err := h.service.GetBySeasonID(ctx.UserContext(), user.ID, seasonID)
+	if err != nil {
+		return writeError(err, "Failed to get watchlist entry")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEntryResponse(entry))
+}
+
+func (h *handler) Save(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	var request entryRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewBadRequestResponse("Invalid request body")
+	}
+
+	if m, err := h.validator.Validate(request); err != nil {
+		return response.NewBadRequestResponse(m)
+	}
+
+	startedOn, err := parseDate(request.StartedOn)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid started_on")
+	}
+	finishedOn, err := parseDate(request.FinishedOn)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid finished_on")
+	}
+
+	entry, err := h.service.Save(ctx.UserContext(), &entities.WatchlistEntry{
+		UserID:     user.ID,
+		SeasonID:   seasonID,
+		Status:     request.Status,
+		Score:      request.Score,
+		Progress:   request.Progress,
+		StartedOn:  startedOn,
+		FinishedOn: finishedOn,
+		Notes:      request.Notes,
+	})
+	if err != nil {
+		return writeError(err, "Failed to save watchlist entry")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEntryResponse(entry))
+}
+
+func (h *handler) Advance(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	entry, err := h.service.Advance(ctx.UserContext(), user.ID, seasonID)
+	if err != nil {
+		return writeError(err, "Failed to save watchlist entry")
+	}
+
+	return ctx.Status(http.StatusOK).JSON(newEntryResponse(entry))
+}
+
+func (h *handler) Delete(ctx *fiber.Ctx) error {
+	user, ok := auth.CurrentUser(ctx)
+	if !ok {
+		return response.NewUnauthorizedResponse("Authentication required")
+	}
+
+	seasonID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestResponse("Invalid id")
+	}
+
+	if err := h.service.Delete(ctx.UserContext(), user.ID, seasonID); err != nil {
+		return writeError(err, "Failed to delete watchlist entry")
+	}
+
+	return ctx.SendStatus(http.StatusNoContent)
+}
+
+// parseDate convierte una fecha YYYY-MM-DD; vacía es nil.
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+func writeError(err error, message string) error {
+	var validation *ex.ErrValidation
+	switch {
+	case errors.Is(err, ex.ErrNotFound):
+		return response.NewNotFoundResponse(err.Error())
+	case errors.As(err, &validation):
+		return response.NewBadRequestResponse(validation.Error())
+	}
+
+	return response.NewInternalServerErrorResponse(message)
+}
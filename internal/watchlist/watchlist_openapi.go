@@ -0,0 +1,132 @@
+package watchlist
+
+import (
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/openapi"
+	"net/http"
+	"time"
+)
+
+var (
+	startedOn  = "2026-09-20"
+	finishedOn = "2026-10-01"
+)
+
+var example = &entryResponse{
+	Season:     entrySeason{ID: 1, Slug: "shingeki-no-kyojin", Title: "shingeki no kyojin", Episodes: 25},
+	Status:     entities.WatchCompleted,
+	Score:      9,
+	Progress:   25,
+	StartedOn:  &startedOn,
+	FinishedOn: &finishedOn,
+	Notes:      "Rewatch before season 2",
+	CreatedAt:  time.Date(2026, 9, 20, 18, 0, 0, 0, time.UTC),
+	UpdatedAt:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+}
+
+// OpenAPI documenta la lista de seguimiento del usuario autenticado.
+func OpenAPI(doc *openapi.Document) {
+	schema := doc.Register("WatchlistEntryV2", entryResponse{})
+	list := doc.Register("WatchlistV2", listResponse{})
+	request := doc.Register("WatchlistEntryRequestV2", entryRequest{})
+
+	statuses := make([]any, 0, len(entities.WatchStatuses))
+	for _, status := range entities.WatchStatuses {
+		statuses = append(statuses, status)
+	}
+	unauthorized := openapi.Error(http.StatusUnauthorized, "Authentication required")
+	notFound := openapi.Error(http.StatusNotFound, "season 1 in watchlist not found")
+	transitions := "The status changes automatically with the progress: watching an episode of a planned season " +
+		"moves it to watching, watching the last episode completes it, and lowering the progress of a completed " +
+		"season without choosing a status moves it back to watching. Missing start and finish dates are set to the current day."
+
+	doc.Add(http.MethodGet, "/api/v2/watchlist", &openapi.Operation{
+		OperationID: "listWatchlistV2",
+		Summary:     "List the watchlist",
+		Description: "Returns the seasons in the list of the authenticated user and how many there are in each status.",
+		Tags:        []string{"watchlist"},
+		Security:    openapi.Authenticated(),
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParam("status", "Only entries with this status", &openapi.Schema{Type: "string", Enum: statuses}),
+			openapi.QueryParam("sort", "Order of the entries (default updated)", &openapi.Schema{
+				Type: "string", Enum: []any{SortUpdated, SortTitle, SortScore},
+			}),
+			openapi.QueryParam("page", "Page number, starting at 1", &openapi.Schema{Type: "integer"}),
+			openapi.QueryParam("limit", "Entries per page (1 to 100, default 20)", &openapi.Schema{Type: "integer"}),
+		},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK: openapi.JSON("Watchlist", list, listResponse{
+				Data:       []*entryResponse{example},
+				Pagination: pagination{Page: 1, Limit: 20, Total: 1},
+				Counts: map[string]int{
+					entities.WatchWatching: 0, entities.WatchCompleted: 1, entities.WatchOnHold: 0,
+					entities.WatchDropped: 0, entities.WatchPlanToWatch: 0,
+				},
+			}),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid status"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get watchlist"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/api/v2/watchlist/:id", &openapi.Operation{
+		OperationID: "getWatchlistEntryV2",
+		Summary:     "Get a season of the watchlist",
+		Tags:        []string{"watchlist"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Watchlist entry", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to get watchlist entry"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/api/v2/watchlist/:id", &openapi.Operation{
+		OperationID: "saveWatchlistEntryV2",
+		Summary:     "Add or update a season of the watchlist",
+		Description: "The id is the season. Without status the current one is kept (plan_to_watch for new entries); " +
+			"a score of 0 removes it and dates that are not sent are kept. Dates use the YYYY-MM-DD format. " + transitions,
+		Tags:        []string{"watchlist"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		RequestBody: openapi.JSONBody(request, entryRequest{Status: entities.WatchWatching, Score: 9, Progress: 12}),
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Saved entry", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'progress' must not be greater than the 25 episodes of the season"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save watchlist entry"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/api/v2/watchlist/:id/progress", &openapi.Operation{
+		OperationID: "advanceWatchlistEntryV2",
+		Summary:     "Mark the next episode of a season as watched",
+		Description: "Adds one to the progress, adding the season to the watchlist if it is not there. " +
+			"Seasons on hold or dropped are resumed. " + transitions,
+		Tags:       []string{"watchlist"},
+		Security:   openapi.Authenticated(),
+		Parameters: []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusOK:                  openapi.JSON("Saved entry", schema, example),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Field 'progress' is already at the last episode"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            openapi.Error(http.StatusNotFound, "season with id 1 not found"),
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to save watchlist entry"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/api/v2/watchlist/:id", &openapi.Operation{
+		OperationID: "deleteWatchlistEntryV2",
+		Summary:     "Remove a season from the watchlist",
+		Tags:        []string{"watchlist"},
+		Security:    openapi.Authenticated(),
+		Parameters:  []*openapi.Parameter{openapi.IDParam()},
+		Responses: openapi.Responses(map[int]*openapi.Response{
+			http.StatusNoContent:           openapi.NoContent("Deleted"),
+			http.StatusBadRequest:          openapi.Error(http.StatusBadRequest, "Invalid id"),
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            notFound,
+			http.StatusInternalServerError: openapi.Error(http.StatusInternalServerError, "Failed to delete watchlist entry"),
+		}),
+	})
+}
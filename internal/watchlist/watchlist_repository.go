@@ -0,0 +1,300 @@
+package watchlist
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wicho90/anime-api/database"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"github.com/wicho90/anime-api/internal/telemetry"
+	"log"
+	"sort"
+)
+
+const (
+	columns = `w.user_id, w.season_id, s.name, s.slug,
+		(SELECT COUNT(*) FROM episodes e WHERE e.season_id = s.id),
+		w.status, COALESCE(w.score, 0), w.progress, w.started_on, w.finished_on, w.notes, w.created_at, w.updated_at`
+	// La consulta de la lista lleva %s en lugar del ORDER BY, que se toma
+	// de orders.
+	queryGetByUserId = "SELECT " + columns + `, COUNT(*) OVER()
+		FROM watchlist_entries w JOIN seasons s ON s.id = w.season_id
+		WHERE w.user_id = $1 AND ($2 = '' OR w.status = $2)
+		ORDER BY %s LIMIT $3 OFFSET $4`
+	queryCountByStatus = "SELECT status, COUNT(*) FROM watchlist_entries WHERE user_id = $1 GROUP BY status"
+	queryGetBySeasonId = "SELECT " + columns + `
+		FROM watchlist_entries w JOIN seasons s ON s.id = w.season_id
+		WHERE w.user_id = $1 AND w.season_id = $2`
+	// La temporada se bloquea en modo compartido para que no desaparezca
+	// mientras se guarda la entrada.
+	queryGetSeason = `SELECT s.name, s.slug, (SELECT COUNT(*) FROM episodes e WHERE e.season_id = s.id)
+		FROM seasons s WHERE s.id = $1 FOR KEY SHARE`
+	queryLockEntry = `SELECT status, COALESCE(score, 0), progress, started_on, finished_on, notes, created_at, updated_at
+		FROM watchlist_entries WHERE user_id = $1 AND season_id = $2 FOR UPDATE`
+	querySave = `INSERT INTO watchlist_entries (user_id, season_id, status, score, progress, started_on, finished_on, notes)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6, $7, $8)
+		ON CONFLICT (user_id, season_id) DO UPDATE SET
+			status = EXCLUDED.status, score = EXCLUDED.score, progress = EXCLUDED.progress,
+			started_on = EXCLUDED.started_on, finished_on = EXCLUDED.finished_on, notes = EXCLUDED.notes,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+	queryDelete = "DELETE FROM watchlist_entries WHERE user_id = $1 AND season_id = $2"
+)
+
+var orders = map[string]string{
+	SortUpdated: "w.updated_at DESC, w.season_id",
+	SortTitle:   "s.name, s.number, w.season_id",
+	SortScore:   "w.score DESC NULLS LAST, s.name, w.season_id",
+}
+
+type repository struct {
+	db      *sql.DB
+	replica *database.Replica
+}
+
+func NewRepository(db *sql.DB, replica *database.Replica) Repository {
+	return &repository{db: db, replica: replica}
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uint64, filter Filter) ([]*entities.WatchlistEntry, int, error) {
+	query := fmt.Sprintf(queryGetByUserId, orders[filter.Sort])
+	ctx, span := telemetry.StartQuery(ctx, "queryGetByUserId", query)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, query, userID, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	entries := []*entities.WatchlistEntry{}
+	total := 0
+	for rows.Next() {
+		entry := &entities.WatchlistEntry{}
+		if err := scan(rows, entry, &total); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, 0, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func (r *repository) CountByStatus(ctx context.Context, userID uint64) (map[string]int, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryCountByStatus", queryCountByStatus)
+	defer span.End()
+
+	rows, err := r.replica.QueryContext(ctx, queryCountByStatus, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	counts := map[string]int{}
+	for _, status := range entities.WatchStatuses {
+		counts[status] = 0
+	}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *repository) GetBySeasonID(ctx context.Context, userID, seasonID uint64) (*entities.WatchlistEntry, error) {
+	ctx, span := telemetry.StartQuery(ctx, "queryGetBySeasonId", queryGetBySeasonId)
+	defer span.End()
+
+	entry := &entities.WatchlistEntry{}
+	if err := scan(r.replica.QueryRowContext(ctx, queryGetBySeasonId, userID, seasonID), entry); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("season %d in watchlist %w", seasonID, ex.ErrNotFound)
+		}
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *repository) Save(ctx context.Context, userID, seasonID uint64, fn func(entry *entities.WatchlistEntry, exists bool) error) (*entities.WatchlistEntry, error) {
+	ctx, span := telemetry.StartQuery(ctx, "querySave", querySave)
+	defer span.End()
+
+	var entry *entities.WatchlistEntry
+	err := r.transaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		entry, err = save(ctx, tx, userID, seasonID, func(entry *entities.WatchlistEntry, exists bool) (bool, error) {
+			return true, fn(entry, exists)
+		})
+		return err
+	})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, mapError(err, seasonID)
+	}
+
+	return entry, nil
+}
+
+func (r *repository) SaveAll(ctx context.Context, userID uint64, seasonIDs []uint64, fn func(entry *entities.WatchlistEntry, exists bool) (bool, error)) ([]*entities.WatchlistEntry, error) {
+	ctx, span := telemetry.StartQuery(ctx, "querySave", querySave)
+	defer span.End()
+
+	// Las entradas se bloquean siempre en el mismo orden para que dos
+	// importaciones simultáneas no se bloqueen entre sí.
+	ids := append([]uint64(nil), seasonIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	entries := []*entities.WatchlistEntry{}
+	var current uint64
+	err := r.transaction(ctx, func(tx *sql.Tx) error {
+		for _, seasonID := range ids {
+			current = seasonID
+			entry, err := save(ctx, tx, userID, seasonID, fn)
+			if err != nil {
+				return err
+			}
+			if entry != nil {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, mapError(err, current)
+	}
+
+	return entries, nil
+}
+
+func (r *repository) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// save bloquea la entrada de la temporada dentro de tx, le aplica fn y la
+// guarda si fn lo pide. Devuelve nil si no se guarda.
+func save(ctx context.Context, tx *sql.Tx, userID, seasonID uint64, fn func(entry *entities.WatchlistEntry, exists bool) (bool, error)) (*entities.WatchlistEntry, error) {
+	entry := &entities.WatchlistEntry{UserID: userID, SeasonID: seasonID}
+	err := tx.QueryRowContext(ctx, queryGetSeason, seasonID).Scan(&entry.SeasonName, &entry.SeasonSlug, &entry.Episodes)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("season with id %d %w", seasonID, ex.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	exists := true
+	err = tx.QueryRowContext(ctx, queryLockEntry, userID, seasonID).Scan(&entry.Status, &entry.Score, &entry.Progress,
+		&entry.StartedOn, &entry.FinishedOn, &entry.Notes, &entry.CreatedAt, &entry.UpdatedAt)
+	if err == sql.ErrNoRows {
+		exists = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	ok, err := fn(entry, exists)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, querySave, userID, seasonID, entry.Status, entry.Score, entry.Progress,
+		entry.StartedOn, entry.FinishedOn, entry.Notes).Scan(&entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *repository) Delete(ctx context.Context, userID, seasonID uint64) error {
+	ctx, span := telemetry.StartQuery(ctx, "queryDelete", queryDelete)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, queryDelete, userID, seasonID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("season %d in watchlist %w", seasonID, ex.ErrNotFound)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner, entry *entities.WatchlistEntry, extra ...any) error {
+	dest := append([]any{&entry.UserID, &entry.SeasonID, &entry.SeasonName, &entry.SeasonSlug, &entry.Episodes,
+		&entry.Status, &entry.Score, &entry.Progress, &entry.StartedOn, &entry.FinishedOn, &entry.Notes,
+		&entry.CreatedAt, &entry.UpdatedAt}, extra...)
+
+	return row.Scan(dest...)
+}
+
+func mapError(err error, seasonID uint64) error {
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code {
+		case "23503": // la temporada se borró durante la transacción
+			return fmt.Errorf("season with id %d %w", seasonID, ex.ErrNotFound)
+		default:
+			log.Printf("Watchlist write failed: %s", err)
+			return fmt.Errorf("watchlist write failed: %w", err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,216 @@
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"github.com/wicho90/anime-api/internal/entities"
+	"github.com/wicho90/anime-api/internal/ex"
+	"go.opentelemetry.io/otel"
+	"strings"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/wicho90/anime-api/internal/watchlist")
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) GetByUserID(ctx context.Context, userID uint64, filter Filter) ([]*entities.WatchlistEntry, int, error) {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.GetByUserID")
+	defer span.End()
+
+	return s.repository.GetByUserID(ctx, userID, filter)
+}
+
+func (s *service) CountByStatus(ctx context.Context, userID uint64) (map[string]int, error) {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.CountByStatus")
+	defer span.End()
+
+	return s.repository.CountByStatus(ctx, userID)
+}
+
+func (s *service) GetBySeasonID(ctx context.Context, userID, seasonID uint64) (*entities.WatchlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.GetBySeasonID")
+	defer span.End()
+
+	return s.repository.GetBySeasonID(ctx, userID, seasonID)
+}
+
+func (s *service) Save(ctx context.Context, entry *entities.WatchlistEntry) (*entities.WatchlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.Save")
+	defer span.End()
+
+	explicit := entry.Status != ""
+	if explicit && !validStatus(entry.Status) {
+		return nil, &ex.ErrValidation{Field: "status", Reason: "must be one of " + strings.Join(entities.WatchStatuses, ", ")}
+	}
+
+	return s.repository.Save(ctx, entry.UserID, entry.SeasonID, func(current *entities.WatchlistEntry, exists bool) error {
+		if explicit {
+			current.Status = entry.Status
+		}
+		current.Score = entry.Score
+		current.Progress = entry.Progress
+		current.Notes = strings.TrimSpace(entry.Notes)
+		// Las fechas que no se envían se conservan.
+		if entry.StartedOn != nil {
+			current.StartedOn = entry.StartedOn
+		}
+		if entry.FinishedOn != nil {
+			current.FinishedOn = entry.FinishedOn
+		}
+
+		if err := transition(current, explicit); err != nil {
+			return err
+		}
+
+		return fillDates(current, today())
+	})
+}
+
+func (s *service) Advance(ctx context.Context, userID, seasonID uint64) (*entities.WatchlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.Advance")
+	defer span.End()
+
+	return s.repository.Save(ctx, userID, seasonID, func(entry *entities.WatchlistEntry, exists bool) error {
+		if entry.Episodes > 0 && entry.Progress >= entry.Episodes {
+			return &ex.ErrValidation{Field: "progress", Reason: "is already at the last episode"}
+		}
+
+		entry.Progress++
+		// Ver un episodio retoma una temporada en pausa o abandonada.
+		if entry.Status == entities.WatchOnHold || entry.Status == entities.WatchDropped {
+			entry.Status = entities.WatchWatching
+		}
+
+		if err := transition(entry, false); err != nil {
+			return err
+		}
+
+		return fillDates(entry, today())
+	})
+}
+
+func (s *service) Import(ctx context.Context, userID uint64, entries []*entities.WatchlistEntry, overwrite bool) ([]*entities.WatchlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.Import")
+	defer span.End()
+
+	imported := map[uint64]*entities.WatchlistEntry{}
+	ids := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		if !validStatus(entry.Status) {
+			return nil, &ex.ErrValidation{Field: "status", Reason: "must be one of " + strings.Join(entities.WatchStatuses, ", ")}
+		}
+		if _, ok := imported[entry.SeasonID]; !ok {
+			ids = append(ids, entry.SeasonID)
+		}
+		imported[entry.SeasonID] = entry
+	}
+
+	return s.repository.SaveAll(ctx, userID, ids, func(current *entities.WatchlistEntry, exists bool) (bool, error) {
+		if exists && !overwrite {
+			return false, nil
+		}
+
+		entry := imported[current.SeasonID]
+		current.Status = entry.Status
+		current.Score = entry.Score
+		current.Notes = strings.TrimSpace(entry.Notes)
+		current.StartedOn = entry.StartedOn
+		current.FinishedOn = entry.FinishedOn
+		// El otro servicio puede contar más episodios que el catálogo.
+		current.Progress = entry.Progress
+		if current.Episodes > 0 && current.Progress > current.Episodes {
+			current.Progress = current.Episodes
+		}
+
+		return true, transition(current, true)
+	})
+}
+
+func (s *service) Delete(ctx context.Context, userID, seasonID uint64) error {
+	ctx, span := tracer.Start(ctx, "watchlist.Service.Delete")
+	defer span.End()
+
+	return s.repository.Delete(ctx, userID, seasonID)
+}
+
+// transition valida la entrada y aplica los cambios automáticos de estado
+// según el progreso. explicit indica si el usuario eligió el estado:
+//   - completar una temporada marca todos sus episodios como vistos;
+//   - ver algún episodio de una temporada planeada pasa a "watching";
+//   - ver el último episodio de una temporada en curso la completa;
+//   - bajar el progreso de una temporada completada, sin elegir estado, la
+//     devuelve a "watching".
+func transition(entry *entities.WatchlistEntry, explicit bool) error {
+	if entry.Status == "" {
+		entry.Status = entities.WatchPlanToWatch
+	}
+	if entry.Episodes > 0 && entry.Progress > entry.Episodes {
+		return &ex.ErrValidation{Field: "progress", Reason: fmt.Sprintf("must not be greater than the %d episodes of the season", entry.Episodes)}
+	}
+
+	if entry.Status == entities.WatchCompleted && entry.Progress < entry.Episodes {
+		if explicit {
+			entry.Progress = entry.Episodes
+		} else {
+			entry.Status = entities.WatchWatching
+			entry.FinishedOn = nil
+		}
+	}
+	if entry.Status == entities.WatchPlanToWatch && entry.Progress > 0 {
+		entry.Status = entities.WatchWatching
+	}
+	if entry.Status == entities.WatchWatching && entry.Episodes > 0 && entry.Progress == entry.Episodes {
+		entry.Status = entities.WatchCompleted
+	}
+
+	return checkDates(entry)
+}
+
+// fillDates rellena con today las fechas de inicio y fin que faltan, sin
+// que el inicio quede después del fin.
+func fillDates(entry *entities.WatchlistEntry, today time.Time) error {
+	if entry.FinishedOn == nil && entry.Status == entities.WatchCompleted {
+		entry.FinishedOn = &today
+	}
+	if entry.StartedOn == nil && (entry.Progress > 0 || entry.Status == entities.WatchCompleted) {
+		started := today
+		if entry.FinishedOn != nil && entry.FinishedOn.Before(started) {
+			started = *entry.FinishedOn
+		}
+		entry.StartedOn = &started
+	}
+
+	return checkDates(entry)
+}
+
+func checkDates(entry *entities.WatchlistEntry) error {
+	if entry.StartedOn != nil && entry.FinishedOn != nil && entry.FinishedOn.Before(*entry.StartedOn) {
+		return &ex.ErrValidation{Field: "finished_on", Reason: "must not be before started_on"}
+	}
+
+	return nil
+}
+
+func validStatus(status string) bool {
+	for _, s := range entities.WatchStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// today es la fecha actual en UTC, sin hora.
+func today() time.Time {
+	now := time.Now().UTC()
+
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
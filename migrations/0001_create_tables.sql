@@ -1,5 +1,5 @@
 
-CREATE TABLE seasons (
+CREATE TABLE IF NOT EXISTS seasons (
     id SERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
     number SMALLINT NOT NULL,
@@ -8,7 +8,7 @@ CREATE TABLE seasons (
 );
 
 
-CREATE TABLE episodes (
+CREATE TABLE IF NOT EXISTS episodes (
     id SERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
     number SMALLINT NOT NULL,
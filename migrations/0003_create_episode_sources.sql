@@ -0,0 +1,17 @@
+-- Fuentes de reproducción de cada episodio. episodes.url sigue siendo la
+-- fuente principal; estas son las réplicas por host, calidad e idioma.
+CREATE TABLE IF NOT EXISTS episode_sources (
+    id SERIAL PRIMARY KEY,
+    episode_id INTEGER NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    host VARCHAR(100) NOT NULL,
+    quality VARCHAR(10) NOT NULL CHECK (quality IN ('480p', '720p', '1080p')),
+    language VARCHAR(10) NOT NULL,
+    audio_type VARCHAR(10) NOT NULL CHECK (audio_type IN ('sub', 'dub', 'raw')),
+    url VARCHAR(2048) NOT NULL,
+    priority SMALLINT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (episode_id, url)
+);
+
+CREATE INDEX IF NOT EXISTS episode_sources_episode_id_idx ON episode_sources (episode_id);
@@ -0,0 +1,14 @@
+-- Pistas de subtítulos de cada episodio, una por idioma. content siempre es
+-- WebVTT; format recuerda el formato en que se subió la pista.
+CREATE TABLE IF NOT EXISTS subtitles (
+    id SERIAL PRIMARY KEY,
+    episode_id INTEGER NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('vtt', 'srt')),
+    content TEXT NOT NULL,
+    cue_count INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (episode_id, language)
+);
@@ -0,0 +1,15 @@
+-- Imágenes subidas de temporadas y episodios, una por propietario.
+-- storage_key es el prefijo de los objetos en el almacenamiento: el
+-- original y las variantes redimensionadas cuelgan de él.
+CREATE TABLE IF NOT EXISTS images (
+    id SERIAL PRIMARY KEY,
+    season_id INTEGER UNIQUE REFERENCES seasons(id) ON DELETE CASCADE,
+    episode_id INTEGER UNIQUE REFERENCES episodes(id) ON DELETE CASCADE,
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((season_id IS NULL) <> (episode_id IS NULL))
+);
@@ -0,0 +1,17 @@
+-- Resultado de la última comprobación de cada URL guardada. kind indica la
+-- tabla de la que sale la URL ("episode", "source" o "season_image") y
+-- owner_id la fila. failures cuenta las comprobaciones fallidas seguidas
+-- de la misma URL.
+CREATE TABLE IF NOT EXISTS link_checks (
+    kind VARCHAR(20) NOT NULL,
+    owner_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    broken BOOLEAN NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (kind, owner_id)
+);
+
+CREATE INDEX IF NOT EXISTS link_checks_broken_idx ON link_checks (failures DESC, checked_at DESC) WHERE broken;
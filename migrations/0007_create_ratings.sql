@@ -0,0 +1,26 @@
+-- Puntuaciones de 1 a 10 de los usuarios, una por usuario y temporada o
+-- episodio.
+CREATE TABLE IF NOT EXISTS ratings (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    season_id INTEGER REFERENCES seasons(id) ON DELETE CASCADE,
+    episode_id INTEGER REFERENCES episodes(id) ON DELETE CASCADE,
+    score SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 10),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, season_id),
+    UNIQUE (user_id, episode_id),
+    CHECK ((season_id IS NULL) <> (episode_id IS NULL))
+);
+
+-- Agregado de las puntuaciones de cada temporada o episodio. Se actualiza
+-- en la misma transacción que cada puntuación para no recalcularlo al leer.
+-- histogram[i] cuenta las puntuaciones i.
+CREATE TABLE IF NOT EXISTS rating_aggregates (
+    season_id INTEGER UNIQUE REFERENCES seasons(id) ON DELETE CASCADE,
+    episode_id INTEGER UNIQUE REFERENCES episodes(id) ON DELETE CASCADE,
+    count INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    histogram INTEGER[] NOT NULL DEFAULT '{0,0,0,0,0,0,0,0,0,0}',
+    CHECK ((season_id IS NULL) <> (episode_id IS NULL))
+);
@@ -0,0 +1,35 @@
+-- Comentarios de los episodios. parent_id enlaza las respuestas con el
+-- comentario al que responden. status puede ser "visible", "pending"
+-- (pendiente de moderación), "hidden" y "removed" (por un moderador) o
+-- "deleted" (por su autor). reply_count y report_count se mantienen al
+-- insertar respuestas y denuncias.
+CREATE TABLE IF NOT EXISTS comments (
+    id SERIAL PRIMARY KEY,
+    episode_id INTEGER NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    spoiler BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'visible'
+        CHECK (status IN ('visible', 'pending', 'hidden', 'removed', 'deleted')),
+    reply_count INTEGER NOT NULL DEFAULT 0,
+    report_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMPTZ,
+    moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS comments_episode_idx ON comments (episode_id, created_at DESC) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_parent_idx ON comments (parent_id, created_at);
+CREATE INDEX IF NOT EXISTS comments_queue_idx ON comments (report_count DESC, created_at)
+    WHERE status = 'pending' OR report_count > 0;
+
+-- Una denuncia por usuario y comentario.
+CREATE TABLE IF NOT EXISTS comment_reports (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(200) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
@@ -0,0 +1,20 @@
+-- Lista de seguimiento de cada usuario: una entrada por temporada con su
+-- estado ("watching", "plan_to_watch", "completed", "on_hold" o
+-- "dropped"), la puntuación personal, los episodios vistos y las fechas de
+-- inicio y fin.
+CREATE TABLE IF NOT EXISTS watchlist_entries (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('watching', 'plan_to_watch', 'completed', 'on_hold', 'dropped')),
+    score SMALLINT CHECK (score BETWEEN 1 AND 10),
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0),
+    started_on DATE,
+    finished_on DATE,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, season_id)
+);
+
+CREATE INDEX IF NOT EXISTS watchlist_entries_user_status_idx ON watchlist_entries (user_id, status);
@@ -0,0 +1,9 @@
+-- Identificadores de las temporadas en otros servicios, como MyAnimeList,
+-- para emparejar las listas que se importan de ellos.
+CREATE TABLE IF NOT EXISTS season_external_ids (
+    provider VARCHAR(30) NOT NULL,
+    external_id VARCHAR(50) NOT NULL,
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    PRIMARY KEY (provider, external_id),
+    UNIQUE (provider, season_id)
+);
@@ -0,0 +1,28 @@
+-- Géneros y etiquetas de las temporadas. Una temporada puede tener varios
+-- de cada; el slug es el valor que usan los filtros del listado.
+CREATE TABLE IF NOT EXISTS genres (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    slug VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    slug VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS season_genres (
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    genre_id INTEGER NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+    PRIMARY KEY (season_id, genre_id)
+);
+
+CREATE TABLE IF NOT EXISTS season_tags (
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (season_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS season_genres_genre_id_idx ON season_genres (genre_id);
+CREATE INDEX IF NOT EXISTS season_tags_tag_id_idx ON season_tags (tag_id);
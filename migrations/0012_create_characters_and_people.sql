@@ -0,0 +1,27 @@
+-- Personajes, personas (actores de voz) y los papeles que las enlazan: qué
+-- persona da voz a qué personaje, en qué idioma y en qué temporada.
+CREATE TABLE IF NOT EXISTS characters (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_url VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS people (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_url VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS voice_roles (
+    id SERIAL PRIMARY KEY,
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    character_id INTEGER NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    person_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL,
+    UNIQUE (season_id, character_id, person_id, language)
+);
+
+CREATE INDEX IF NOT EXISTS voice_roles_character_id_idx ON voice_roles (character_id);
+CREATE INDEX IF NOT EXISTS voice_roles_person_id_idx ON voice_roles (person_id);
@@ -0,0 +1,25 @@
+-- Estudios de cada temporada y puestos del equipo de cada episodio. Las
+-- personas de los créditos son las mismas que las del reparto.
+CREATE TABLE IF NOT EXISTS studios (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    slug VARCHAR(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS season_studios (
+    season_id INTEGER NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    studio_id INTEGER NOT NULL REFERENCES studios(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('animation', 'production')),
+    PRIMARY KEY (season_id, studio_id, role)
+);
+
+CREATE TABLE IF NOT EXISTS episode_credits (
+    id SERIAL PRIMARY KEY,
+    episode_id INTEGER NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    person_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('director', 'storyboard', 'script', 'animation_director')),
+    UNIQUE (episode_id, person_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS season_studios_studio_id_idx ON season_studios (studio_id);
+CREATE INDEX IF NOT EXISTS episode_credits_person_id_idx ON episode_credits (person_id);
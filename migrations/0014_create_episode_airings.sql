@@ -0,0 +1,10 @@
+-- Fecha y hora de emisión programada de cada episodio. time_zone es la zona
+-- horaria de la emisión (por ejemplo Asia/Tokyo); se usa para mover la
+-- emisión días enteros sin cambiar la hora local.
+CREATE TABLE IF NOT EXISTS episode_airings (
+    episode_id INTEGER PRIMARY KEY REFERENCES episodes(id) ON DELETE CASCADE,
+    airs_at TIMESTAMPTZ NOT NULL,
+    time_zone VARCHAR(64) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS episode_airings_airs_at_idx ON episode_airings (airs_at);
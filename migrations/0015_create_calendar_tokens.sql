@@ -0,0 +1,12 @@
+-- Revisión de cada emisión para los calendarios iCalendar: sequence cuenta
+-- los cambios de fecha y updated_at guarda el último.
+ALTER TABLE episode_airings ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE episode_airings ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- Token del calendario personal de cada usuario. Es distinto del token de
+-- la API porque viaja en la URL del calendario; sólo se guarda su hash.
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,6 @@
+-- Fecha de publicación de cada episodio, que ordena los feeds RSS y Atom.
+-- Los episodios existentes toman la fecha de la migración.
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS episodes_published_at_idx ON episodes (published_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS episodes_season_published_at_idx ON episodes (season_id, published_at DESC, id DESC);
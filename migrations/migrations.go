@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+const (
+	queryCreateTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+	queryGetApplied = "SELECT version FROM schema_migrations"
+	queryInsert     = "INSERT INTO schema_migrations (version) VALUES ($1)"
+)
+
+// Versions devuelve los nombres de los archivos de migración embebidos, en
+// el orden en que deben aplicarse.
+func Versions() ([]string, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	versions := make([]string, 0, len(names))
+	for _, name := range names {
+		versions = append(versions, strings.TrimSuffix(name, ".sql"))
+	}
+
+	return versions, nil
+}
+
+// Pending devuelve las migraciones que todavía no se han aplicado en db.
+func Pending(ctx context.Context, db *sql.DB) ([]string, error) {
+	versions, err := Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]bool{}
+	rows, err := db.QueryContext(ctx, queryGetApplied)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %s", err)
+		}
+	}(rows)
+
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	var pending []string
+	for _, version := range versions {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+
+	return pending, nil
+}
+
+// Up aplica, cada una en su propia transacción, las migraciones pendientes.
+func Up(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, queryCreateTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	pending, err := Pending(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range pending {
+		if err := apply(ctx, db, version); err != nil {
+			return err
+		}
+		log.Printf("Applied migration %s", version)
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.DB, version string) error {
+	content, err := files.ReadFile(version + ".sql")
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		return fmt.Errorf("migration %s failed: %w", version, err)
+	}
+	if _, err := tx.ExecContext(ctx, queryInsert, version); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", version, err)
+	}
+
+	return tx.Commit()
+}